@@ -0,0 +1,106 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto copies the receiver into out
+func (in *LaunchKitConfigSpec) DeepCopyInto(out *LaunchKitConfigSpec) {
+	*out = *in
+	in.Config.DeepCopyInto(&out.Config)
+	if in.Plugins != nil {
+		out.Plugins = make([]string, len(in.Plugins))
+		copy(out.Plugins, in.Plugins)
+	}
+	if in.ResyncPeriod != nil {
+		out.ResyncPeriod = new(metav1.Duration)
+		*out.ResyncPeriod = *in.ResyncPeriod
+	}
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *LaunchKitConfigStatus) DeepCopyInto(out *LaunchKitConfigStatus) {
+	*out = *in
+	if in.Profiles != nil {
+		out.Profiles = make([]string, len(in.Profiles))
+		copy(out.Profiles, in.Profiles)
+	}
+	if in.LastReconcileTime != nil {
+		out.LastReconcileTime = in.LastReconcileTime.DeepCopy()
+	}
+	if in.Conditions != nil {
+		out.Conditions = make([]metav1.Condition, len(in.Conditions))
+		for i := range in.Conditions {
+			in.Conditions[i].DeepCopyInto(&out.Conditions[i])
+		}
+	}
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *LaunchKitConfig) DeepCopyInto(out *LaunchKitConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy returns a copy of the receiver
+func (in *LaunchKitConfig) DeepCopy() *LaunchKitConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LaunchKitConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object
+func (in *LaunchKitConfig) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *LaunchKitConfigList) DeepCopyInto(out *LaunchKitConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]LaunchKitConfig, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy returns a copy of the receiver
+func (in *LaunchKitConfigList) DeepCopy() *LaunchKitConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(LaunchKitConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object
+func (in *LaunchKitConfigList) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Condition types of LaunchKitConfig, one per workflow phase, and Ready once every phase succeeded
+const (
+	ConditionDiscovered       = "Discovered"
+	ConditionProfilesSelected = "ProfilesSelected"
+	ConditionRendered         = "Rendered"
+	ConditionDeployed         = "Deployed"
+	ConditionVerified         = "Verified"
+	ConditionReady            = "Ready"
+)
+
+// LaunchKitConfigSpec is the configuration the controller discovers, renders and deploys
+type LaunchKitConfigSpec struct {
+	// Config holds the l8k configuration, with the fields of l8k-config.yaml. It is merged over the defaults of the
+	// controller. The clusterConfig and profile sections are ignored: the cluster is rediscovered on every
+	// reconciliation and the profiles are selected with Profile.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Config runtime.RawExtension `json:"config,omitempty"`
+
+	// Profile selects the profile of every plugin
+	Profile ProfileSelection `json:"profile"`
+
+	// Plugins are the enabled plugins, network-operator if empty
+	Plugins []string `json:"plugins,omitempty"`
+
+	// Verify runs the verification tests after every deployment
+	Verify bool `json:"verify,omitempty"`
+
+	// ResyncPeriod is the interval between reconciliations, 10m if unset
+	ResyncPeriod *metav1.Duration `json:"resyncPeriod,omitempty"`
+}
+
+// ProfileSelection holds the profile requirements, like the l8k command line flags
+type ProfileSelection struct {
+	Fabric         string `json:"fabric,omitempty"`         // infiniband or ethernet
+	DeploymentType string `json:"deploymentType,omitempty"` // sriov, rdma_shared or host_device
+	Multirail      bool   `json:"multirail,omitempty"`
+	SpectrumX      bool   `json:"spectrumX,omitempty"`
+	Ai             bool   `json:"ai,omitempty"`
+	GPUDirect      string `json:"gpuDirect,omitempty"` // none, rdma or storage, GPU Operator profile
+}
+
+// LaunchKitConfigStatus is the outcome of the last reconciliation
+type LaunchKitConfigStatus struct {
+	// ObservedGeneration is the generation of the spec last reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Profiles are the names of the deployed profiles
+	Profiles []string `json:"profiles,omitempty"`
+	// LastReconcileTime is the time the last reconciliation ended
+	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
+	// Conditions of the workflow phases
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// LaunchKitConfig is reconciled by the l8k controller: the cluster is periodically rediscovered and the selected
+// profiles are rendered and applied
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+type LaunchKitConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LaunchKitConfigSpec   `json:"spec,omitempty"`
+	Status LaunchKitConfigStatus `json:"status,omitempty"`
+}
+
+// LaunchKitConfigList contains a list of LaunchKitConfig
+// +kubebuilder:object:root=true
+type LaunchKitConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LaunchKitConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LaunchKitConfig{}, &LaunchKitConfigList{})
+}
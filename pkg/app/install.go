@@ -0,0 +1,70 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	pluginapi "github.com/nvidia/k8s-launch-kit/pkg/plugin"
+)
+
+// installOperators installs or upgrades the operators of the enabled plugins implementing Installer, in plugin order, before
+// discovery, once the preflight checks found the permissions of the installations. The config is the one discovery or the
+// deployment reads, the profile requirements are those of the config, else of the command line options, else unknown until
+// the LLM-assisted selection.
+func (l *Launcher) installOperators(ctx context.Context, profilesConfiguredInCmd bool) error {
+	configPath := l.options.UserConfig
+	if configPath == "" {
+		configPath = defaultsPath
+	}
+	cfg, err := config.LoadFullConfig(configPath, l.logger)
+	if err != nil {
+		return fmt.Errorf("failed to load config from %s: %w", configPath, err)
+	}
+	l.applyTimeouts(cfg)
+
+	requirements := cfg.Profile
+	if requirements == nil && profilesConfiguredInCmd {
+		requirements = &config.Profile{}
+		for _, plugin := range l.orderedPlugins() {
+			if err := plugin.BuildProfileFromOptions(ctx, l.options, requirements); err != nil {
+				return fmt.Errorf("failed to build profile for plugin %s: %w", plugin.GetName(), err)
+			}
+		}
+	}
+
+	// The requirements are passed to the permission checks in the profile of the config
+	cfg.Profile = requirements
+	if err := l.preflight(ctx, []string{pluginapi.PhaseInstall}, nil, cfg); err != nil {
+		return err
+	}
+
+	for _, plugin := range l.orderedPlugins() {
+		installer, ok := plugin.(pluginapi.Installer)
+		if !ok {
+			l.logger.V(1).Info("Plugin does not install its operator, skipping", "plugin", plugin.GetName())
+			continue
+		}
+		l.logger.Info("Installing operator", "plugin", plugin.GetName())
+		if err := installer.InstallOperator(ctx, requirements, cfg, l.kubeClient, l.clientOptions()); err != nil {
+			return fmt.Errorf("failed to install the operator of plugin %s: %w", plugin.GetName(), err)
+		}
+	}
+	return nil
+}
@@ -17,32 +17,39 @@
 package app
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/go-logr/logr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
-	"context"
-
 	"github.com/nvidia/k8s-launch-kit/pkg/config"
 	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
 	"github.com/nvidia/k8s-launch-kit/pkg/llm"
 	applog "github.com/nvidia/k8s-launch-kit/pkg/log"
-	"github.com/nvidia/k8s-launch-kit/pkg/networkoperatorplugin"
 	"github.com/nvidia/k8s-launch-kit/pkg/options"
-	"github.com/nvidia/k8s-launch-kit/pkg/plugin"
+	pluginapi "github.com/nvidia/k8s-launch-kit/pkg/plugin"
 	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
 	"gopkg.in/yaml.v2"
 )
 
+// defaultsPath is the config discovery starts from
+const defaultsPath = "l8k-config.yaml"
+
 // Launcher represents the main application launcher
 type Launcher struct {
 	options    options.Options
 	logger     logr.Logger
-	plugins    map[string]plugin.Plugin
+	plugins    map[string]pluginapi.Plugin
+	order      []string // enabled plugin names, dependencies first
 	kubeClient client.Client
 }
 
@@ -51,53 +58,134 @@ func New(options options.Options) *Launcher {
 	l := &Launcher{
 		options: options,
 		logger:  log.Log,
-		plugins: make(map[string]plugin.Plugin),
+		plugins: make(map[string]pluginapi.Plugin),
 	}
 
 	return l
 }
 
-// Run executes the main application logic with the 3-phase workflow
-func (l *Launcher) Run() error {
+// Run executes the main application logic with the 3-phase workflow.
+// Cancelling ctx aborts any pending wait; discovery objects are still cleaned up.
+func (l *Launcher) Run(ctx context.Context) error {
 	if l.options.LogLevel != "" {
 		if err := applog.SetLogLevel(l.options.LogLevel); err != nil {
 			return fmt.Errorf("failed to set log level: %w", err)
 		}
 	}
 
-	for _, plugin := range l.options.EnabledPlugins {
-		switch plugin {
-		case networkoperatorplugin.PluginName:
-			l.plugins[plugin] = &networkoperatorplugin.NetworkOperatorPlugin{}
-		default:
-			err := fmt.Errorf("unknown plugin: %s", plugin)
-			l.logger.Error(err, "Skipping plugin")
-			return err
-		}
+	if err := l.loadPlugins(ctx, l.options.EnabledPlugins); err != nil {
+		return err
 	}
 
-	if l.options.Kubeconfig != "" {
-		k8sClient, err := kubeclient.New(l.options.Kubeconfig)
+	if l.needsCluster() {
+		k8sClient, err := kubeclient.New(l.clientOptions())
 		if err != nil {
 			return fmt.Errorf("failed to create k8s client: %w", err)
 		}
 		l.kubeClient = k8sClient
 	}
 
-	if err := l.executeWorkflow(); err != nil {
+	if l.options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, l.options.Timeout)
+		defer cancel()
+	}
+
+	if err := l.executeWorkflow(ctx); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// loadPlugins instantiates the built-in or external plugins with the given names and orders them by dependencies
+func (l *Launcher) loadPlugins(ctx context.Context, names []string) error {
+	for _, plugin := range names {
+		if registration, ok := pluginapi.Lookup(plugin); ok {
+			l.plugins[plugin] = registration.New()
+		} else {
+			path, err := pluginapi.FindExternal(plugin, l.options.PluginsDir)
+			if err != nil {
+				err = fmt.Errorf("unknown plugin: %s: %w", plugin, err)
+				l.logger.Error(err, "Skipping plugin")
+				return err
+			}
+			external, err := pluginapi.NewExternal(ctx, path, l.clientOptions())
+			if err != nil {
+				return fmt.Errorf("failed to load external plugin %s: %w", plugin, err)
+			}
+			if external.GetName() != plugin {
+				return fmt.Errorf("external plugin %s reports a different name: %s", path, external.GetName())
+			}
+			l.plugins[plugin] = external
+		}
+
+		if err := pluginapi.CheckCompatibility(l.plugins[plugin]); err != nil {
+			return err
+		}
+	}
+
+	order, err := pluginapi.Order(names, func(name string) []string { return pluginapi.DependsOn(l.plugins[name]) })
+	if err != nil {
+		return err
+	}
+	l.order = order
+	l.logger.V(1).Info("Plugin order", "plugins", l.order)
+	return nil
+}
+
+// needsCluster returns whether the workflow accesses the cluster
+func (l *Launcher) needsCluster() bool {
+	if l.options.Kubeconfig != "" || l.options.DiscoverClusterConfig || l.options.Deploy || l.options.Verify || l.options.Status || l.options.Watch || l.options.Preflight || l.options.InstallOperators {
+		return true
+	}
+	for _, name := range l.options.EnabledPlugins {
+		if registration, ok := pluginapi.Lookup(name); ok && registration.NeedsKubeconfig {
+			return true
+		}
+	}
+	return false
+}
+
+// clientOptions returns the cluster access options of the Kubernetes clients
+func (l *Launcher) clientOptions() kubeclient.Options {
+	return ClientOptions(l.options)
+}
+
+// ClientOptions returns the cluster access options of the Kubernetes clients selected by the options
+func ClientOptions(o options.Options) kubeclient.Options {
+	return kubeclient.Options{
+		Kubeconfig: o.Kubeconfig,
+		Context:    o.Context,
+		As:         o.As,
+		AsGroups:   o.AsGroups,
+		QPS:        o.KubeAPIQPS,
+		Burst:      o.KubeAPIBurst,
+	}
+}
+
 // executeWorkflow executes the main 3-phase workflow
-func (l *Launcher) executeWorkflow() error {
+func (l *Launcher) executeWorkflow(ctx context.Context) error {
 	l.logger.Info("Starting l8k workflow")
 
+	profilesConfiguredInCmd := true
+	for _, plugin := range l.orderedPlugins() {
+		if !plugin.ProfileConfiguredInCmd(ctx, l.options) {
+			profilesConfiguredInCmd = false
+			break
+		}
+	}
+
+	// Phase 0: Operator installation, discovery needs the operators running
+	if l.options.InstallOperators {
+		if err := l.installOperators(ctx, profilesConfiguredInCmd); err != nil {
+			return fmt.Errorf("operator installation failed: %w", err)
+		}
+	}
+
 	configPath := ""
 	if l.options.DiscoverClusterConfig {
-		if err := l.discoverClusterConfig(); err != nil {
+		if err := l.discoverClusterConfig(ctx); err != nil {
 			return fmt.Errorf("cluster discovery failed: %w", err)
 		}
 
@@ -106,14 +194,6 @@ func (l *Launcher) executeWorkflow() error {
 		configPath = l.options.UserConfig
 	}
 
-	profilesConfiguredInCmd := true
-	for _, plugin := range l.plugins {
-		if !plugin.ProfileConfiguredInCmd(l.options) {
-			profilesConfiguredInCmd = false
-			break
-		}
-	}
-
 	if !profilesConfiguredInCmd && l.options.Prompt == "" {
 		l.logger.Info("Profiles are not configured for every plugin, skipping deployment files generation")
 		return nil
@@ -123,31 +203,92 @@ func (l *Launcher) executeWorkflow() error {
 	if err != nil {
 		return fmt.Errorf("failed to load full config: %w", err)
 	}
+	l.applyTimeouts(fullConfig)
+	if l.options.ForceConflicts {
+		if fullConfig.Deployment == nil {
+			fullConfig.Deployment = &config.DeploymentConfig{}
+		}
+		fullConfig.Deployment.ForceConflicts = true
+	}
+
+	foundProfiles, err := l.selectProfiles(ctx, fullConfig, profilesConfiguredInCmd)
+	if err != nil {
+		return err
+	}
+
+	if l.options.Status {
+		if err := l.preflight(ctx, []string{pluginapi.PhaseStatus}, foundProfiles, fullConfig); err != nil {
+			return err
+		}
+		return l.reportStatus(ctx, foundProfiles, fullConfig)
+	}
+
+	for _, profile := range foundProfiles {
+		l.logger.Info("Generating deployment files for profile", "profile", profile.Name)
+
+		if err := l.generateDeploymentFiles(ctx, &profile, fullConfig); err != nil {
+			return fmt.Errorf("deployment files generation failed: %w", err)
+		}
+	}
 
+	// The permissions of all the phases run on the cluster are checked before the first one
+	if err := l.preflight(ctx, l.clusterPhases(), foundProfiles, fullConfig); err != nil {
+		return err
+	}
+	if l.options.Preflight {
+		l.logger.Info("Preflight checks completed, nothing was deployed")
+		return nil
+	}
+
+	// Phase 3: Cluster Deployment
+	if l.options.Deploy {
+		if err := l.deploy(ctx, foundProfiles, fullConfig, 0); err != nil {
+			return fmt.Errorf("deployment failed: %w", err)
+		}
+	}
+
+	// Phase 4: Deployment Verification
+	if l.options.Verify {
+		if err := l.verifyDeployment(ctx, foundProfiles, fullConfig); err != nil {
+			return fmt.Errorf("verification failed: %w", err)
+		}
+	}
+
+	if l.options.Watch {
+		return l.watch(ctx, foundProfiles, fullConfig)
+	}
+
+	l.logger.Info("l8k workflow completed successfully")
+	return nil
+}
+
+// selectProfiles returns the applicable profile of every plugin, in plugin order. The profile requirements are read from
+// the config, or else built from the command line options or from the LLM-assisted prompt.
+func (l *Launcher) selectProfiles(ctx context.Context, fullConfig *config.LaunchKubernetesConfig, profilesConfiguredInCmd bool) ([]profiles.Profile, error) {
 	if fullConfig.Profile == nil {
 		fullConfig.Profile = &config.Profile{}
 
 		if profilesConfiguredInCmd {
-			for _, plugin := range l.plugins {
-				if err := plugin.BuildProfileFromOptions(l.options, fullConfig.Profile); err != nil {
-					return fmt.Errorf("failed to build profile for plugin %s: %w", plugin.GetName(), err)
+			for _, plugin := range l.orderedPlugins() {
+				if err := plugin.BuildProfileFromOptions(ctx, l.options, fullConfig.Profile); err != nil {
+					return nil, fmt.Errorf("failed to build profile for plugin %s: %w", plugin.GetName(), err)
 				}
 			}
 		} else if l.options.Prompt != "" {
 			l.logger.Info("Selecting a profile using LLM-assisted prompt")
 
-			prompt, err := llm.SelectPrompt(l.options.Prompt, *fullConfig.ClusterConfig, l.options.LLMApiKey, l.options.LLMApiUrl, l.options.LLMVendor)
+			prompt, err := llm.SelectPrompt(ctx, l.options.Prompt, *fullConfig.ClusterConfig, l.options.LLMApiKey, l.options.LLMApiUrl, l.options.LLMVendor)
 			if err != nil {
-				return fmt.Errorf("failed to select prompt: %w", err)
+				return nil, fmt.Errorf("failed to select prompt: %w", err)
 			}
 			confidence := prompt["confidence"]
 			if confidence == "low" {
-				return fmt.Errorf("couldn't select a deployment profile based on the user prompt. Try again with a different prompt or use the cli flags (--fabric, --deployment-type, --multirail) to select the profile manually. Reason: %s", prompt["reasoning"])
+				return nil, fmt.Errorf("couldn't select a deployment profile based on the user prompt. Try again with a different prompt or use the cli flags (--fabric, --deployment-type, --multirail) to select the profile manually. Reason: %s", prompt["reasoning"])
 			}
 
-			for _, plugin := range l.plugins {
-				if err := plugin.BuildProfileFromLLMResponse(prompt, fullConfig.Profile); err != nil {
-					return fmt.Errorf("failed to build profile for plugin %s: %w", plugin.GetName(), err)
+			for _, plugin := range l.orderedPlugins() {
+				if err := plugin.BuildProfileFromLLMResponse(ctx, prompt, fullConfig.Profile); err != nil {
+					return nil, fmt.Errorf("failed to build profile for plugin %s: %w", plugin.GetName(), err)
 				}
 			}
 
@@ -159,43 +300,99 @@ func (l *Launcher) executeWorkflow() error {
 				"ai", fullConfig.Profile.Ai,
 				"reasoning", prompt["reasoning"])
 		} else {
-			return fmt.Errorf("no profile configured in the command line and no prompt provided")
+			return nil, fmt.Errorf("no profile configured in the command line and no prompt provided")
 		}
 	}
 
 	foundProfiles := []profiles.Profile{}
-	for pluginName, plugin := range l.plugins {
-		profile, err := profiles.FindApplicableProfile(fullConfig.Profile, fullConfig.ClusterConfig.Capabilities, pluginName)
+	for _, plugin := range l.orderedPlugins() {
+		profile, err := profiles.FindApplicableProfile(fullConfig.Profile, fullConfig.ClusterConfig.Capabilities, plugin.GetName())
 		if err != nil {
 			l.logger.Error(err, "Failed to find applicable profile for the plugin", "plugin", plugin.GetName(), "cluster capabilities", fullConfig.ClusterConfig.Capabilities, "profile requirements", fullConfig.Profile)
-			return err
+			return nil, err
 		}
 		foundProfiles = append(foundProfiles, *profile)
 	}
+	return foundProfiles, nil
+}
 
-	for _, profile := range foundProfiles {
-		l.logger.Info("Generating deployment files for profile", "profile", profile.Name)
+// deploy deploys the saved deployment files of the profiles, in plugin order, prunes the objects of the previous
+// revision that are no longer deployed and records the deployment as a new revision of the history.
+// rollbackOf is the revision being rolled back to, 0 for a regular deployment.
+func (l *Launcher) deploy(ctx context.Context, foundProfiles []profiles.Profile, fullConfig *config.LaunchKubernetesConfig, rollbackOf int) error {
+	if fullConfig.Timeouts.Deployment > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, fullConfig.Timeouts.Deployment)
+		defer cancel()
+	}
 
-		if err := l.generateDeploymentFiles(&profile, fullConfig); err != nil {
-			return fmt.Errorf("deployment files generation failed: %w", err)
+	if !fullConfig.ForceConflicts() {
+		if err := l.checkConflicts(ctx, foundProfiles); err != nil {
+			return err
 		}
 	}
 
-	// Phase 3: Cluster Deployment
-	if l.options.Deploy {
-		for _, profile := range foundProfiles {
-			if err := l.deployConfigurationProfile(&profile); err != nil {
-				return fmt.Errorf("deployment failed: %w", err)
-			}
+	previous, err := l.latestRevision(ctx, fullConfig)
+	if err != nil {
+		return err
+	}
+
+	// Profiles are deployed in plugin order, each deployment returns once ready, gating the next plugins
+	for _, profile := range foundProfiles {
+		if err := l.deployConfigurationProfile(ctx, &profile, fullConfig); err != nil {
+			return err
 		}
 	}
 
-	l.logger.Info("l8k workflow completed successfully")
-	return nil
+	revision, err := l.newRevision(foundProfiles, fullConfig, previous, rollbackOf)
+	if err != nil {
+		return err
+	}
+	if previous != nil {
+		if err := kubeclient.Prune(ctx, l.kubeClient, revisionObjects(previous), revisionObjects(revision)); err != nil {
+			return err
+		}
+	}
+	return l.recordRevision(ctx, revision, fullConfig)
+}
+
+// orderedPlugins returns the enabled plugins, every plugin after the plugins it depends on
+func (l *Launcher) orderedPlugins() []pluginapi.Plugin {
+	plugins := make([]pluginapi.Plugin, 0, len(l.order))
+	for _, name := range l.order {
+		plugins = append(plugins, l.plugins[name])
+	}
+	return plugins
+}
+
+// applyTimeouts makes sure cfg.Timeouts is set, with the CLI flags taking precedence
+// over the config file values and the defaults filling in the rest
+func (l *Launcher) applyTimeouts(cfg *config.LaunchKubernetesConfig) {
+	if cfg.Timeouts == nil {
+		cfg.Timeouts = &config.TimeoutsConfig{}
+	}
+
+	if l.options.DiscoveryTimeout > 0 {
+		cfg.Timeouts.Discovery = l.options.DiscoveryTimeout
+	}
+	if l.options.DeploymentTimeout > 0 {
+		cfg.Timeouts.Deployment = l.options.DeploymentTimeout
+	}
+	if l.options.NicClusterPolicyReadyTimeout > 0 {
+		cfg.Timeouts.NicClusterPolicyReady = l.options.NicClusterPolicyReadyTimeout
+	}
+	if l.options.ClusterPolicyReadyTimeout > 0 {
+		cfg.Timeouts.ClusterPolicyReady = l.options.ClusterPolicyReadyTimeout
+	}
+	if l.options.PollInterval > 0 {
+		cfg.Timeouts.PollInterval = l.options.PollInterval
+	}
+
+	cfg.Timeouts.SetDefaults()
 }
 
 // discoverClusterConfig handles cluster configuration discovery
-func (l *Launcher) discoverClusterConfig() error {
+func (l *Launcher) discoverClusterConfig(ctx context.Context) error {
 	if l.options.UserConfig != "" {
 		l.logger.Info("Using provided user config", "path", l.options.UserConfig)
 		// TODO: Validate and load user config file
@@ -205,36 +402,24 @@ func (l *Launcher) discoverClusterConfig() error {
 	l.logger.Info("Discovering cluster configuration")
 
 	// Load defaults from l8k-config.yaml (temporary default path)
-	defaultsPath := "l8k-config.yaml"
 	defaults, err := config.LoadFullConfig(defaultsPath, l.logger)
 	if err != nil {
 		return fmt.Errorf("failed to load default config from %s: %w", defaultsPath, err)
 	}
-
-	defaults.ClusterConfig = &config.ClusterConfig{
-		Capabilities: &config.ClusterCapabilities{
-			Nodes: &config.NodesCapabilities{},
-		},
-		PFs:          []config.PFConfig{},
-		WorkerNodes:  []string{},
-		NodeSelector: map[string]string{"feature.node.kubernetes.io/pci-15b3.present": "true"},
-	}
-	defaults.Profile = nil
-
-	for _, plugin := range l.plugins {
-		err := plugin.DiscoverClusterConfig(context.Background(), l.kubeClient, defaults)
-		if err != nil {
-			return fmt.Errorf("failed to discover cluster config: %w", err)
-		}
+	if err := l.discover(ctx, defaults); err != nil {
+		return err
 	}
 
-	discoveredConfig := *defaults
-
 	// Ensure output path provided
 	if l.options.SaveClusterConfig == "" {
 		return fmt.Errorf("no output path provided for discovered cluster config (use --discover-cluster-config)")
 	}
 
+	return l.saveClusterConfig(defaults)
+}
+
+// saveClusterConfig saves the discovered config to options.SaveClusterConfig
+func (l *Launcher) saveClusterConfig(discoveredConfig *config.LaunchKubernetesConfig) error {
 	// Marshal and save merged config to disk
 	data, err := yaml.Marshal(discoveredConfig)
 	if err != nil {
@@ -251,8 +436,43 @@ func (l *Launcher) discoverClusterConfig() error {
 	return nil
 }
 
+// discover replaces the cluster config of cfg with the one discovered by the plugins and resets its profile
+func (l *Launcher) discover(ctx context.Context, cfg *config.LaunchKubernetesConfig) error {
+	l.applyTimeouts(cfg)
+	if l.options.ExistingNicClusterPolicy != "" && cfg.NetworkOperator != nil {
+		cfg.NetworkOperator.ExistingNicClusterPolicy = l.options.ExistingNicClusterPolicy
+	}
+
+	if cfg.Timeouts.Discovery > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Timeouts.Discovery)
+		defer cancel()
+	}
+
+	cfg.ClusterConfig = &config.ClusterConfig{
+		Capabilities: &config.ClusterCapabilities{
+			Nodes: &config.NodesCapabilities{},
+		},
+		PFs:          []config.PFConfig{},
+		WorkerNodes:  []string{},
+		NodeSelector: map[string]string{"feature.node.kubernetes.io/pci-15b3.present": "true"},
+	}
+	cfg.Profile = nil
+
+	if err := l.preflight(ctx, []string{pluginapi.PhaseDiscover}, nil, cfg); err != nil {
+		return err
+	}
+	for _, plugin := range l.orderedPlugins() {
+		err := plugin.DiscoverClusterConfig(ctx, l.kubeClient, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to discover cluster config: %w", err)
+		}
+	}
+	return nil
+}
+
 // generateDeploymentFiles handles deployment file generation
-func (l *Launcher) generateDeploymentFiles(profile *profiles.Profile, clusterConfig *config.LaunchKubernetesConfig) error {
+func (l *Launcher) generateDeploymentFiles(ctx context.Context, profile *profiles.Profile, clusterConfig *config.LaunchKubernetesConfig) error {
 	l.logger.Info("Generating deployment files", "profile", profile.Name)
 	l.logger.Info("Generating deployment files", "config", clusterConfig)
 
@@ -261,7 +481,7 @@ func (l *Launcher) generateDeploymentFiles(profile *profiles.Profile, clusterCon
 		return fmt.Errorf("plugin %s not found", profile.Plugin)
 	}
 
-	renderedFiles, err := plugin.GenerateProfileDeploymentFiles(profile, clusterConfig)
+	renderedFiles, err := plugin.GenerateProfileDeploymentFiles(ctx, profile, clusterConfig)
 	if err != nil {
 		return fmt.Errorf("failed to process profile templates: %w", err)
 	}
@@ -305,13 +525,13 @@ func (l *Launcher) saveDeploymentFiles(renderedFiles map[string]string, outputDi
 }
 
 // deployConfigurationProfile handles cluster deployment
-func (l *Launcher) deployConfigurationProfile(profile *profiles.Profile) error {
+func (l *Launcher) deployConfigurationProfile(ctx context.Context, profile *profiles.Profile, fullConfig *config.LaunchKubernetesConfig) error {
 	if !l.options.Deploy {
 		l.logger.Info("Skipped (deploy not requested)")
 		return nil
 	}
 
-	l.logger.Info("Deploying profile to cluster", "profile", profile.Name, "kubeconfig", l.options.Kubeconfig)
+	l.logger.Info("Deploying profile to cluster", "profile", profile.Name, "kubeconfig", l.options.Kubeconfig, "context", l.options.Context)
 
 	if l.options.SaveDeploymentFiles == "" {
 		return fmt.Errorf("--deploy requires generated files directory; provide --save-deployment-files")
@@ -322,10 +542,278 @@ func (l *Launcher) deployConfigurationProfile(profile *profiles.Profile) error {
 		return fmt.Errorf("plugin %s not found", profile.Plugin)
 	}
 
-	if err := plugin.DeployProfile(context.Background(), profile, l.kubeClient, filepath.Join(l.options.SaveDeploymentFiles, profile.Plugin)); err != nil {
+	if err := plugin.DeployProfile(ctx, profile, fullConfig, l.kubeClient, filepath.Join(l.options.SaveDeploymentFiles, profile.Plugin)); err != nil {
 		return fmt.Errorf("failed to deploy profile: %w", err)
 	}
 
 	l.logger.Info("Deployment profile applied successfully", "profile", profile.Name)
 	return nil
 }
+
+// verifyDeployment runs the verification workloads of the profiles whose plugin implements pluginapi.Verifier
+// and prints the results. It fails if any check failed.
+func (l *Launcher) verifyDeployment(ctx context.Context, foundProfiles []profiles.Profile, fullConfig *config.LaunchKubernetesConfig) error {
+	results := []pluginapi.VerificationResult{}
+	for _, profile := range foundProfiles {
+		verifier, ok := l.plugins[profile.Plugin].(pluginapi.Verifier)
+		if !ok || profile.Verification == nil {
+			l.logger.Info("Profile has no verification workload, skipping verification", "profile", profile.Name)
+			continue
+		}
+
+		l.logger.Info("Verifying profile", "profile", profile.Name, "workload", profile.Verification.Workload, "tests", profile.Verification.Tests)
+		profileResults, err := verifier.VerifyProfile(ctx, &profile, fullConfig, l.kubeClient)
+		if err != nil {
+			return fmt.Errorf("failed to verify profile %s: %w", profile.Name, err)
+		}
+		results = append(results, profileResults...)
+	}
+	if len(results) == 0 {
+		l.logger.Info("Nothing to verify")
+		return nil
+	}
+
+	failed := 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CHECK\tNETWORK\tSERVER\tCLIENT\tRESULT\tVALUE\tTHRESHOLD\tMESSAGE")
+	for _, r := range results {
+		status := "PASS"
+		if !r.Passed {
+			status = "FAIL"
+			failed++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", r.Check, r.Network, r.Server, r.Client, status, orNone(r.Value), orNone(r.Threshold), orNone(r.Message))
+	}
+	w.Flush()
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d checks failed", failed, len(results))
+	}
+	l.logger.Info("All verification checks passed", "checks", len(results))
+	return nil
+}
+
+// checkConflicts compares the deployment files of the profiles with the live objects and fails, before anything
+// is applied, if the deployment would overwrite fields owned by other field managers
+func (l *Launcher) checkConflicts(ctx context.Context, foundProfiles []profiles.Profile) error {
+	conflicts := []kubeclient.FieldDrift{}
+	for _, profile := range foundProfiles {
+		drifts, err := driftOf(ctx, l.kubeClient, filepath.Join(l.options.SaveDeploymentFiles, profile.Plugin))
+		if err != nil {
+			return err
+		}
+		for _, drift := range drifts {
+			if drift.Conflict {
+				conflicts = append(conflicts, drift)
+			}
+		}
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	printDrift(conflicts)
+	return fmt.Errorf("%d fields of the deployed objects are owned by other field managers, review them with l8k drift and use --force-conflicts to take them over", len(conflicts))
+}
+
+// Drift prints the differences between the deployment files saved in options.SaveDeploymentFiles, one directory per plugin,
+// and the live objects, as a table or as JSON
+func (l *Launcher) Drift(ctx context.Context) error {
+	if l.options.LogLevel != "" {
+		if err := applog.SetLogLevel(l.options.LogLevel); err != nil {
+			return fmt.Errorf("failed to set log level: %w", err)
+		}
+	}
+
+	k8sClient, err := kubeclient.New(l.clientOptions())
+	if err != nil {
+		return fmt.Errorf("failed to create k8s client: %w", err)
+	}
+
+	dirs, err := pluginDirs(l.options.SaveDeploymentFiles)
+	if err != nil {
+		return fmt.Errorf("failed to read deployment files: %w", err)
+	}
+	drifts := []kubeclient.FieldDrift{}
+	for _, dir := range dirs {
+		pluginDrifts, err := driftOf(ctx, k8sClient, dir)
+		if err != nil {
+			return err
+		}
+		drifts = append(drifts, pluginDrifts...)
+	}
+
+	if l.options.Output == "json" {
+		out, err := json.MarshalIndent(drifts, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+	if len(drifts) == 0 {
+		fmt.Println("No drift, the live objects match the deployment files")
+		return nil
+	}
+	printDrift(drifts)
+	return nil
+}
+
+// driftOf returns the drift of the objects of the deployment files in dir
+func driftOf(ctx context.Context, c client.Client, dir string) ([]kubeclient.FieldDrift, error) {
+	objects, err := kubeclient.ReadManifests(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deployment files: %w", err)
+	}
+	drifts := []kubeclient.FieldDrift{}
+	for _, obj := range objects {
+		objectDrifts, err := kubeclient.Drift(ctx, c, obj)
+		if err != nil {
+			return nil, err
+		}
+		drifts = append(drifts, objectDrifts...)
+	}
+	return drifts, nil
+}
+
+// printDrift prints the drifted fields as a table, long values are truncated
+func printDrift(drifts []kubeclient.FieldDrift) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KIND\tNAMESPACE\tNAME\tFIELD\tLIVE\tRENDERED\tMANAGERS\tCONFLICT")
+	for _, d := range drifts {
+		field, live := d.Field, d.Live
+		if field == "" {
+			field, live = "-", "<not found>"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%t\n", d.Kind, orNone(d.Namespace), d.Name, field,
+			truncate(orNone(live)), truncate(orNone(d.Rendered)), orNone(strings.Join(d.Managers, ",")), d.Conflict)
+	}
+	w.Flush()
+}
+
+func truncate(s string) string {
+	if len(s) > 40 {
+		return s[:37] + "..."
+	}
+	return s
+}
+
+// reportStatus prints the status of the deployed profiles whose plugin implements pluginapi.StatusReporter,
+// as tables or as JSON. The objects are compared to the deployment files of the latest revision of the history
+// that deployed the profile, or else to the profile rendered with fullConfig.
+func (l *Launcher) reportStatus(ctx context.Context, foundProfiles []profiles.Profile, fullConfig *config.LaunchKubernetesConfig) error {
+	latest, err := l.latestRevision(ctx, fullConfig)
+	if err != nil {
+		return err
+	}
+
+	statuses := []*pluginapi.ProfileStatus{}
+	for _, profile := range foundProfiles {
+		reporter, ok := l.plugins[profile.Plugin].(pluginapi.StatusReporter)
+		if !ok {
+			l.logger.Info("Plugin does not report status, skipping profile", "plugin", profile.Plugin, "profile", profile.Name)
+			continue
+		}
+
+		deployed := deployedFiles(latest, profile)
+		if deployed == nil {
+			l.logger.Info("Profile not deployed by the latest revision, comparing with the rendered profile", "profile", profile.Name)
+		}
+		status, err := reporter.ProfileStatus(ctx, &profile, fullConfig, deployed, l.kubeClient)
+		if err != nil {
+			return fmt.Errorf("failed to get the status of profile %s: %w", profile.Name, err)
+		}
+		if deployed != nil {
+			status.Revision = latest.Number
+		}
+		statuses = append(statuses, status)
+	}
+
+	if l.options.Output == "json" {
+		out, err := json.MarshalIndent(statuses, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+
+	for _, status := range statuses {
+		printStatus(status)
+	}
+	return nil
+}
+
+// printStatus prints the status of a profile as tables, omitting the empty ones
+func printStatus(status *pluginapi.ProfileStatus) {
+	health := "healthy"
+	if !status.Healthy {
+		health = "unhealthy"
+	}
+	revision := "rendered, not deployed by the latest revision"
+	if status.Revision > 0 {
+		revision = fmt.Sprintf("revision %d", status.Revision)
+	}
+	fmt.Printf("PROFILE: %s (%s) - %s, %s\n\n", status.Profile, status.Plugin, health, revision)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KIND\tNAMESPACE\tNAME\tSTATE\tMANAGED\tIN PROFILE\tMESSAGE")
+	for _, o := range status.Objects {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\t%t\t%s\n", o.Kind, orNone(o.Namespace), o.Name, o.State, o.Managed, o.InProfile, orNone(o.Message))
+	}
+	w.Flush()
+
+	if len(status.Components) > 0 {
+		fmt.Println()
+		fmt.Fprintln(w, "COMPONENT\tSTATE\tMESSAGE")
+		for _, c := range status.Components {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", c.Name, c.State, orNone(c.Message))
+		}
+		w.Flush()
+	}
+
+	if len(status.Nodes) > 0 {
+		// Missing nodes have no allocatable resources
+		resources := []string{}
+		for _, n := range status.Nodes {
+			for resource := range n.Allocatable {
+				if !slices.Contains(resources, resource) {
+					resources = append(resources, resource)
+				}
+			}
+		}
+		sort.Strings(resources)
+
+		fmt.Println()
+		fmt.Fprintf(w, "NODE\tSTATE\tSRIOV SYNC STATUS\t%s\tSYNC ERROR\n", strings.Join(resources, "\t"))
+		for _, n := range status.Nodes {
+			allocatable := []string{}
+			for _, resource := range resources {
+				allocatable = append(allocatable, orNone(n.Allocatable[resource]))
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", n.Node, n.State, orNone(n.SyncStatus), strings.Join(allocatable, "\t"), orNone(n.SyncError))
+		}
+		w.Flush()
+	}
+
+	if len(status.Pools) > 0 {
+		fmt.Println()
+		fmt.Fprintln(w, "POOL\tKIND\tSUBNET\tNODES\tALLOCATED\tCAPACITY\tUSAGE")
+		for _, p := range status.Pools {
+			usage := "-"
+			if p.Capacity > 0 {
+				usage = fmt.Sprintf("%.1f%%", 100*float64(p.Allocated)/float64(p.Capacity))
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%d\t%s\n", p.Name, p.Kind, p.Subnet, p.Nodes, p.Allocated, p.Capacity, usage)
+		}
+		w.Flush()
+	}
+	fmt.Println()
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
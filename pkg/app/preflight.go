@@ -0,0 +1,302 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	sigsyaml "sigs.k8s.io/yaml"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+	pluginapi "github.com/nvidia/k8s-launch-kit/pkg/plugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+)
+
+// preflightClusterRole is the name of the ClusterRole printed when permissions are missing
+const preflightClusterRole = "l8k"
+
+// preflight checks before the phases run that they would not fail midway: that the user is allowed their requests,
+// and before the deployment that the prerequisites of the profiles are met in the cluster. Missing permissions are printed
+// with a ClusterRole granting them, unmet prerequisites are printed as blockers and warnings, only blockers fail.
+func (l *Launcher) preflight(ctx context.Context, phases []string, foundProfiles []profiles.Profile, cfg *config.LaunchKubernetesConfig) error {
+	if l.options.SkipPreflight || len(phases) == 0 {
+		return nil
+	}
+	if err := l.checkPermissions(ctx, phases, foundProfiles, cfg); err != nil {
+		return err
+	}
+	if slices.Contains(phases, pluginapi.PhaseDeploy) {
+		return l.checkPrerequisites(ctx, foundProfiles, cfg)
+	}
+	return nil
+}
+
+// checkPermissions checks with SelfSubjectAccessReviews that the user is allowed the requests of the phases.
+// If the permissions cannot be reviewed, the check fails, --skip-preflight bypasses it.
+func (l *Launcher) checkPermissions(ctx context.Context, phases []string, foundProfiles []profiles.Profile, cfg *config.LaunchKubernetesConfig) error {
+	permissions, err := l.requiredPermissions(ctx, phases, foundProfiles, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to compute the permissions of %s: %w", strings.Join(phases, ", "), err)
+	}
+	missing, err := kubeclient.CheckPermissions(ctx, l.kubeClient, permissions)
+	if err != nil {
+		return fmt.Errorf("failed to check the permissions of %s, skip the checks with --skip-preflight: %w", strings.Join(phases, ", "), err)
+	}
+	if len(missing) == 0 {
+		l.logger.Info("Preflight permission checks passed", "phases", phases, "permissions", len(permissions))
+		return nil
+	}
+
+	printPermissions(missing)
+	role, err := runtime.DefaultUnstructuredConverter.ToUnstructured(kubeclient.ClusterRole(preflightClusterRole, missing))
+	if err != nil {
+		return err
+	}
+	unstructured.RemoveNestedField(role, "metadata", "creationTimestamp")
+	out, err := sigsyaml.Marshal(role)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\nClusterRole granting the missing permissions:\n\n%s\n", out)
+	return fmt.Errorf("missing %d permissions for %s, bind them to the user with the ClusterRole above or skip the checks with --skip-preflight",
+		len(missing), strings.Join(phases, ", "))
+}
+
+// checkPrerequisites checks that the kinds of the deployment files are served in their versions, and runs the checks
+// of the plugins implementing pluginapi.PreflightChecker
+func (l *Launcher) checkPrerequisites(ctx context.Context, foundProfiles []profiles.Profile, cfg *config.LaunchKubernetesConfig) error {
+	findings := []pluginapi.PreflightFinding{}
+	for _, profile := range foundProfiles {
+		objects, err := kubeclient.ReadManifests(filepath.Join(l.options.SaveDeploymentFiles, profile.Plugin))
+		if err != nil {
+			return fmt.Errorf("failed to read deployment files: %w", err)
+		}
+		findings = append(findings, servedKinds(l.kubeClient, objects)...)
+
+		if checker, ok := l.plugins[profile.Plugin].(pluginapi.PreflightChecker); ok {
+			profileFindings, err := checker.PreflightChecks(ctx, &profile, cfg, l.kubeClient)
+			if err != nil {
+				return fmt.Errorf("failed to run the preflight checks of profile %s: %w", profile.Name, err)
+			}
+			findings = append(findings, profileFindings...)
+		}
+	}
+	if len(findings) == 0 {
+		l.logger.Info("Preflight cluster checks passed")
+		return nil
+	}
+
+	printFindings(findings)
+	blockers := 0
+	for _, finding := range findings {
+		if finding.Severity == pluginapi.SeverityBlocker {
+			blockers++
+		}
+	}
+	if blockers > 0 {
+		return fmt.Errorf("%d preflight checks found blockers, fix them or skip the checks with --skip-preflight", blockers)
+	}
+	l.logger.Info("Preflight cluster checks passed with warnings", "warnings", len(findings))
+	return nil
+}
+
+// servedKinds returns a blocker for every kind of the objects that the API server does not serve in the version of the
+// object, e.g. the CRD is not installed or is from another operator version
+func servedKinds(c client.Client, objects []*unstructured.Unstructured) []pluginapi.PreflightFinding {
+	findings := []pluginapi.PreflightFinding{}
+	checked := map[schema.GroupVersionKind]bool{}
+	for _, obj := range objects {
+		gvk := obj.GroupVersionKind()
+		if checked[gvk] {
+			continue
+		}
+		checked[gvk] = true
+
+		// Other errors than a missing kind are left to the deployment
+		if _, err := c.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); !meta.IsNoMatchError(err) {
+			continue
+		}
+		message := fmt.Sprintf("%s is not served, install its CRD", gvk.GroupKind())
+		if mappings, err := c.RESTMapper().RESTMappings(gvk.GroupKind()); err == nil && len(mappings) > 0 {
+			versions := []string{}
+			for _, mapping := range mappings {
+				versions = append(versions, mapping.GroupVersionKind.Version)
+			}
+			message = fmt.Sprintf("%s is served in versions %s, not %s, the CRD is from another operator version", gvk.GroupKind(), strings.Join(versions, ", "), gvk.Version)
+		}
+		findings = append(findings, pluginapi.PreflightFinding{Check: "crd", Severity: pluginapi.SeverityBlocker, Object: gvk.Kind + " " + obj.GetName(), Message: message})
+	}
+	return findings
+}
+
+// clusterPhases returns the phases run on the cluster after the generation of the deployment files,
+// the deployment is checked by the preflight command without running it
+func (l *Launcher) clusterPhases() []string {
+	phases := []string{}
+	if l.options.Deploy || l.options.Preflight {
+		phases = append(phases, pluginapi.PhaseDeploy)
+	}
+	if l.options.Verify {
+		phases = append(phases, pluginapi.PhaseVerify)
+	}
+	if l.options.Watch {
+		phases = append(phases, pluginapi.PhaseWatch)
+	}
+	return phases
+}
+
+// requiredPermissions returns the permissions of the phases: the ones declared by the plugins and the ones on the
+// objects of the operators, the deployment files, the previous revision, the history storage and the watched kinds
+func (l *Launcher) requiredPermissions(ctx context.Context, phases []string, foundProfiles []profiles.Profile, cfg *config.LaunchKubernetesConfig) ([]kubeclient.Permission, error) {
+	permissions := []kubeclient.Permission{}
+	for _, phase := range phases {
+		switch phase {
+		case pluginapi.PhaseInstall:
+			// Operator objects are created, or compared with the live ones and patched on upgrades
+			for _, plugin := range l.orderedPlugins() {
+				installer, ok := plugin.(pluginapi.Installer)
+				if !ok {
+					continue
+				}
+				objects, err := installer.OperatorObjects(ctx, cfg.Profile, cfg, l.kubeClient, l.clientOptions())
+				if err != nil {
+					return nil, fmt.Errorf("failed to render the operator of plugin %s: %w", plugin.GetName(), err)
+				}
+				permissions = append(permissions, kubeclient.ObjectPermissions(l.kubeClient, objects, "create", "delete", "get", "patch")...)
+			}
+		case pluginapi.PhaseDeploy:
+			// Objects are compared with the live ones for conflicts, then server-side applied
+			for _, profile := range foundProfiles {
+				objects, err := kubeclient.ReadManifests(filepath.Join(l.options.SaveDeploymentFiles, profile.Plugin))
+				if err != nil {
+					return nil, fmt.Errorf("failed to read deployment files: %w", err)
+				}
+				permissions = append(permissions, kubeclient.ObjectPermissions(l.kubeClient, objects, "create", "get", "patch")...)
+			}
+			// The objects of the previous revision may be pruned
+			previous, err := l.loadRevision(ctx, 0, nil)
+			if err != nil {
+				return nil, err
+			}
+			if previous != nil {
+				permissions = append(permissions, kubeclient.ObjectPermissions(l.kubeClient, revisionObjects(previous), "delete", "get")...)
+			}
+			if cfg.History != nil && cfg.History.Storage != "" {
+				resource := "configmaps"
+				if cfg.History.Storage == config.HistoryStorageSecret {
+					resource = "secrets"
+				}
+				permissions = append(permissions, kubeclient.Permission{Resource: resource, Namespace: cfg.NetworkOperator.Namespace, Verbs: []string{"create", "delete", "list"}})
+			}
+		case pluginapi.PhaseStatus:
+			// Objects are read and the leftovers of their kinds listed
+			for _, profile := range foundProfiles {
+				if _, ok := l.plugins[profile.Plugin].(pluginapi.StatusReporter); !ok {
+					continue
+				}
+				rendered, err := l.plugins[profile.Plugin].GenerateProfileDeploymentFiles(ctx, &profile, cfg)
+				if err != nil {
+					return nil, err
+				}
+				for _, content := range rendered {
+					objects, err := kubeclient.DecodeManifests([]byte(content))
+					if err != nil {
+						return nil, err
+					}
+					permissions = append(permissions, kubeclient.ObjectPermissions(l.kubeClient, objects, "get", "list")...)
+				}
+			}
+		case pluginapi.PhaseWatch:
+			// Informers list and watch in all namespaces
+			for _, plugin := range l.orderedPlugins() {
+				watcher, ok := plugin.(pluginapi.Watcher)
+				if !ok {
+					continue
+				}
+				kinds := []schema.GroupVersionKind{}
+				for _, obj := range watcher.WatchedObjects() {
+					gvk, err := apiutil.GVKForObject(obj, l.kubeClient.Scheme())
+					if err != nil {
+						return nil, err
+					}
+					kinds = append(kinds, gvk)
+				}
+				permissions = append(permissions, kubeclient.KindPermissions(l.kubeClient, kinds, "", "list", "watch")...)
+			}
+		}
+
+		if phase == pluginapi.PhaseInstall || phase == pluginapi.PhaseDiscover {
+			for _, plugin := range l.orderedPlugins() {
+				if requirer, ok := plugin.(pluginapi.PermissionRequirer); ok {
+					declared, err := requirer.Permissions(phase, nil, cfg)
+					if err != nil {
+						return nil, err
+					}
+					permissions = append(permissions, declared...)
+				}
+			}
+			continue
+		}
+		for _, profile := range foundProfiles {
+			if requirer, ok := l.plugins[profile.Plugin].(pluginapi.PermissionRequirer); ok {
+				declared, err := requirer.Permissions(phase, &profile, cfg)
+				if err != nil {
+					return nil, err
+				}
+				permissions = append(permissions, declared...)
+			}
+		}
+	}
+	return kubeclient.MergePermissions(permissions), nil
+}
+
+// printFindings prints the preflight findings as a table, blockers first
+func printFindings(findings []pluginapi.PreflightFinding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Severity == pluginapi.SeverityBlocker && findings[j].Severity != pluginapi.SeverityBlocker
+	})
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CHECK\tSEVERITY\tOBJECT\tMESSAGE")
+	for _, f := range findings {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", f.Check, f.Severity, orNone(f.Object), f.Message)
+	}
+	w.Flush()
+}
+
+// printPermissions prints the permissions as a table, - for the cluster-scoped resources or all namespaces
+func printPermissions(permissions []kubeclient.Permission) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "MISSING PERMISSION\tNAMESPACE\tVERBS")
+	for _, p := range permissions {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", p.GroupResource(), orNone(p.Namespace), strings.Join(p.Verbs, ","))
+	}
+	w.Flush()
+}
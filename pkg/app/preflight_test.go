@@ -0,0 +1,65 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+	pluginapi "github.com/nvidia/k8s-launch-kit/pkg/plugin"
+)
+
+func TestPreflightFailsWhenPermissionsCannotBeReviewed(t *testing.T) {
+	kubeClient := fake.NewClientBuilder().WithScheme(kubeclient.NewScheme()).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if _, ok := obj.(*authorizationv1.SelfSubjectAccessReview); ok {
+				return errors.New("forbidden")
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}).Build()
+	cfg := &config.LaunchKubernetesConfig{
+		NetworkOperator: &config.NetworkOperatorConfig{Namespace: "nvidia-network-operator"},
+		History:         &config.HistoryConfig{Storage: config.HistoryStorageConfigMap},
+	}
+
+	for _, skip := range []bool{false, true} {
+		l := &Launcher{
+			options:    options.Options{SaveDeploymentFiles: t.TempDir(), SkipPreflight: skip},
+			logger:     log.Log,
+			kubeClient: kubeClient,
+		}
+		err := l.preflight(context.Background(), []string{pluginapi.PhaseDeploy}, nil, cfg)
+		if skip && err != nil {
+			t.Errorf("preflight with --skip-preflight: unexpected error: %v", err)
+		}
+		if !skip && (err == nil || !strings.Contains(err.Error(), "failed to check the permissions")) {
+			t.Errorf("preflight error = %v, want a failed permission check", err)
+		}
+	}
+}
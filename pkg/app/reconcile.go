@@ -0,0 +1,145 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+)
+
+// Phases of the workflow reported by Reconcile
+const (
+	PhaseDiscovery        = "Discovery"
+	PhaseProfileSelection = "ProfileSelection"
+	PhaseGeneration       = "Generation"
+	PhaseDeployment       = "Deployment"
+	PhaseVerification     = "Verification"
+)
+
+// Discovery is a discovered config kept by the controller between the reconciliations of a LaunchKitConfig
+type Discovery struct {
+	// Fingerprint is the digest of the objects watched by the plugins after the discovery, empty if no plugin watches the cluster
+	Fingerprint string
+	// Config is the config with the discovered cluster config
+	Config *config.LaunchKubernetesConfig
+}
+
+// Reconcile runs the whole workflow once with the given client, for the in-cluster controller: it discovers the
+// cluster into cfg, selects the profiles from the options, renders them into options.SaveDeploymentFiles, deploys
+// them and verifies them if options.Verify is set. report is called with the outcome of every phase that ran,
+// the workflow stops at the first failing phase. The deployed profiles and the discovery are returned.
+// If the objects watched by the plugins did not change since the previous discovery, its config is reused instead
+// of rediscovering the cluster, and if the rendered files are those of the latest revision, nothing is deployed.
+func (l *Launcher) Reconcile(ctx context.Context, kubeClient client.Client, cfg *config.LaunchKubernetesConfig, previous *Discovery,
+	report func(phase string, err error)) ([]profiles.Profile, *Discovery, error) {
+	l.kubeClient = kubeClient
+	if err := l.loadPlugins(ctx, l.options.EnabledPlugins); err != nil {
+		return nil, nil, err
+	}
+
+	phase := func(name string, err error) error {
+		report(name, err)
+		if err != nil {
+			return fmt.Errorf("%s failed: %w", name, err)
+		}
+		return nil
+	}
+
+	discovery, err := l.rediscover(ctx, cfg, previous)
+	if err := phase(PhaseDiscovery, err); err != nil {
+		return nil, nil, err
+	}
+
+	for _, plugin := range l.orderedPlugins() {
+		if !plugin.ProfileConfiguredInCmd(ctx, l.options) {
+			return nil, discovery, phase(PhaseProfileSelection, fmt.Errorf("the profile of plugin %s is not configured", plugin.GetName()))
+		}
+	}
+	foundProfiles, err := l.selectProfiles(ctx, cfg, true)
+	if err := phase(PhaseProfileSelection, err); err != nil {
+		return nil, discovery, err
+	}
+
+	err = nil
+	for _, profile := range foundProfiles {
+		if err = l.generateDeploymentFiles(ctx, &profile, cfg); err != nil {
+			break
+		}
+	}
+	if err := phase(PhaseGeneration, err); err != nil {
+		return nil, discovery, err
+	}
+
+	unchanged, err := l.unchangedRevision(ctx, foundProfiles, cfg)
+	if err == nil && unchanged != nil {
+		l.logger.Info("Deployment files unchanged, skipping the deployment", "revision", unchanged.Number)
+	} else if err == nil {
+		err = l.preflight(ctx, l.clusterPhases(), foundProfiles, cfg)
+		if err == nil {
+			err = l.deploy(ctx, foundProfiles, cfg, 0)
+		}
+	}
+	if err := phase(PhaseDeployment, err); err != nil {
+		return nil, discovery, err
+	}
+
+	if l.options.Verify {
+		if err := phase(PhaseVerification, l.verifyDeployment(ctx, foundProfiles, cfg)); err != nil {
+			return nil, discovery, err
+		}
+	}
+	return foundProfiles, discovery, nil
+}
+
+// rediscover discovers the cluster into cfg, or replaces cfg with the config of the previous discovery if the objects
+// watched by the plugins did not change since, and returns the discovery
+func (l *Launcher) rediscover(ctx context.Context, cfg *config.LaunchKubernetesConfig, previous *Discovery) (*Discovery, error) {
+	if previous != nil && previous.Fingerprint != "" {
+		fingerprint, err := l.clusterFingerprint(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if fingerprint == previous.Fingerprint {
+			reused, err := copyConfig(previous.Config)
+			if err != nil {
+				return nil, err
+			}
+			l.logger.Info("Watched objects unchanged since the previous discovery, reusing its cluster config")
+			*cfg = *reused
+			return previous, nil
+		}
+	}
+
+	if err := l.discover(ctx, cfg); err != nil {
+		return nil, err
+	}
+	// Taken after the discovery, which changes the watched objects, e.g. the NicDevices of a new NicClusterPolicy
+	fingerprint, err := l.clusterFingerprint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	discovered, err := copyConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Discovery{Fingerprint: fingerprint, Config: discovered}, nil
+}
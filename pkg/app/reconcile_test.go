@@ -0,0 +1,204 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/history"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+	pluginapi "github.com/nvidia/k8s-launch-kit/pkg/plugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+)
+
+const reconcileTestPlugin = "reconcile-test"
+
+// watchPlugin discovers the nodes as worker nodes, renders a ConfigMap listing them and watches the nodes
+type watchPlugin struct {
+	createPlugin
+	configured  bool
+	discoveries int
+}
+
+var reconcilePlugin = &watchPlugin{}
+
+func (p *watchPlugin) GetName() string { return reconcileTestPlugin }
+
+func (p *watchPlugin) ProfileConfiguredInCmd(_ context.Context, _ options.Options) bool {
+	return p.configured
+}
+
+func (p *watchPlugin) BuildProfileFromOptions(_ context.Context, _ options.Options, profile *config.Profile) error {
+	profile.Fabric = "ethernet"
+	return nil
+}
+
+func (p *watchPlugin) DiscoverClusterConfig(ctx context.Context, c client.Client, cfg *config.LaunchKubernetesConfig) error {
+	p.discoveries++
+	nodes := &corev1.NodeList{}
+	if err := c.List(ctx, nodes); err != nil {
+		return err
+	}
+	for _, node := range nodes.Items {
+		cfg.ClusterConfig.WorkerNodes = append(cfg.ClusterConfig.WorkerNodes, node.Name)
+	}
+	return nil
+}
+
+func (p *watchPlugin) GenerateProfileDeploymentFiles(_ context.Context, _ *profiles.Profile, cfg *config.LaunchKubernetesConfig) (map[string]string, error) {
+	return map[string]string{"10-nodes.yaml": configMapManifest("nodes", strings.Join(cfg.ClusterConfig.WorkerNodes, "_"))}, nil
+}
+
+func (p *watchPlugin) WatchedObjects() []client.Object {
+	return []client.Object{&corev1.Node{}}
+}
+
+func (p *watchPlugin) RefreshClusterConfig(context.Context, *config.LaunchKubernetesConfig, client.Client) (map[string]config.NodesCapabilities, error) {
+	return nil, nil
+}
+
+func init() {
+	pluginapi.Register(pluginapi.Registration{
+		Metadata: pluginapi.Metadata{Name: reconcileTestPlugin, Version: pluginapi.APIVersion},
+		New:      func() pluginapi.Plugin { return reconcilePlugin },
+	})
+}
+
+// reconcile runs the workflow as the controller does, with a new launcher and config, and returns the reported phases
+func reconcile(t *testing.T, c client.Client, dir string, previous *Discovery) ([]profiles.Profile, *Discovery, []string, error) {
+	t.Helper()
+	l := New(options.Options{EnabledPlugins: []string{reconcileTestPlugin}, SaveDeploymentFiles: dir, SkipPreflight: true, Deploy: true})
+	cfg := &config.LaunchKubernetesConfig{NetworkOperator: &config.NetworkOperatorConfig{Namespace: "nvidia-network-operator"}}
+	reported := []string{}
+	foundProfiles, discovery, err := l.Reconcile(context.Background(), c, cfg, previous, func(phase string, err error) {
+		if err != nil {
+			phase += " failed"
+		}
+		reported = append(reported, phase)
+	})
+	return foundProfiles, discovery, reported, err
+}
+
+func TestReconcile(t *testing.T) {
+	workdir := t.TempDir()
+	profileDir := filepath.Join(workdir, profiles.ProfilesDir, reconcileTestPlugin)
+	if err := os.MkdirAll(profileDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	manifest := "name: Test\nplugin: " + reconcileTestPlugin + "\nprofileRequirements:\n  fabric: ethernet\ntemplates: []\n"
+	if err := os.WriteFile(filepath.Join(profileDir, "profile.yaml"), []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(workdir)
+
+	dir := filepath.Join(workdir, "state")
+	ctx := context.Background()
+	c := fake.NewClientBuilder().WithScheme(kubeclient.NewScheme()).WithObjects(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}},
+	).Build()
+	*reconcilePlugin = watchPlugin{configured: true}
+
+	deployedValue := func() string {
+		cm := &corev1.ConfigMap{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "nodes"}, cm); err != nil {
+			t.Fatal(err)
+		}
+		return cm.Data["value"]
+	}
+	revisions := func() []int {
+		numbers, err := history.List(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return numbers
+	}
+
+	foundProfiles, discovery, reported, err := reconcile(t, c, dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{PhaseDiscovery, PhaseProfileSelection, PhaseGeneration, PhaseDeployment}; !reflect.DeepEqual(reported, want) {
+		t.Errorf("reported phases = %v, want %v", reported, want)
+	}
+	if len(foundProfiles) != 1 || foundProfiles[0].Name != "Test" {
+		t.Errorf("deployed profiles = %+v, want Test", foundProfiles)
+	}
+	if discovery == nil || discovery.Fingerprint == "" || !reflect.DeepEqual(discovery.Config.ClusterConfig.WorkerNodes, []string{"node-a"}) {
+		t.Fatalf("discovery = %+v, want the discovered node-a", discovery)
+	}
+	if value := deployedValue(); value != "node-a" {
+		t.Errorf("deployed %s, want node-a", value)
+	}
+
+	// The watched nodes are unchanged: the discovery is reused and the unchanged files are not deployed again
+	reused, reusedDiscovery, _, err := reconcile(t, c, dir, discovery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reconcilePlugin.discoveries != 1 || reusedDiscovery != discovery {
+		t.Errorf("the previous discovery must be reused, discovered %d times", reconcilePlugin.discoveries)
+	}
+	if !reflect.DeepEqual(reused, foundProfiles) {
+		t.Errorf("deployed profiles = %+v, want %+v", reused, foundProfiles)
+	}
+	if numbers := revisions(); !reflect.DeepEqual(numbers, []int{1}) {
+		t.Errorf("revisions = %v, unchanged files must not be deployed again", numbers)
+	}
+
+	// A new node changes the fingerprint
+	if err := c.Create(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-b"}}); err != nil {
+		t.Fatal(err)
+	}
+	_, rediscovered, _, err := reconcile(t, c, dir, discovery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reconcilePlugin.discoveries != 2 || rediscovered.Fingerprint == discovery.Fingerprint {
+		t.Errorf("the cluster must be rediscovered after a node change, discovered %d times", reconcilePlugin.discoveries)
+	}
+	if value := deployedValue(); value != "node-a_node-b" {
+		t.Errorf("deployed %s, want node-a_node-b", value)
+	}
+	if numbers := revisions(); !reflect.DeepEqual(numbers, []int{1, 2}) {
+		t.Errorf("revisions = %v, want [1 2]", numbers)
+	}
+
+	// The workflow stops at the first failing phase
+	reconcilePlugin.configured = false
+	_, failedDiscovery, reported, err := reconcile(t, c, dir, rediscovered)
+	if err == nil || !strings.Contains(err.Error(), "is not configured") {
+		t.Errorf("Reconcile() error = %v, want a profile selection failure", err)
+	}
+	if want := []string{PhaseDiscovery, PhaseProfileSelection + " failed"}; !reflect.DeepEqual(reported, want) {
+		t.Errorf("reported phases = %v, want %v", reported, want)
+	}
+	if failedDiscovery != rediscovered {
+		t.Errorf("the discovery must be returned after a later failure")
+	}
+}
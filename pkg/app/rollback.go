@@ -0,0 +1,305 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/history"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+	applog "github.com/nvidia/k8s-launch-kit/pkg/log"
+	pluginapi "github.com/nvidia/k8s-launch-kit/pkg/plugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+)
+
+// Rollback re-deploys a revision of the history, options.RollbackTo or else the one before the latest revision,
+// with the config and profiles it was deployed with. The objects of the latest revision missing from it are pruned
+// and the rollback is recorded as a new revision.
+func (l *Launcher) Rollback(ctx context.Context) error {
+	if l.options.LogLevel != "" {
+		if err := applog.SetLogLevel(l.options.LogLevel); err != nil {
+			return fmt.Errorf("failed to set log level: %w", err)
+		}
+	}
+	if l.options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, l.options.Timeout)
+		defer cancel()
+	}
+
+	k8sClient, err := kubeclient.New(l.clientOptions())
+	if err != nil {
+		return fmt.Errorf("failed to create k8s client: %w", err)
+	}
+	l.kubeClient = k8sClient
+	return l.rollback(ctx)
+}
+
+// rollback re-deploys the revision selected by the options with the cluster client of the launcher, see Rollback
+func (l *Launcher) rollback(ctx context.Context) error {
+	storages := []string{history.StorageAny}
+	current, err := l.loadRevision(ctx, 0, storages)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return fmt.Errorf("no deployment revision found in %s or in the cluster", filepath.Join(l.options.SaveDeploymentFiles, history.DirName))
+	}
+	number := l.options.RollbackTo
+	if number == 0 {
+		number = current.Number - 1
+	}
+	if number == current.Number {
+		return fmt.Errorf("revision %d is the current deployment", number)
+	}
+	target, err := l.loadRevision(ctx, number, storages)
+	if err != nil {
+		return err
+	}
+	if target == nil {
+		return fmt.Errorf("revision %d not found, select an existing revision with --to", number)
+	}
+	l.logger.Info("Rolling back", "from", current.Number, "to", target.Number, "deployedAt", target.Time)
+
+	plugins := []string{}
+	for _, profile := range target.Profiles {
+		plugins = append(plugins, profile.Plugin)
+	}
+	if err := l.loadPlugins(ctx, plugins); err != nil {
+		return err
+	}
+	foundProfiles := slices.Clone(target.Profiles)
+	sort.SliceStable(foundProfiles, func(i, j int) bool {
+		return slices.Index(l.order, foundProfiles[i].Plugin) < slices.Index(l.order, foundProfiles[j].Plugin)
+	})
+
+	fullConfig := target.Config
+	l.applyTimeouts(fullConfig)
+	if l.options.ForceConflicts {
+		if fullConfig.Deployment == nil {
+			fullConfig.Deployment = &config.DeploymentConfig{}
+		}
+		fullConfig.Deployment.ForceConflicts = true
+	}
+
+	// The files of the revision become the saved deployment files, they are deployed from there
+	for _, profile := range current.Profiles {
+		if _, ok := target.Files[profile.Plugin]; !ok {
+			if err := os.RemoveAll(filepath.Join(l.options.SaveDeploymentFiles, profile.Plugin)); err != nil {
+				return fmt.Errorf("failed to remove deployment files: %w", err)
+			}
+		}
+	}
+	for plugin, files := range target.Files {
+		if err := l.saveDeploymentFiles(files, filepath.Join(l.options.SaveDeploymentFiles, plugin)); err != nil {
+			return fmt.Errorf("failed to save deployment files: %w", err)
+		}
+	}
+
+	l.options.Deploy = true
+	if err := l.preflight(ctx, []string{pluginapi.PhaseDeploy}, foundProfiles, fullConfig); err != nil {
+		return err
+	}
+	if err := l.deploy(ctx, foundProfiles, fullConfig, target.Number); err != nil {
+		return fmt.Errorf("rollback failed: %w", err)
+	}
+	l.logger.Info("Rollback completed successfully", "revision", target.Number)
+	return nil
+}
+
+// latestRevision returns the latest revision of the history, nil if there is none
+func (l *Launcher) latestRevision(ctx context.Context, cfg *config.LaunchKubernetesConfig) (*history.Revision, error) {
+	storages := []string{}
+	if cfg.History != nil && cfg.History.Storage != "" {
+		storages = append(storages, cfg.History.Storage)
+	}
+	return l.loadRevision(ctx, 0, storages)
+}
+
+// loadRevision returns a revision of the history, the latest one of the deployment files directory and the cluster
+// storages if number is 0, nil if not found. The revisions are read from the deployment files directory,
+// or else from the cluster storages.
+func (l *Launcher) loadRevision(ctx context.Context, number int, storages []string) (*history.Revision, error) {
+	numbers, err := history.List(l.options.SaveDeploymentFiles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the deployment revisions: %w", err)
+	}
+	if number == 0 {
+		if len(numbers) > 0 {
+			number = numbers[len(numbers)-1]
+		}
+		// The history on disk is behind the cluster when another directory deployed since
+		for _, storage := range storages {
+			latest, err := history.LatestInCluster(ctx, l.kubeClient, storage)
+			if err != nil {
+				return nil, err
+			}
+			number = max(number, latest)
+		}
+		if number == 0 {
+			return nil, nil
+		}
+	}
+	if slices.Contains(numbers, number) {
+		return history.Load(l.options.SaveDeploymentFiles, number)
+	}
+
+	for _, storage := range storages {
+		revision, err := history.LoadFromCluster(ctx, l.kubeClient, storage, number)
+		if err != nil || revision != nil {
+			return revision, err
+		}
+	}
+	return nil, nil
+}
+
+// newRevision returns the revision of the deployment of the saved deployment files of the profiles,
+// numbered after previous, the latest revision of the history, and after the revisions on disk
+func (l *Launcher) newRevision(foundProfiles []profiles.Profile, fullConfig *config.LaunchKubernetesConfig, previous *history.Revision, rollbackOf int) (*history.Revision, error) {
+	number := 1
+	if numbers, err := history.List(l.options.SaveDeploymentFiles); err == nil && len(numbers) > 0 {
+		number = numbers[len(numbers)-1] + 1
+	}
+	if previous != nil && previous.Number >= number {
+		number = previous.Number + 1
+	}
+
+	revision := &history.Revision{
+		Number:     number,
+		Time:       time.Now().UTC(),
+		RollbackOf: rollbackOf,
+		Profiles:   foundProfiles,
+		Config:     fullConfig,
+		Files:      map[string]map[string]string{},
+	}
+	for _, profile := range foundProfiles {
+		dir := filepath.Join(l.options.SaveDeploymentFiles, profile.Plugin)
+		paths, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+		if err != nil {
+			return nil, err
+		}
+		revision.Files[profile.Plugin] = map[string]string{}
+		for _, path := range paths {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read deployment file: %w", err)
+			}
+			revision.Files[profile.Plugin][filepath.Base(path)] = string(content)
+		}
+	}
+	return revision, nil
+}
+
+// unchangedRevision returns the latest revision if it deployed the profiles with the saved deployment files, nil otherwise
+func (l *Launcher) unchangedRevision(ctx context.Context, foundProfiles []profiles.Profile, fullConfig *config.LaunchKubernetesConfig) (*history.Revision, error) {
+	latest, err := l.latestRevision(ctx, fullConfig)
+	if err != nil || latest == nil {
+		return nil, err
+	}
+	current, err := l.newRevision(foundProfiles, fullConfig, latest, 0)
+	if err != nil {
+		return nil, err
+	}
+	sameProfiles := slices.EqualFunc(latest.Profiles, current.Profiles, func(a, b profiles.Profile) bool { return a.Name == b.Name })
+	if !sameProfiles || !reflect.DeepEqual(latest.Files, current.Files) {
+		return nil, nil
+	}
+	return latest, nil
+}
+
+// recordRevision saves the revision to the history, on disk and in the cluster storage if configured
+func (l *Launcher) recordRevision(ctx context.Context, revision *history.Revision, fullConfig *config.LaunchKubernetesConfig) error {
+	maxRevisions, storage := config.DefaultMaxRevisions, ""
+	if fullConfig.History != nil {
+		if fullConfig.History.MaxRevisions > 0 {
+			maxRevisions = fullConfig.History.MaxRevisions
+		}
+		storage = fullConfig.History.Storage
+	}
+
+	if err := history.Save(l.options.SaveDeploymentFiles, revision, maxRevisions); err != nil {
+		return fmt.Errorf("failed to save deployment revision %d: %w", revision.Number, err)
+	}
+	if storage != "" {
+		if err := history.SaveToCluster(ctx, l.kubeClient, storage, fullConfig.NetworkOperator.Namespace, revision, maxRevisions); err != nil {
+			return err
+		}
+	}
+	l.logger.Info("Recorded deployment revision", "revision", revision.Number, "directory", filepath.Join(l.options.SaveDeploymentFiles, history.DirName), "storage", storage)
+	return nil
+}
+
+// deployedFiles returns the deployment files of the plugin of profile in the revision, nil if the revision is nil
+// or did not deploy the profile
+func deployedFiles(revision *history.Revision, profile profiles.Profile) map[string]string {
+	if revision == nil || !slices.ContainsFunc(revision.Profiles, func(p profiles.Profile) bool {
+		return p.Name == profile.Name && p.Plugin == profile.Plugin
+	}) {
+		return nil
+	}
+	if files := revision.Files[profile.Plugin]; files != nil {
+		return files
+	}
+	return map[string]string{}
+}
+
+// revisionObjects returns the objects of the revision, in deployment order
+func revisionObjects(revision *history.Revision) []*unstructured.Unstructured {
+	objects := []*unstructured.Unstructured{}
+	for _, profile := range revision.Profiles {
+		files := revision.Files[profile.Plugin]
+		names := make([]string, 0, len(files))
+		for name := range files {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			decoded, err := kubeclient.DecodeManifests([]byte(files[name]))
+			if err != nil {
+				continue
+			}
+			objects = append(objects, decoded...)
+		}
+	}
+	return objects
+}
+
+// pluginDirs returns the plugin directories of the deployment files directory, without the history
+func pluginDirs(deploymentDir string) ([]string, error) {
+	entries, err := os.ReadDir(deploymentDir)
+	if err != nil {
+		return nil, err
+	}
+	dirs := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() && entry.Name() != history.DirName && !strings.HasPrefix(entry.Name(), ".") {
+			dirs = append(dirs, filepath.Join(deploymentDir, entry.Name()))
+		}
+	}
+	return dirs, nil
+}
@@ -0,0 +1,236 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/history"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+	pluginapi "github.com/nvidia/k8s-launch-kit/pkg/plugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+)
+
+const testPlugin = "rollback-test"
+
+// createPlugin deploys the saved deployment files by creating or updating their objects,
+// the fake client does not support server-side apply
+type createPlugin struct {
+	pluginapi.Plugin
+}
+
+func (p *createPlugin) GetName() string    { return testPlugin }
+func (p *createPlugin) GetVersion() string { return pluginapi.APIVersion }
+
+func (p *createPlugin) DeployProfile(ctx context.Context, _ *profiles.Profile, _ *config.LaunchKubernetesConfig, c client.Client, manifestsDir string) error {
+	paths, err := filepath.Glob(filepath.Join(manifestsDir, "*.yaml"))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		objects, err := kubeclient.DecodeManifests(content)
+		if err != nil {
+			return err
+		}
+		for _, obj := range objects {
+			obj.SetLabels(map[string]string{kubeclient.ManagedByLabel: kubeclient.FieldOwner})
+			if err := c.Create(ctx, obj); apierrors.IsAlreadyExists(err) {
+				err = c.Update(ctx, obj)
+			} else if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func init() {
+	pluginapi.Register(pluginapi.Registration{
+		Metadata: pluginapi.Metadata{Name: testPlugin, Version: pluginapi.APIVersion},
+		New:      func() pluginapi.Plugin { return &createPlugin{} },
+	})
+}
+
+func configMapManifest(name, value string) string {
+	return "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: " + name + "\n  namespace: default\ndata:\n  value: " + value + "\n"
+}
+
+func testRevision(number int, files map[string]string) *history.Revision {
+	return &history.Revision{
+		Number:   number,
+		Time:     time.Date(2025, 6, 1, 12, 0, number, 0, time.UTC),
+		Profiles: []profiles.Profile{{Name: "Test", Plugin: testPlugin, Templates: []string{"configmap.yaml"}}},
+		Config: &config.LaunchKubernetesConfig{
+			NetworkOperator: &config.NetworkOperatorConfig{Namespace: "nvidia-network-operator"},
+		},
+		Files: map[string]map[string]string{testPlugin: files},
+	}
+}
+
+func TestRollback(t *testing.T) {
+	dir := t.TempDir()
+	first := testRevision(1, map[string]string{
+		"10-a.yaml": configMapManifest("a", "v1"),
+		"20-b.yaml": configMapManifest("b", "v1"),
+	})
+	second := testRevision(2, map[string]string{
+		"10-a.yaml": configMapManifest("a", "v2"),
+		"30-c.yaml": configMapManifest("c", "v2"),
+	})
+	for _, revision := range []*history.Revision{first, second} {
+		if err := history.Save(dir, revision, config.DefaultMaxRevisions); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	managed := map[string]string{kubeclient.ManagedByLabel: kubeclient.FieldOwner}
+	c := fake.NewClientBuilder().WithScheme(kubeclient.NewScheme()).WithObjects(
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "default", Labels: managed}, Data: map[string]string{"value": "v2"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "default", Labels: managed}, Data: map[string]string{"value": "v2"}},
+	).Build()
+	l := &Launcher{
+		options:    options.Options{SaveDeploymentFiles: dir, SkipPreflight: true},
+		logger:     log.Log,
+		plugins:    map[string]pluginapi.Plugin{},
+		kubeClient: c,
+	}
+
+	if err := l.rollback(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	for name, want := range map[string]string{"a": "v1", "b": "v1", "c": ""} {
+		cm := &corev1.ConfigMap{}
+		err := c.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, cm)
+		if want == "" {
+			if !apierrors.IsNotFound(err) {
+				t.Errorf("ConfigMap %s of the current revision only must be pruned: %v", name, err)
+			}
+			continue
+		}
+		if err != nil || cm.Data["value"] != want {
+			t.Errorf("ConfigMap %s = %v, %v, want the value %s", name, cm.Data, err, want)
+		}
+	}
+
+	saved, err := filepath.Glob(filepath.Join(dir, testPlugin, "*.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{filepath.Join(dir, testPlugin, "10-a.yaml"), filepath.Join(dir, testPlugin, "20-b.yaml")}; !reflect.DeepEqual(saved, want) {
+		t.Errorf("saved deployment files = %v, want %v", saved, want)
+	}
+
+	recorded, err := history.Load(dir, 3)
+	if err != nil {
+		t.Fatalf("the rollback must be recorded as revision 3: %v", err)
+	}
+	if recorded.RollbackOf != 1 || !reflect.DeepEqual(recorded.Files, first.Files) {
+		t.Errorf("revision 3 = %+v, want a rollback to revision 1 with its files", recorded)
+	}
+
+	l.options.RollbackTo = 3
+	if err := l.rollback(ctx); err == nil {
+		t.Errorf("rolling back to the current revision must fail")
+	}
+	l.options.RollbackTo = 7
+	if err := l.rollback(ctx); err == nil {
+		t.Errorf("rolling back to a missing revision must fail")
+	}
+}
+
+func TestNewRevisionIsNumberedAfterTheCluster(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	c := fake.NewClientBuilder().WithScheme(kubeclient.NewScheme()).Build()
+	for _, number := range []int{1, 2} {
+		if err := history.Save(dir, testRevision(number, nil), config.DefaultMaxRevisions); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Revision 4 was deployed from another deployment files directory
+	if err := history.SaveToCluster(ctx, c, config.HistoryStorageConfigMap, "nvidia-network-operator", testRevision(4, nil), config.DefaultMaxRevisions); err != nil {
+		t.Fatal(err)
+	}
+
+	l := &Launcher{options: options.Options{SaveDeploymentFiles: dir}, logger: log.Log, kubeClient: c}
+	cfg := &config.LaunchKubernetesConfig{History: &config.HistoryConfig{Storage: config.HistoryStorageConfigMap}}
+	latest, err := l.latestRevision(ctx, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest == nil || latest.Number != 4 {
+		t.Fatalf("latestRevision() = %+v, want revision 4 of the cluster", latest)
+	}
+	revision, err := l.newRevision(latest.Profiles, cfg, latest, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if revision.Number != 5 {
+		t.Errorf("newRevision() = %d, want 5", revision.Number)
+	}
+
+	// Without cluster storage, the history on disk is the latest
+	latest, err = l.latestRevision(ctx, &config.LaunchKubernetesConfig{})
+	if err != nil || latest == nil || latest.Number != 2 {
+		t.Errorf("latestRevision() without cluster storage = %+v, %v, want revision 2", latest, err)
+	}
+}
+
+func TestRevisionObjects(t *testing.T) {
+	revision := &history.Revision{
+		Profiles: []profiles.Profile{{Name: "Second", Plugin: "second"}, {Name: "First", Plugin: "first"}},
+		Files: map[string]map[string]string{
+			"first": {
+				"20-b.yaml": configMapManifest("b", "v1"),
+				"10-a.yaml": configMapManifest("a", "v1") + "---\n" + configMapManifest("a2", "v1"),
+			},
+			"second": {
+				"10-c.yaml":       configMapManifest("c", "v1"),
+				"15-invalid.yaml": "kind: [",
+			},
+			"not-deployed": {"10-d.yaml": configMapManifest("d", "v1")},
+		},
+	}
+
+	names := []string{}
+	for _, obj := range revisionObjects(revision) {
+		names = append(names, obj.GetName())
+	}
+	if want := []string{"c", "a", "a2", "b"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("revisionObjects() = %v, want %v", names, want)
+	}
+}
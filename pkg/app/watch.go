@@ -0,0 +1,322 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+	pluginapi "github.com/nvidia/k8s-launch-kit/pkg/plugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+	"gopkg.in/yaml.v2"
+)
+
+// watchSettleTime is how long the watched objects must not change before the cluster config is refreshed,
+// so that a node joining with several NICs triggers a single refresh
+const watchSettleTime = 10 * time.Second
+
+// watch refreshes the cluster config whenever the objects watched by the plugins change, until ctx is done.
+// If a worker node does not match a deployed profile, the mismatch is reported and nothing is regenerated.
+// Otherwise a changed cluster config is saved if it was discovered, the deployment files are regenerated and,
+// with --deploy, deployed.
+func (l *Launcher) watch(ctx context.Context, foundProfiles []profiles.Profile, fullConfig *config.LaunchKubernetesConfig) error {
+	watchers := []pluginapi.Watcher{}
+	for _, plugin := range l.orderedPlugins() {
+		if watcher, ok := plugin.(pluginapi.Watcher); ok {
+			watchers = append(watchers, watcher)
+		}
+	}
+	if len(watchers) == 0 {
+		return fmt.Errorf("none of the enabled plugins supports watching the cluster")
+	}
+
+	restCfg, err := kubeclient.NewConfig(l.clientOptions())
+	if err != nil {
+		return err
+	}
+	informers, err := cache.New(restCfg, cache.Options{Scheme: kubeclient.NewScheme()})
+	if err != nil {
+		return fmt.Errorf("failed to create the informers: %w", err)
+	}
+
+	changed := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+	// Updates of the node heartbeats and of the other status fields would keep postponing the refresh
+	handler := toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(any) { notify() },
+		UpdateFunc: func(oldObj, newObj any) {
+			if watchedStateChanged(oldObj, newObj) {
+				notify()
+			}
+		},
+		DeleteFunc: func(any) { notify() },
+	}
+	for _, watcher := range watchers {
+		for _, obj := range watcher.WatchedObjects() {
+			informer, err := informers.GetInformer(ctx, obj)
+			if err != nil {
+				return fmt.Errorf("failed to watch %T: %w", obj, err)
+			}
+			if _, err := informer.AddEventHandler(handler); err != nil {
+				return err
+			}
+		}
+	}
+
+	go func() {
+		if err := informers.Start(ctx); err != nil {
+			l.logger.Error(err, "Watch stopped")
+		}
+	}()
+	if !informers.WaitForCacheSync(ctx) {
+		return fmt.Errorf("failed to sync the watched objects: %w", ctx.Err())
+	}
+	l.logger.Info("Watching the cluster for changes")
+
+	settled := time.NewTimer(watchSettleTime)
+	settled.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			l.logger.Info("Watch stopped")
+			return nil
+		case <-changed:
+			settled.Reset(watchSettleTime)
+		case <-settled.C:
+			if err := l.refresh(ctx, watchers, foundProfiles, fullConfig); err != nil {
+				l.logger.Error(err, "Failed to refresh the cluster config")
+			}
+		}
+	}
+}
+
+// refresh refreshes the cluster config of fullConfig with the watchers and regenerates the deployment files if it changed
+func (l *Launcher) refresh(ctx context.Context, watchers []pluginapi.Watcher, foundProfiles []profiles.Profile, fullConfig *config.LaunchKubernetesConfig) error {
+	// The refreshed config replaces fullConfig once applied, a mismatch is reported again on the next refresh
+	refreshed, err := copyConfig(fullConfig)
+	if err != nil {
+		return err
+	}
+
+	nodes := map[string]config.NodesCapabilities{}
+	for _, watcher := range watchers {
+		capabilities, err := watcher.RefreshClusterConfig(ctx, refreshed, l.kubeClient)
+		if err != nil {
+			return err
+		}
+		for node, c := range capabilities {
+			merged := nodes[node]
+			merged.Sriov = merged.Sriov || c.Sriov
+			merged.Rdma = merged.Rdma || c.Rdma
+			merged.Ib = merged.Ib || c.Ib
+			merged.Gpu = merged.Gpu || c.Gpu
+			nodes[node] = merged
+		}
+	}
+
+	names := make([]string, 0, len(nodes))
+	for node := range nodes {
+		names = append(names, node)
+	}
+	sort.Strings(names)
+	mismatches := 0
+	for _, node := range names {
+		capabilities := nodes[node]
+		for _, profile := range foundProfiles {
+			if ok, reason := profile.Validate(refreshed.Profile, &config.ClusterCapabilities{Nodes: &capabilities}); !ok {
+				mismatches++
+				l.logger.Error(fmt.Errorf("%s", reason), "Deployed profile does not match the hardware of the node", "node", node, "profile", profile.Name)
+			}
+		}
+	}
+	if mismatches > 0 {
+		l.logger.Info("Deployment files not regenerated, fix the nodes or select another profile", "mismatches", mismatches)
+		return nil
+	}
+
+	previous, err := yaml.Marshal(fullConfig.ClusterConfig)
+	if err != nil {
+		return err
+	}
+	current, err := yaml.Marshal(refreshed.ClusterConfig)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(previous, current) {
+		l.logger.V(1).Info("Cluster config unchanged")
+		return nil
+	}
+	added, removed := diffNodes(fullConfig.ClusterConfig.WorkerNodes, refreshed.ClusterConfig.WorkerNodes)
+	l.logger.Info("Cluster config changed", "addedNodes", added, "removedNodes", removed, "pfs", len(refreshed.ClusterConfig.PFs))
+	*fullConfig = *refreshed
+
+	if l.options.DiscoverClusterConfig && l.options.SaveClusterConfig != "" {
+		discovered := *fullConfig
+		discovered.Profile = nil
+		if err := l.saveClusterConfig(&discovered); err != nil {
+			return err
+		}
+	}
+
+	for _, profile := range foundProfiles {
+		if err := l.generateDeploymentFiles(ctx, &profile, fullConfig); err != nil {
+			return fmt.Errorf("deployment files generation failed: %w", err)
+		}
+	}
+	if l.options.Deploy {
+		if err := l.deploy(ctx, foundProfiles, fullConfig, 0); err != nil {
+			return fmt.Errorf("deployment failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// diffNodes returns the nodes of current missing from previous and the nodes of previous missing from current
+func diffNodes(previous, current []string) ([]string, []string) {
+	added, removed := []string{}, []string{}
+	for _, node := range current {
+		if !slices.Contains(previous, node) {
+			added = append(added, node)
+		}
+	}
+	for _, node := range previous {
+		if !slices.Contains(current, node) {
+			removed = append(removed, node)
+		}
+	}
+	return added, removed
+}
+
+// clusterFingerprint returns a digest of the state of the objects watched by the plugins, see watchedState,
+// empty if no enabled plugin watches the cluster
+func (l *Launcher) clusterFingerprint(ctx context.Context) (string, error) {
+	hash := sha256.New()
+	watched := false
+	for _, plugin := range l.orderedPlugins() {
+		watcher, ok := plugin.(pluginapi.Watcher)
+		if !ok {
+			continue
+		}
+		watched = true
+		for _, obj := range watcher.WatchedObjects() {
+			gvk, err := apiutil.GVKForObject(obj, l.kubeClient.Scheme())
+			if err != nil {
+				return "", err
+			}
+			listObj, err := l.kubeClient.Scheme().New(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+			if err != nil {
+				return "", err
+			}
+			list, ok := listObj.(client.ObjectList)
+			if !ok {
+				return "", fmt.Errorf("%s is not a list", gvk.Kind+"List")
+			}
+			if err := l.kubeClient.List(ctx, list); err != nil {
+				return "", fmt.Errorf("failed to list %s: %w", gvk.Kind, err)
+			}
+			items, err := meta.ExtractList(list)
+			if err != nil {
+				return "", err
+			}
+
+			states := []string{}
+			for _, item := range items {
+				obj, ok := item.(client.Object)
+				if !ok {
+					continue
+				}
+				state, err := json.Marshal(watchedState(obj))
+				if err != nil {
+					return "", err
+				}
+				states = append(states, fmt.Sprintf("%s %s %s", gvk.Kind, client.ObjectKeyFromObject(obj), state))
+			}
+			sort.Strings(states)
+			for _, state := range states {
+				fmt.Fprintln(hash, state)
+			}
+		}
+	}
+	if !watched {
+		return "", nil
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// watchedState returns the state of a watched object the discovered cluster config depends on: the labels, capacity
+// and allocatable resources of a Node, which change with its NICs and GPUs but not with its heartbeats,
+// and the labels, spec and status of the other objects
+func watchedState(obj client.Object) map[string]any {
+	if node, ok := obj.(*corev1.Node); ok {
+		return map[string]any{"labels": node.Labels, "capacity": node.Status.Capacity, "allocatable": node.Status.Allocatable}
+	}
+	state := map[string]any{"labels": obj.GetLabels()}
+	if content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj); err == nil {
+		state["spec"] = content["spec"]
+		state["status"] = content["status"]
+	}
+	return state
+}
+
+// watchedStateChanged returns whether the watched state of an updated object changed, see watchedState
+func watchedStateChanged(oldObj, newObj any) bool {
+	oldWatched, ok := oldObj.(client.Object)
+	if !ok {
+		return true
+	}
+	newWatched, ok := newObj.(client.Object)
+	if !ok {
+		return true
+	}
+	return !equality.Semantic.DeepEqual(watchedState(oldWatched), watchedState(newWatched))
+}
+
+// copyConfig returns a deep copy of cfg
+func copyConfig(cfg *config.LaunchKubernetesConfig) (*config.LaunchKubernetesConfig, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	copied := &config.LaunchKubernetesConfig{}
+	if err := yaml.Unmarshal(data, copied); err != nil {
+		return nil, err
+	}
+	return copied, nil
+}
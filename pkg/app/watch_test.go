@@ -0,0 +1,74 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	nicop "github.com/Mellanox/nic-configuration-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWatchedStateChanged(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1", ResourceVersion: "1", Labels: map[string]string{"feature.node.kubernetes.io/pci-15b3.present": "true"}},
+		Status: corev1.NodeStatus{
+			Capacity:    corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("8")},
+			Allocatable: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("8")},
+		},
+	}
+	device := &nicop.NicDevice{ObjectMeta: metav1.ObjectMeta{Name: "node-1-mt2232t", Namespace: "nvidia-network-operator"}}
+
+	tests := []struct {
+		name   string
+		old    any
+		update func(node *corev1.Node, device *nicop.NicDevice) any
+		want   bool
+	}{
+		{name: "heartbeat", old: node, update: func(node *corev1.Node, _ *nicop.NicDevice) any {
+			node.ResourceVersion = "2"
+			node.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, LastHeartbeatTime: metav1.NewTime(time.Now())}}
+			return node
+		}},
+		{name: "allocatable quantity in another format", old: node, update: func(node *corev1.Node, _ *nicop.NicDevice) any {
+			node.Status.Allocatable["nvidia.com/gpu"] = *resource.NewQuantity(8, resource.DecimalSI)
+			return node
+		}},
+		{name: "label", old: node, want: true, update: func(node *corev1.Node, _ *nicop.NicDevice) any {
+			node.Labels["nvidia.com/gpu.present"] = "true"
+			return node
+		}},
+		{name: "allocatable", old: node, want: true, update: func(node *corev1.Node, _ *nicop.NicDevice) any {
+			node.Status.Allocatable["nvidia.com/gpu"] = resource.MustParse("7")
+			return node
+		}},
+		{name: "NicDevice status", old: device, want: true, update: func(_ *corev1.Node, device *nicop.NicDevice) any {
+			device.Status.Node = "node-1"
+			return device
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := watchedStateChanged(tt.old, tt.update(node.DeepCopy(), device.DeepCopy())); got != tt.want {
+				t.Errorf("watchedStateChanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/api/v1alpha1"
+	"github.com/nvidia/k8s-launch-kit/pkg/app"
+	"github.com/nvidia/k8s-launch-kit/pkg/controller"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+)
+
+var (
+	controllerDefaults     string
+	metricsBindAddress     string
+	healthProbeBindAddress string
+	leaderElect            bool
+)
+
+// controllerCmd represents the controller command
+var controllerCmd = &cobra.Command{
+	Use:   "controller",
+	Short: "Run l8k as an in-cluster controller reconciling LaunchKitConfig objects",
+	Long: `Run l8k as a controller reconciling the cluster-scoped LaunchKitConfig custom resources (l8k.nvidia.com/v1alpha1).
+The spec of a LaunchKitConfig holds the l8k configuration and the profile selection. Every reconciliation rediscovers
+the cluster, renders the selected profiles and applies them, on spec changes and every spec.resyncPeriod (default 10m).
+Resyncs reuse the previous discovery while the watched nodes and NICs are unchanged, and deploy only changed files.
+The outcome of every phase is reported in the status conditions: Discovered, ProfilesSelected, Rendered, Deployed,
+Verified and Ready. The CRD and the manifests of the controller are in deploy/controller.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runController(); err != nil {
+			logger.Error(err, "Controller failed")
+			os.Exit(1)
+		}
+	},
+}
+
+func runController() error {
+	restCfg, err := kubeclient.NewConfig(app.ClientOptions(opts))
+	if err != nil {
+		return err
+	}
+	scheme := kubeclient.NewScheme()
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		return err
+	}
+
+	mgr, err := ctrl.NewManager(restCfg, ctrl.Options{
+		Scheme:                 scheme,
+		Metrics:                metricsserver.Options{BindAddress: metricsBindAddress},
+		HealthProbeBindAddress: healthProbeBindAddress,
+		LeaderElection:         leaderElect,
+		LeaderElectionID:       "l8k.nvidia.com",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create the manager: %w", err)
+	}
+
+	// The workflow reads objects of many kinds once per reconciliation, it does not need the informers of the cache
+	kubeClient, err := kubeclient.New(app.ClientOptions(opts))
+	if err != nil {
+		return fmt.Errorf("failed to create k8s client: %w", err)
+	}
+
+	reconciler := &controller.LaunchKitConfigReconciler{
+		Client:       mgr.GetClient(),
+		KubeClient:   kubeClient,
+		Options:      opts,
+		DefaultsPath: controllerDefaults,
+	}
+	if err := reconciler.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("failed to set up the LaunchKitConfig controller: %w", err)
+	}
+	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+		return err
+	}
+	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+		return err
+	}
+
+	logger.Info("Starting the LaunchKitConfig controller")
+	return mgr.Start(ctrl.SetupSignalHandler())
+}
+
+func init() {
+	addClusterFlags(controllerCmd.Flags())
+	controllerCmd.Flags().StringVar(&controllerDefaults, "defaults", "l8k-config.yaml", "Configuration file the config of the LaunchKitConfig spec is merged over")
+	controllerCmd.Flags().StringVar(&opts.SaveDeploymentFiles, "state-dir", "/var/lib/l8k", "Directory of the deployment files and history, one subdirectory per LaunchKitConfig")
+	controllerCmd.Flags().BoolVar(&opts.SkipPreflight, "skip-preflight", false, "Skip the permission and cluster prerequisite checks run before the discovery and deployment")
+	controllerCmd.Flags().StringVar(&opts.PluginsDir, "plugins-dir", "/opt/nvidia/k8s-launch-kit/plugins", "Directory with out-of-tree l8k-plugin-<name> executables, searched before $PATH")
+	controllerCmd.Flags().StringVar(&metricsBindAddress, "metrics-bind-address", "0", "Address the metrics endpoint binds to, 0 disables it")
+	controllerCmd.Flags().StringVar(&healthProbeBindAddress, "health-probe-bind-address", ":8081", "Address the health probe endpoint binds to")
+	controllerCmd.Flags().BoolVar(&leaderElect, "leader-elect", false, "Enable leader election, for controllers with several replicas")
+	rootCmd.AddCommand(controllerCmd)
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/app"
+)
+
+// driftCmd represents the drift command
+var driftCmd = &cobra.Command{
+	Use:   "drift",
+	Short: "Compare the saved deployment files with the live cluster",
+	Long: `Compare the deployment files saved by the last run with the live objects and list the fields that differ.
+The owners of the live fields are read from the managedFields of the objects: fields owned by other field managers
+than l8k, e.g. edited by hand, are conflicts that the next deployment only overwrites with --force-conflicts.
+Fields owned by l8k differ when the profile or the config changed since the last deployment.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !slices.Contains([]string{"table", "json"}, opts.Output) {
+			logger.Error(fmt.Errorf("--output must be one of: table, json"), "Invalid command line arguments")
+			os.Exit(1)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		if err := app.New(opts).Drift(ctx); err != nil {
+			stop()
+			fmt.Printf("\nFatal error: %s\n", err)
+			fmt.Println()
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	driftCmd.Flags().StringVar(&opts.SaveDeploymentFiles, "save-deployment-files", "/opt/nvidia/k8s-launch-kit/deployment", "Directory of the saved deployment files, one subdirectory per plugin")
+	addClusterFlags(driftCmd.Flags())
+	driftCmd.Flags().StringVarP(&opts.Output, "output", "o", "table", "Output format (table, json)")
+	rootCmd.AddCommand(driftCmd)
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/plugin"
+)
+
+// pluginsCmd represents the plugins command
+var pluginsCmd = &cobra.Command{
+	Use:   "plugins",
+	Short: "List the built-in plugins",
+	Long:  `List the built-in plugins with their version, dependencies, the profile requirements they match on and the CLI flags they contribute.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tVERSION\tDEPENDS ON\tPROFILE REQUIREMENTS\tFLAGS\tREQUIRED FLAGS\tNEEDS KUBECONFIG")
+		for _, registration := range plugin.Registered() {
+			flags := []string{}
+			rootCmd.Flags().VisitAll(func(flag *pflag.Flag) {
+				if owner := flag.Annotations[pluginFlagAnnotation]; len(owner) > 0 && owner[0] == registration.Name {
+					flags = append(flags, "--"+flag.Name)
+				}
+			})
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%t\n",
+				registration.Name,
+				registration.Version,
+				orNone(strings.Join(registration.DependsOn, ",")),
+				orNone(strings.Join(registration.ProfileRequirements, ",")),
+				orNone(strings.Join(flags, ",")),
+				orNone(strings.Join(registration.RequiredFlags, ",")),
+				registration.NeedsKubeconfig)
+		}
+		w.Flush()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pluginsCmd)
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// preflightCmd represents the preflight command
+var preflightCmd = &cobra.Command{
+	Use:   "preflight",
+	Short: "Check the permissions and the cluster prerequisites of the deployment without deploying",
+	Long: `Generate the deployment files of the profiles selected with the same flags as l8k and run the checks that --deploy
+runs before applying anything: the permissions of the deployment (and of the verification and watch with --verify and
+--watch), the CRDs and versions of the kinds of the deployment files, and the prerequisites checked by the plugins, e.g.
+the network-operator version, NFD, other Multus installations, the node kernels supported by the DOCA driver, Secure Boot
+and loaded in-tree storage modules. Blockers and missing permissions make the command fail, warnings are only reported.`,
+	Example: `  l8k preflight --user-config ./config.yaml --fabric ethernet --deployment-type sriov --multirail \
+    --save-deployment-files ./deployment`,
+	Run: func(cmd *cobra.Command, args []string) {
+		opts.Preflight = true
+		opts.SkipPreflight = false
+		rootCmd.Run(cmd, args)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(preflightCmd)
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/app"
+)
+
+// rollbackCmd represents the rollback command
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "Roll back to a previous deployment",
+	Long: `Re-deploy a revision of the deployment history with the config and profiles it was deployed with.
+Every deployment records a revision in the history subdirectory of the saved deployment files, and in a ConfigMap
+or Secret of the operator namespace when history.storage is set in the config. The revision before the latest one
+is deployed unless --to is given, in the same order as a regular deployment, and the objects of the latest revision
+that it does not contain are deleted. The rollback is recorded as a new revision.`,
+	Example: `  l8k rollback --context my-cluster
+  l8k rollback --context my-cluster --to 3`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if opts.RollbackTo < 0 {
+			logger.Error(fmt.Errorf("--to must be a revision number"), "Invalid command line arguments")
+			os.Exit(1)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		if err := app.New(opts).Rollback(ctx); err != nil {
+			stop()
+			fmt.Printf("\nFatal error: %s\n", err)
+			fmt.Println()
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rollbackCmd.Flags().IntVar(&opts.RollbackTo, "to", 0, "Revision to roll back to (default: the revision before the latest one)")
+	rollbackCmd.Flags().StringVar(&opts.SaveDeploymentFiles, "save-deployment-files", "/opt/nvidia/k8s-launch-kit/deployment", "Directory of the saved deployment files and of the deployment history")
+	addClusterFlags(rollbackCmd.Flags())
+	rollbackCmd.Flags().BoolVar(&opts.ForceConflicts, "force-conflicts", false, "Take over the fields owned by other field managers")
+	rollbackCmd.Flags().BoolVar(&opts.SkipPreflight, "skip-preflight", false, "Skip the permission and cluster prerequisite checks run before the deployment")
+	rollbackCmd.Flags().StringVar(&opts.PluginsDir, "plugins-dir", "/opt/nvidia/k8s-launch-kit/plugins", "Directory with out-of-tree l8k-plugin-<name> executables, searched before $PATH")
+	rollbackCmd.Flags().DurationVar(&opts.Timeout, "timeout", 0, "Overall timeout of the rollback (0 means no timeout)")
+	rootCmd.AddCommand(rollbackCmd)
+}
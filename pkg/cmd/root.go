@@ -17,39 +17,39 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"slices"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/nvidia/k8s-launch-kit/pkg/app"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
 	applog "github.com/nvidia/k8s-launch-kit/pkg/log"
-	"github.com/nvidia/k8s-launch-kit/pkg/networkoperatorplugin"
 	"github.com/nvidia/k8s-launch-kit/pkg/options"
+	"github.com/nvidia/k8s-launch-kit/pkg/plugin"
+
+	// Built-in plugins register themselves
+	_ "github.com/nvidia/k8s-launch-kit/pkg/gpuoperatorplugin"
+	_ "github.com/nvidia/k8s-launch-kit/pkg/networkoperatorplugin"
+	_ "github.com/nvidia/k8s-launch-kit/pkg/nicconfigurationplugin"
 )
 
+// pluginFlagAnnotation is the flag annotation holding the name of the plugin that contributed the flag
+const pluginFlagAnnotation = "l8k/plugin"
+
 var (
-	logLevel              string
-	fabric                string
-	deploymentType        string
-	multirail             bool
-	spectrumX             bool
-	ai                    bool
-	prompt                string
-	llmApiKey             string
-	llmApiUrl             string
-	llmVendor             string
-	saveDeploymentFiles   string
-	deploy                bool
-	kubeconfig            string
-	userConfig            string
-	discoverClusterConfig bool
-	saveClusterConfig     string
-	logger                = log.Log.WithName("l8k")
-	enabledPlugins        string
+	// opts is bound to the CLI flags, including the ones contributed by the plugins
+	opts           options.Options
+	logger         = log.Log.WithName("l8k")
+	enabledPlugins string
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -63,7 +63,9 @@ K8s Launch Kit (l8k) is a CLI tool for deploying and managing NVIDIA cloud-nativ
 Deploy a minimal Network Operator profile to automatically discover your cluster's
 network capabilities and hardware configuration by using --discover-cluster-config.
 This phase can be skipped if you provide your own configuration file by using --user-config.
-This phase requires --kubeconfig to be specified.
+This phase requires access to the cluster, see Cluster access. With --install-operators, the network-operator Helm chart
+is installed or upgraded first, at the version of the config and with values derived from the profile and the cluster,
+once the permissions of the installation are checked.
 
 ### Generate Deployment Files
 Based on the discovered or provided configuration, 
@@ -73,41 +75,42 @@ The profile can be defined manually with --fabric, --deployment-type and --multi
 OR generated by an LLM-assisted profile generator with --prompt (requires --llm-api-key and --llm-vendor).
 
 ### Deploy to Cluster
-Apply the generated deployment files to your Kubernetes cluster by using --deploy. This phase requires --kubeconfig and can be skipped if --deploy is not specified.`,
+Apply the generated deployment files to your Kubernetes cluster by using --deploy. This phase requires access to the cluster and can be skipped if --deploy is not specified.
+Deployment fails before applying anything if it would overwrite fields owned by other field managers, e.g. edited by hand,
+unless --force-conflicts is specified. Use the drift command to review the differences.
+
+### Verify Deployment
+Run RDMA connectivity, bandwidth and latency tests between pods on two nodes over the deployed networks by using --verify,
+or with the verify command on an existing deployment. Thresholds are set in the verification section of the config file.
+This phase requires access to the cluster and can be skipped if --verify is not specified.
+
+### Cluster access
+The cluster is selected with the kubectl loading rules: --kubeconfig, else the KUBECONFIG files, else ~/.kube/config,
+falling back to the service account of the pod when l8k runs inside the cluster, e.g. as a Job. --context selects
+another context than the current one, --as and --as-group impersonate a user and groups.
+Before discovery and deployment, l8k checks with SelfSubjectAccessReviews that the user has the permissions the phases need,
+and otherwise prints the missing ones with a ClusterRole granting them. Before deployment, it also checks the prerequisites
+of the profiles in the cluster, e.g. CRDs, operator version, NFD and node kernels, and fails on blockers. --skip-preflight
+disables the checks, the preflight command runs them without deploying.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		enabledPlugins := parseEnabledPlugins(enabledPlugins)
-		// Create application options from CLI flags
-		options := options.Options{
-			LogLevel:              logLevel,
-			UserConfig:            userConfig,
-			DiscoverClusterConfig: discoverClusterConfig,
-			Fabric:                fabric,
-			DeploymentType:        deploymentType,
-			Multirail:             multirail,
-			SpectrumX:             spectrumX,
-			Ai:                    ai,
-			Prompt:                prompt,
-			SaveDeploymentFiles:   saveDeploymentFiles,
-			Deploy:                deploy,
-			Kubeconfig:            kubeconfig,
-			SaveClusterConfig:     saveClusterConfig,
-			EnabledPlugins:        enabledPlugins,
-			LLMApiKey:             llmApiKey,
-			LLMApiUrl:             llmApiUrl,
-			LLMVendor:             llmVendor,
-		}
+		opts.EnabledPlugins = parseEnabledPlugins(enabledPlugins)
 
 		// Validate CLI configuration
-		if err := validateConfig(options); err != nil {
+		if err := validateConfig(cmd.Flags(), opts); err != nil {
 			logger.Error(err, "Invalid command line arguments")
 			os.Exit(1)
 		}
 
-		logger.Info("SaveConfig", "val", options)
+		logger.Info("SaveConfig", "val", opts)
+
+		// Cancel the workflow on Ctrl-C / SIGTERM, pending waits return and discovery cleans up after itself
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
 
 		// Create and run the application
-		launcher := app.New(options)
-		if err := launcher.Run(); err != nil {
+		launcher := app.New(opts)
+		if err := launcher.Run(ctx); err != nil {
+			stop()
 			fmt.Printf("\nFatal error: %s\n", err)
 			fmt.Println()
 			os.Exit(1)
@@ -129,33 +132,75 @@ func init() {
 
 	// Phase 0: Plugin flags
 	rootCmd.Flags().StringVar(&enabledPlugins, "enabled-plugins", "network-operator", "Comma-separated list of plugins to enable")
+	rootCmd.Flags().StringVar(&opts.PluginsDir, "plugins-dir", "/opt/nvidia/k8s-launch-kit/plugins", "Directory with out-of-tree l8k-plugin-<name> executables, searched before $PATH")
 
 	// Phase 1: Cluster discovery flags
-	rootCmd.Flags().BoolVar(&discoverClusterConfig, "discover-cluster-config", false, "Deploy a thin Network Operator profile to discover cluster capabilities")
-	rootCmd.Flags().StringVar(&saveClusterConfig, "save-cluster-config", "/opt/nvidia/k8s-launch-kit/cluster-config.yaml", "Save discovered cluster configuration to the specified path")
-	rootCmd.Flags().StringVar(&userConfig, "user-config", "", "Use provided cluster configuration file instead of auto-discovery (skips cluster discovery)")
+	rootCmd.Flags().BoolVar(&opts.DiscoverClusterConfig, "discover-cluster-config", false, "Deploy a thin Network Operator profile to discover cluster capabilities")
+	rootCmd.Flags().StringVar(&opts.SaveClusterConfig, "save-cluster-config", "/opt/nvidia/k8s-launch-kit/cluster-config.yaml", "Save discovered cluster configuration to the specified path")
+	rootCmd.Flags().StringVar(&opts.UserConfig, "user-config", "", "Use provided cluster configuration file instead of auto-discovery (skips cluster discovery)")
+	rootCmd.Flags().BoolVar(&opts.InstallOperators, "install-operators", false, "Install or upgrade the operators of the enabled plugins with Helm before discovery, e.g. the network-operator chart at networkOperator.version")
 
 	// Phase 2: Deployment generation flags
-	rootCmd.Flags().StringVar(&fabric, "fabric", "", "Select the fabric type to deploy (infiniband, ethernet)")
-	rootCmd.Flags().StringVar(&deploymentType, "deployment-type", "", "Select the deployment type (sriov, rdma_shared, host_device)")
-	rootCmd.Flags().BoolVar(&multirail, "multirail", false, "Enable multirail deployment")
-	rootCmd.Flags().BoolVar(&spectrumX, "spectrum-x", false, "Enable Spectrum X deployment")
-	rootCmd.Flags().BoolVar(&ai, "ai", false, "Enable AI deployment")
-	rootCmd.Flags().StringVar(&prompt, "prompt", "", "Path to file with a prompt to use for LLM-assisted profile generation")
-	rootCmd.Flags().StringVar(&llmApiKey, "llm-api-key", "", "API key for the LLM API (required when using --prompt)")
-	rootCmd.Flags().StringVar(&llmApiUrl, "llm-api-url", "", "API URL for the LLM API (required when using --prompt)")
-	rootCmd.Flags().StringVar(&llmVendor, "llm-vendor", "openai-azure", "Vendor of the LLM API (required when using --prompt)")
-	rootCmd.Flags().StringVar(&saveDeploymentFiles, "save-deployment-files", "/opt/nvidia/k8s-launch-kit/deployment", "Save generated deployment files to the specified directory")
+	rootCmd.Flags().StringVar(&opts.Prompt, "prompt", "", "Path to file with a prompt to use for LLM-assisted profile generation")
+	rootCmd.Flags().StringVar(&opts.LLMApiKey, "llm-api-key", "", "API key for the LLM API (required when using --prompt)")
+	rootCmd.Flags().StringVar(&opts.LLMApiUrl, "llm-api-url", "", "API URL for the LLM API (required when using --prompt)")
+	rootCmd.Flags().StringVar(&opts.LLMVendor, "llm-vendor", "openai-azure", "Vendor of the LLM API (required when using --prompt)")
+	rootCmd.Flags().StringVar(&opts.SaveDeploymentFiles, "save-deployment-files", "/opt/nvidia/k8s-launch-kit/deployment", "Save generated deployment files to the specified directory")
 
 	// Phase 3: Cluster deployment flags
-	rootCmd.Flags().BoolVar(&deploy, "deploy", false, "Deploy the generated files to the Kubernetes cluster")
-	rootCmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file for cluster deployment (required when using --deploy)")
+	rootCmd.Flags().BoolVar(&opts.Deploy, "deploy", false, "Deploy the generated files to the Kubernetes cluster")
+	addClusterFlags(rootCmd.Flags())
+	rootCmd.Flags().BoolVar(&opts.ForceConflicts, "force-conflicts", false, "Take over the fields of the deployed objects owned by other field managers, e.g. edited by hand, instead of failing")
+	rootCmd.Flags().BoolVar(&opts.SkipPreflight, "skip-preflight", false, "Skip the permission and cluster prerequisite checks run before the discovery and deployment")
+
+	// Phase 4: Deployment verification flags
+	rootCmd.Flags().BoolVar(&opts.Verify, "verify", false, "Verify the deployed networks with RDMA test pods on two nodes")
+
+	// Timeout flags, override the timeouts section of the config file
+	rootCmd.Flags().DurationVar(&opts.Timeout, "timeout", 0, "Overall timeout for the whole workflow (0 means no timeout)")
+	rootCmd.Flags().DurationVar(&opts.DiscoveryTimeout, "discovery-timeout", 0, "Timeout for the cluster discovery phase (0 means no timeout)")
+	rootCmd.Flags().DurationVar(&opts.DeploymentTimeout, "deployment-timeout", 0, "Timeout for the cluster deployment phase (0 means no timeout)")
+	rootCmd.Flags().DurationVar(&opts.PollInterval, "poll-interval", 0, "Initial interval between status polls, grows exponentially up to timeouts.maxPollInterval (default 3s)")
+
+	// Plugin-specific flags, annotated with the plugin that owns them
+	for _, registration := range plugin.Registered() {
+		if registration.AddFlags == nil {
+			continue
+		}
+		flags := pflag.NewFlagSet(registration.Name, pflag.ContinueOnError)
+		registration.AddFlags(flags, &opts)
+		flags.VisitAll(func(flag *pflag.Flag) {
+			flag.Annotations = map[string][]string{pluginFlagAnnotation: {registration.Name}}
+		})
+		rootCmd.Flags().AddFlagSet(flags)
+	}
+
 	// Log level flag
-	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "Log level (debug, info, warn, error)")
+	rootCmd.PersistentFlags().StringVar(&opts.LogLevel, "log-level", "info", "Log level (debug, info, warn, error)")
+
+	addWorkflowFlags(preflightCmd, statusCmd, verifyCmd, watchCmd)
+}
+
+// addWorkflowFlags adds the flags of l8k to the commands running its workflow. It must run once they are all registered,
+// from the init function of this file: the init functions of the files sorted before it run first.
+func addWorkflowFlags(cmds ...*cobra.Command) {
+	for _, cmd := range cmds {
+		cmd.Flags().AddFlagSet(rootCmd.Flags())
+	}
+}
+
+// addClusterFlags adds the flags selecting the cluster and the credentials, with the kubectl names
+func addClusterFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&opts.Kubeconfig, "kubeconfig", "", "Path to the kubeconfig file, KUBECONFIG or ~/.kube/config if empty, else the in-cluster config")
+	flags.StringVar(&opts.Context, "context", "", "Kubeconfig context to use, the current context if empty")
+	flags.StringVar(&opts.As, "as", "", "User to impersonate")
+	flags.StringArrayVar(&opts.AsGroups, "as-group", nil, "Group to impersonate, can be repeated")
+	flags.Float32Var(&opts.KubeAPIQPS, "kube-api-qps", kubeclient.DefaultQPS, "Maximum queries per second to the Kubernetes API server")
+	flags.IntVar(&opts.KubeAPIBurst, "kube-api-burst", kubeclient.DefaultBurst, "Maximum burst of queries to the Kubernetes API server")
 }
 
 // validateConfig validates the CLI flag combinations
-func validateConfig(options options.Options) error {
+func validateConfig(flags *pflag.FlagSet, options options.Options) error {
 	// At least one plugin should be enabled
 	if len(options.EnabledPlugins) == 0 {
 		return fmt.Errorf("no plugins enabled, use --enabled-plugins to enable plugins")
@@ -171,42 +216,55 @@ func validateConfig(options options.Options) error {
 		return fmt.Errorf("--user-config and --discover-cluster-config cannot be used together")
 	}
 
-	// If discover-cluster-config is provided, kubeconfig should be too
-	if options.DiscoverClusterConfig && options.Kubeconfig == "" {
-		return fmt.Errorf("--discover-cluster-config requires --kubeconfig to be specified")
+	if options.InstallOperators && (options.Status || options.Preflight) {
+		return fmt.Errorf("--install-operators cannot be used with the status and preflight commands")
 	}
 
-	// If deploy is provided, kubeconfig should be too
-	if options.Deploy && options.Kubeconfig == "" {
-		return fmt.Errorf("--deploy requires --kubeconfig to be specified")
+	if options.Status && !slices.Contains([]string{"table", "json"}, options.Output) {
+		return fmt.Errorf("--output must be one of: table, json")
 	}
 
-	// Network Operator plugin rules
-	if slices.Contains(options.EnabledPlugins, networkoperatorplugin.PluginName) {
-		// If profile is selected, either save-deployment-files or deploy options should be provided
-		if (options.Fabric != "" || options.DeploymentType != "" || options.Prompt != "") && options.SaveDeploymentFiles == "" && !options.Deploy {
-			return fmt.Errorf("when --deployment-type or --prompt is specified, either --save-deployment-files or --deploy must be provided")
-		}
+	if options.KubeAPIQPS < 0 || options.KubeAPIBurst < 0 {
+		return fmt.Errorf("--kube-api-qps and --kube-api-burst must not be negative")
+	}
 
-		// Save-deployment-files or deploy can't work without profile
-		if options.Fabric == "" && options.DeploymentType == "" && options.Prompt == "" && options.Deploy {
-			return fmt.Errorf("--deploy requires --deployment-type or --prompt to be specified")
+	for name, value := range map[string]time.Duration{
+		"--timeout":            options.Timeout,
+		"--discovery-timeout":  options.DiscoveryTimeout,
+		"--deployment-timeout": options.DeploymentTimeout,
+		"--poll-interval":      options.PollInterval,
+	} {
+		if value < 0 {
+			return fmt.Errorf("%s must not be negative", name)
 		}
+	}
 
-		if options.Prompt != "" && (options.Fabric != "" || options.DeploymentType != "") {
-			return fmt.Errorf("--fabric and --prompt cannot be used together")
+	// Flags of a plugin can only be used when the plugin is enabled
+	var flagErr error
+	flags.Visit(func(flag *pflag.Flag) {
+		if owner := flag.Annotations[pluginFlagAnnotation]; len(owner) > 0 && !slices.Contains(options.EnabledPlugins, owner[0]) && flagErr == nil {
+			flagErr = fmt.Errorf("--%s requires the %s plugin to be enabled", flag.Name, owner[0])
 		}
+	})
+	if flagErr != nil {
+		return flagErr
+	}
 
-		if (options.DeploymentType != "" && options.Fabric == "") || (options.Fabric != "" && options.DeploymentType == "") {
-			return fmt.Errorf("--deployment-type requires --fabric to be specified")
+	// Rules of the enabled built-in plugins, external plugins are validated when loaded
+	for _, name := range options.EnabledPlugins {
+		registration, ok := plugin.Lookup(name)
+		if !ok {
+			continue
 		}
-
-		if options.Fabric != "" && !slices.Contains([]string{"infiniband", "ethernet"}, options.Fabric) {
-			return fmt.Errorf("--fabric must be one of: infiniband, ethernet")
+		for _, required := range registration.RequiredFlags {
+			if !flags.Changed(required) {
+				return fmt.Errorf("plugin %s requires --%s to be specified", name, required)
+			}
 		}
-
-		if options.DeploymentType != "" && !slices.Contains([]string{"sriov", "rdma_shared", "host_device"}, options.DeploymentType) {
-			return fmt.Errorf("--deployment-type must be one of: sriov, rdma_shared, host_device")
+		if registration.Validate != nil {
+			if err := registration.Validate(options); err != nil {
+				return err
+			}
 		}
 	}
 
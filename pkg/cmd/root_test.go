@@ -0,0 +1,39 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"io"
+	"testing"
+)
+
+func TestWorkflowCommandsTakeTheFlagsOfL8k(t *testing.T) {
+	for _, name := range []string{"preflight", "status", "verify", "watch"} {
+		t.Run(name, func(t *testing.T) {
+			userConfig := name + "-config.yaml"
+			rootCmd.SetArgs([]string{name, "--user-config", userConfig, "--fabric", "ethernet", "--kubeconfig", "kubeconfig", "--help"})
+			rootCmd.SetOut(io.Discard)
+			defer rootCmd.SetArgs(nil)
+			if err := rootCmd.Execute(); err != nil {
+				t.Fatalf("l8k %s --user-config: %v", name, err)
+			}
+			if opts.UserConfig != userConfig {
+				t.Errorf("user config = %q, want %q", opts.UserConfig, userConfig)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// statusCmd represents the status command
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Report the health of the deployed profiles",
+	Long: `Report the health of the profiles selected with the same flags as l8k, as deployed in the cluster:
+the objects of the profiles and the other objects of their kinds labelled as managed by l8k, the NicClusterPolicy
+applied states, the SR-IOV node state sync status and the allocatable device plugin resources of the worker nodes,
+and the allocation usage of the IP pools. Nothing is generated or deployed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		opts.Status = true
+		rootCmd.Run(cmd, args)
+	},
+}
+
+func init() {
+	statusCmd.Flags().StringVarP(&opts.Output, "output", "o", "table", "Output format (table, json)")
+	rootCmd.AddCommand(statusCmd)
+}
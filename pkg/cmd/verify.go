@@ -0,0 +1,39 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// verifyCmd represents the verify command
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify the deployed networks",
+	Long: `Verify the networks deployed for the selected profiles: for every network, a server and a client pod are scheduled
+on two nodes (verification.nodes in the config file, or the first two worker nodes) and run the tests declared by the profile,
+e.g. rping, ib_write_bw and ib_write_lat. Results are compared with the thresholds of the verification section of the config
+file and the test pods are removed. Accepts the same flags as l8k; combined with --deploy, the profiles are deployed first.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		opts.Verify = true
+		rootCmd.Run(cmd, args)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+}
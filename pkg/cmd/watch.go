@@ -0,0 +1,43 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// watchCmd represents the watch command
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Keep the deployment files in sync with the nodes joining the cluster",
+	Long: `Run the workflow selected with the same flags as l8k, then watch the Node and NicDevice objects. When they change,
+the cluster config is rebuilt from the current NicDevices, collecting the PF attributes of the new nodes only, and
+every worker node is checked against the selected profiles. A node that does not match a profile, e.g. without the
+RDMA capability the profile requires, is reported and nothing is regenerated. Otherwise a changed cluster config is saved
+to --save-cluster-config when discovered, the deployment files are regenerated and, with --deploy, deployed.
+The nic-configuration-operator must keep running to maintain the NicDevices.`,
+	Example: `  l8k watch --discover-cluster-config --kubeconfig ~/.kube/config --fabric ethernet --deployment-type sriov --multirail \
+    --save-deployment-files ./deployment --deploy`,
+	Run: func(cmd *cobra.Command, args []string) {
+		opts.Watch = true
+		rootCmd.Run(cmd, args)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+}
@@ -18,15 +18,33 @@ package config
 
 import (
 	"fmt"
+	"math"
 	"os"
+	"time"
 
 	"github.com/go-logr/logr"
 	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/util/wait"
+	sigsyaml "sigs.k8s.io/yaml"
+)
+
+const (
+	DefaultNicClusterPolicyReadyTimeout = 15 * time.Minute
+	DefaultClusterPolicyReadyTimeout    = 15 * time.Minute
+	DefaultNicConfigurationTimeout      = 30 * time.Minute
+	DefaultNicDevicesDiscoveredTimeout  = 5 * time.Minute
+	DefaultNodeCollectorTimeout         = 2 * time.Minute
+	DefaultVerificationTimeout          = 10 * time.Minute
+	DefaultOperatorInstallTimeout       = 10 * time.Minute
+	DefaultPollInterval                 = 3 * time.Second
+	DefaultMaxPollInterval              = 30 * time.Second
+	DefaultApplyRetries                 = 6
 )
 
 // LaunchKubernetesConfig represents the l8k-config.yaml structure
 type LaunchKubernetesConfig struct {
 	NetworkOperator *NetworkOperatorConfig `yaml:"networkOperator,omitempty"`
+	GPUOperator     *GPUOperatorConfig     `yaml:"gpuOperator,omitempty"`
 	DOCADriver      *DOCADriverConfig      `yaml:"docaDriver,omitempty"`
 	NvIpam          *NvIpamConfig          `yaml:"nvIpam,omitempty"`
 	Sriov           *SriovConfig           `yaml:"sriov,omitempty"`
@@ -36,6 +54,15 @@ type LaunchKubernetesConfig struct {
 	Macvlan         *MacvlanConfig         `yaml:"macvlan,omitempty"`
 	Profile         *Profile               `yaml:"profile,omitempty"`
 	ClusterConfig   *ClusterConfig         `yaml:"clusterConfig,omitempty"`
+	Timeouts        *TimeoutsConfig        `yaml:"timeouts,omitempty"`
+
+	NicConfiguration *NicConfigurationConfig `yaml:"nicConfiguration,omitempty"`
+	AI               *AIConfig               `yaml:"ai,omitempty"`
+
+	Verification *VerificationConfig `yaml:"verification,omitempty"`
+
+	Deployment *DeploymentConfig `yaml:"deployment,omitempty"`
+	History    *HistoryConfig    `yaml:"history,omitempty"`
 }
 
 type NetworkOperatorConfig struct {
@@ -43,22 +70,150 @@ type NetworkOperatorConfig struct {
 	ComponentVersion string `yaml:"componentVersion"`
 	Repository       string `yaml:"repository"`
 	Namespace        string `yaml:"namespace"`
+	// ExistingNicClusterPolicy selects how discovery treats a NicClusterPolicy that already exists (refuse, reuse)
+	ExistingNicClusterPolicy string `yaml:"existingNicClusterPolicy,omitempty"`
+	// NodeCollectorImage is the image of the pods reading PF attributes from sysfs during discovery, skipped if empty
+	NodeCollectorImage string `yaml:"nodeCollectorImage,omitempty"`
+	// Helm locates the network-operator chart installed with --install-operators, the NGC chart if nil
+	Helm *HelmChartConfig `yaml:"helm,omitempty"`
+}
+
+// HelmChartConfig locates the Helm chart of an operator installed or upgraded by l8k before discovery
+type HelmChartConfig struct {
+	Repository string                 `yaml:"repository,omitempty"` // URL of the chart repository, https:// or oci://
+	Chart      string                 `yaml:"chart,omitempty"`      // Chart name in the repository, or path of a local chart archive or directory
+	Release    string                 `yaml:"release,omitempty"`    // Release name, the chart name if empty
+	Values     map[string]interface{} `yaml:"values,omitempty"`     // Values overriding the ones derived from the profile
+}
+
+// ChartValues returns Values with string keys at every level, as Helm expects them
+func (h *HelmChartConfig) ChartValues() (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	if len(h.Values) == 0 {
+		return values, nil
+	}
+	data, err := yaml.Marshal(h.Values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal chart values: %w", err)
+	}
+	if err := sigsyaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal chart values: %w", err)
+	}
+	return values, nil
+}
+
+// GPUOperatorConfig holds the settings of the GPU Operator ClusterPolicy
+type GPUOperatorConfig struct {
+	Version          string `yaml:"version"`          // GPU Operator version, e.g. v25.3.4
+	Repository       string `yaml:"repository"`       // Repository of the GPU driver image
+	Namespace        string `yaml:"namespace"`        // Namespace the GPU Operator is installed in
+	DriverVersion    string `yaml:"driverVersion"`    // GPU driver version, e.g. 580.82.07
+	KernelModuleType string `yaml:"kernelModuleType"` // GPU kernel modules: open, proprietary or auto
+	RdmaMode         string `yaml:"rdmaMode"`         // GPUDirect RDMA implementation: dmabuf or peermem (nvidia-peermem)
+	GdsRepository    string `yaml:"gdsRepository"`    // Repository of the GPUDirect Storage (nvidia-fs) image
+	GdsVersion       string `yaml:"gdsVersion"`       // GPUDirect Storage driver version, e.g. 2.20.5
+}
+
+// NicConfigurationConfig holds the NIC firmware settings applied by the nic-configuration-operator.
+// When set, the NicClusterPolicy of the network-operator profiles deploys the nic-configuration-operator.
+type NicConfigurationConfig struct {
+	MaxReadRequest int    `yaml:"maxReadRequest"` // PCIe max read request size in bytes: 128, 256, 512, 1024, 2048 or 4096
+	Trust          string `yaml:"trust"`          // RoCE QoS trust mode for Spectrum-X, e.g. dscp
+	Pfc            string `yaml:"pfc"`            // RoCE priority flow control per priority for Spectrum-X, e.g. 0,0,0,1,0,0,0,0
+	ResetToDefault bool   `yaml:"resetToDefault"` // Reset the NIC firmware configuration to defaults before applying the template
+}
+
+// AIConfig holds the settings of the NCCL ConfigMap and the NCCL test job rendered by the AI profiles
+type AIConfig struct {
+	NcclConfigMap string `yaml:"ncclConfigMap"` // Name of the ConfigMap with the NCCL environment variables for the workloads
+	TestImage     string `yaml:"testImage"`     // PyTorch image of the NCCL test job, e.g. nvcr.io/nvidia/pytorch:25.08-py3
+	TestNodes     int    `yaml:"testNodes"`     // Number of GPU nodes the NCCL test job runs on
+}
+
+// VerificationConfig holds the nodes and thresholds of the verification of a deployed profile
+type VerificationConfig struct {
+	Nodes            []string `yaml:"nodes,omitempty"`  // Server and client nodes, the first two worker nodes if empty
+	MinBandwidthGbps float64  `yaml:"minBandwidthGbps"` // Minimum ib_write_bw average bandwidth in Gb/s, not checked if 0
+	MaxLatencyUsec   float64  `yaml:"maxLatencyUsec"`   // Maximum ib_write_lat average latency in microseconds, not checked if 0
 }
 
+// DeploymentConfig holds the settings of the deployment of the profiles
+type DeploymentConfig struct {
+	ForceConflicts bool `yaml:"forceConflicts"` // Take over the fields owned by other field managers instead of failing
+}
+
+// ForceConflicts returns whether the deployment takes over the fields owned by other field managers
+func (c *LaunchKubernetesConfig) ForceConflicts() bool {
+	return c.Deployment != nil && c.Deployment.ForceConflicts
+}
+
+// HistoryConfig holds the settings of the history of the deployments, see l8k rollback
+type HistoryConfig struct {
+	MaxRevisions int    `yaml:"maxRevisions"`      // Revisions kept, DefaultMaxRevisions if 0
+	Storage      string `yaml:"storage,omitempty"` // Also keep the revisions in the cluster, in a configmap or a secret of the network operator namespace
+}
+
+// DefaultMaxRevisions is the default number of revisions kept in the history
+const DefaultMaxRevisions = 10
+
+// Supported storages of the history in the cluster
+const (
+	HistoryStorageConfigMap = "configmap"
+	HistoryStorageSecret    = "secret"
+)
+
 type DOCADriverConfig struct {
 	Version              string `yaml:"version"`
 	UnloadStorageModules bool   `yaml:"unloadStorageModules"`
 	EnableNFSRDMA        bool   `yaml:"enableNFSRDMA"`
+	// SupportedKernels are the kernel versions the driver version supports, as major.minor prefixes, e.g. 5.15,
+	// checked before the deployment against the kernels of the nodes. Not checked if empty.
+	SupportedKernels []string `yaml:"supportedKernels,omitempty"`
+	// SupportedGPUDrivers are the GPU driver branches the driver version is validated with for GPUDirect, e.g. 580,
+	// checked against gpuOperator.driverVersion when rendering the GPUDirect profiles. Not checked if empty.
+	SupportedGPUDrivers []string `yaml:"supportedGPUDrivers,omitempty"`
 }
 
 type NvIpamConfig struct {
 	PoolName string               `yaml:"poolName"`
 	Subnets  []NvIpamSubnetConfig `yaml:"subnets"`
+
+	PoolType string `yaml:"poolType,omitempty"` // Kind of the nv-ipam pools: ippool (default) or cidrpool
+	Supernet string `yaml:"supernet,omitempty"` // Range the subnets missing from subnets are carved from, one per PF
 }
 
+// Supported nv-ipam pool types
+const (
+	PoolTypeIPPool   = "ippool"
+	PoolTypeCIDRPool = "cidrpool"
+)
+
+// NvIpamSubnetConfig describes the subnet of an nv-ipam pool. Multirail profiles create a pool per rail,
+// using the subnet with the index of the rail (see ClusterConfig.RailIndex), other profiles use the first subnet.
 type NvIpamSubnetConfig struct {
 	Subnet  string `yaml:"subnet"`
 	Gateway string `yaml:"gateway"`
+
+	// Per-node block of the pool, computed from the subnet size and the number of worker nodes if unset
+	PerNodeBlockSize     int  `yaml:"perNodeBlockSize,omitempty"`     // IPPool: number of addresses per node
+	PerNodeNetworkPrefix int  `yaml:"perNodeNetworkPrefix,omitempty"` // CIDRPool: prefix length of the per-node subnets
+	GatewayIndex         *int `yaml:"gatewayIndex,omitempty"`         // CIDRPool: index of the gateway in every per-node subnet
+
+	Exclusions        []NvIpamExclusionConfig        `yaml:"exclusions,omitempty"`        // Address ranges never allocated
+	StaticAllocations []NvIpamStaticAllocationConfig `yaml:"staticAllocations,omitempty"` // CIDRPool: per-node subnets pinned to nodes
+}
+
+// NvIpamExclusionConfig is an inclusive range of addresses excluded from allocation
+type NvIpamExclusionConfig struct {
+	StartIP string `yaml:"startIP"`
+	EndIP   string `yaml:"endIP"`
+}
+
+// NvIpamStaticAllocationConfig pins a per-node subnet of a CIDRPool to a node, or reserves it if nodeName is empty
+type NvIpamStaticAllocationConfig struct {
+	NodeName string `yaml:"nodeName,omitempty"`
+	Prefix   string `yaml:"prefix"`
+	Gateway  string `yaml:"gateway,omitempty"`
 }
 
 type SriovConfig struct {
@@ -93,13 +248,148 @@ type Profile struct {
 	Multirail  bool   `yaml:"multirail"`
 	SpectrumX  bool   `yaml:"spectrumX"`
 	Ai         bool   `yaml:"ai"`
+	GPUDirect  string `yaml:"gpuDirect,omitempty"` // none, rdma, storage; selects the GPU Operator profile
+}
+
+// TimeoutsConfig holds the timeouts and polling intervals used while waiting for cluster resources.
+// Zero values are replaced with defaults by SetDefaults.
+type TimeoutsConfig struct {
+	Discovery             time.Duration `yaml:"discovery,omitempty"`             // Timeout for the whole discovery phase, unlimited if zero
+	Deployment            time.Duration `yaml:"deployment,omitempty"`            // Timeout for the whole deployment phase, unlimited if zero
+	NicClusterPolicyReady time.Duration `yaml:"nicClusterPolicyReady,omitempty"` // Timeout for the NicClusterPolicy to become ready
+	ClusterPolicyReady    time.Duration `yaml:"clusterPolicyReady,omitempty"`    // Timeout for the GPU Operator ClusterPolicy to become ready
+	NicConfiguration      time.Duration `yaml:"nicConfiguration,omitempty"`      // Timeout for the NIC firmware configuration to be applied, including node reboots
+	NicDevicesDiscovered  time.Duration `yaml:"nicDevicesDiscovered,omitempty"`  // Timeout for NicDevice objects to appear
+	NodeCollector         time.Duration `yaml:"nodeCollector,omitempty"`         // Timeout for the node-local collector pods to complete
+	Verification          time.Duration `yaml:"verification,omitempty"`          // Timeout for the verification pods of a network to complete
+	OperatorInstall       time.Duration `yaml:"operatorInstall,omitempty"`       // Timeout for an operator installed with Helm to become ready
+	PollInterval          time.Duration `yaml:"pollInterval,omitempty"`          // Initial interval between polls and retries
+	MaxPollInterval       time.Duration `yaml:"maxPollInterval,omitempty"`       // Upper bound for the exponentially growing poll interval
+	ApplyRetries          int           `yaml:"applyRetries,omitempty"`          // Number of attempts when applying an object fails
+}
+
+// SetDefaults fills unset timeouts and intervals with their default values
+func (t *TimeoutsConfig) SetDefaults() {
+	if t.NicClusterPolicyReady == 0 {
+		t.NicClusterPolicyReady = DefaultNicClusterPolicyReadyTimeout
+	}
+	if t.ClusterPolicyReady == 0 {
+		t.ClusterPolicyReady = DefaultClusterPolicyReadyTimeout
+	}
+	if t.NicConfiguration == 0 {
+		t.NicConfiguration = DefaultNicConfigurationTimeout
+	}
+	if t.NicDevicesDiscovered == 0 {
+		t.NicDevicesDiscovered = DefaultNicDevicesDiscoveredTimeout
+	}
+	if t.NodeCollector == 0 {
+		t.NodeCollector = DefaultNodeCollectorTimeout
+	}
+	if t.Verification == 0 {
+		t.Verification = DefaultVerificationTimeout
+	}
+	if t.OperatorInstall == 0 {
+		t.OperatorInstall = DefaultOperatorInstallTimeout
+	}
+	if t.PollInterval == 0 {
+		t.PollInterval = DefaultPollInterval
+	}
+	if t.MaxPollInterval == 0 {
+		t.MaxPollInterval = DefaultMaxPollInterval
+	}
+	if t.MaxPollInterval < t.PollInterval {
+		t.MaxPollInterval = t.PollInterval
+	}
+	if t.ApplyRetries == 0 {
+		t.ApplyRetries = DefaultApplyRetries
+	}
+}
+
+// PollBackoff returns an unbounded exponential backoff for status polling and retries,
+// starting at PollInterval and capped at MaxPollInterval.
+func (t *TimeoutsConfig) PollBackoff() wait.Backoff {
+	return wait.Backoff{
+		Duration: t.PollInterval,
+		Factor:   2,
+		Jitter:   0.1,
+		Steps:    math.MaxInt32,
+		Cap:      t.MaxPollInterval,
+	}
 }
 
 type ClusterConfig struct {
 	Capabilities *ClusterCapabilities `yaml:"capabilities"`
 	PFs          []PFConfig           `yaml:"pfs"`
+	GPUs         []GPUConfig          `yaml:"gpus,omitempty"`
+	Rails        []RailConfig         `yaml:"rails,omitempty"`
+	GPUNodes     []string             `yaml:"gpuNodes,omitempty"` // nodes labeled with NVIDIA GPUs
 	WorkerNodes  []string             `yaml:"workerNodes"`
 	NodeSelector map[string]string    `yaml:"nodeSelector,omitempty"`
+
+	// Address ranges of the cluster network, nv-ipam subnets must not overlap them. Not validated if empty.
+	NodeCIDRs    []string `yaml:"nodeCIDRs,omitempty"`    // node addresses, as host prefixes
+	PodCIDRs     []string `yaml:"podCIDRs,omitempty"`     // cluster pod CIDRs, or the pod CIDRs of the nodes
+	ServiceCIDRs []string `yaml:"serviceCIDRs,omitempty"` // service cluster IP ranges
+}
+
+// RailName returns the name of the rail served by the PF with the given PCI address.
+// Without a discovered rail map, a letter derived from the PF index is returned (a, b, c, ...).
+func (c *ClusterConfig) RailName(pciAddress string, index int) string {
+	for _, rail := range c.Rails {
+		if rail.PciAddress == pciAddress {
+			return rail.Name
+		}
+	}
+	return string(rune('a' + index))
+}
+
+// Rail is an east-west PF with the name and index of the rail it serves
+type Rail struct {
+	Index int // position of the rail, selects its nvIpam subnet
+	Name  string
+	PF    PFConfig
+}
+
+// EastWestRails returns the rails of the east-west PFs, in rail order: the order of the discovered rail map, where
+// rail N serves GPU N, followed by the PFs missing from it in PF order. Without a rail map, this is the PF order.
+func (c *ClusterConfig) EastWestRails() []Rail {
+	rails := []Rail{}
+	added := map[string]bool{}
+	for _, rail := range c.Rails {
+		for _, pf := range c.PFs {
+			if pf.PciAddress == rail.PciAddress && pf.Traffic == "east-west" && !added[pf.PciAddress] {
+				rails = append(rails, Rail{Index: len(rails), Name: rail.Name, PF: pf})
+				added[pf.PciAddress] = true
+			}
+		}
+	}
+	for i, pf := range c.PFs {
+		if pf.Traffic == "east-west" && !added[pf.PciAddress] {
+			rails = append(rails, Rail{Index: len(rails), Name: c.RailName(pf.PciAddress, i), PF: pf})
+			added[pf.PciAddress] = true
+		}
+	}
+	return rails
+}
+
+// RailIndex returns the index of the rail served by the east-west PF with the given PCI address, -1 for other PFs.
+// Rail names and nvIpam subnets are both selected in this order.
+func (c *ClusterConfig) RailIndex(pciAddress string) int {
+	for _, rail := range c.EastWestRails() {
+		if rail.PF.PciAddress == pciAddress {
+			return rail.Index
+		}
+	}
+	return -1
+}
+
+// EastWestRailNames returns the rail names of the east-west PFs, in rail order
+func (c *ClusterConfig) EastWestRailNames() []string {
+	names := []string{}
+	for _, rail := range c.EastWestRails() {
+		names = append(names, rail.Name)
+	}
+	return names
 }
 
 type ClusterCapabilities struct {
@@ -107,9 +397,11 @@ type ClusterCapabilities struct {
 }
 
 type NodesCapabilities struct {
-	Sriov bool `yaml:"sriov"`
-	Rdma  bool `yaml:"rdma"`
-	Ib    bool `yaml:"ib"`
+	Sriov       bool `yaml:"sriov"`
+	Rdma        bool `yaml:"rdma"`
+	Ib          bool `yaml:"ib"`
+	Gpu         bool `yaml:"gpu"`                   // has nodes with NVIDIA GPUs
+	GpusPerNode int  `yaml:"gpusPerNode,omitempty"` // smallest number of GPUs on a GPU node
 }
 
 type PFConfig struct {
@@ -117,6 +409,36 @@ type PFConfig struct {
 	PciAddress       string `yaml:"pciAddress"`
 	NetworkInterface string `yaml:"networkInterface"`
 	Traffic          string `yaml:"traffic"`
+
+	// Hardware attributes, discovered from NicDevice status and sysfs. With multiple nodes,
+	// the most restrictive value is kept. Unset values are unknown and not validated.
+	DeviceID        string `yaml:"deviceID,omitempty"`        // PCI device ID, e.g. 101d
+	DeviceType      string `yaml:"deviceType,omitempty"`      // e.g. ConnectX7
+	PartNumber      string `yaml:"partNumber,omitempty"`      // e.g. MCX713106AEHEA_QP1
+	PSID            string `yaml:"psid,omitempty"`            // e.g. MT_0000000221
+	FirmwareVersion string `yaml:"firmwareVersion,omitempty"` // e.g. 28.39.1002
+	SriovEnabled    *bool  `yaml:"sriovEnabled,omitempty"`    // Whether SR-IOV is enabled in firmware
+	TotalVfs        int    `yaml:"totalVfs,omitempty"`        // Maximum number of VFs supported by the PF
+	NumaNode        *int   `yaml:"numaNode,omitempty"`        // NUMA node the PF is attached to
+	PciLinkSpeed    string `yaml:"pciLinkSpeed,omitempty"`    // Current PCIe link speed, e.g. 16.0 GT/s PCIe
+	PciLinkWidth    int    `yaml:"pciLinkWidth,omitempty"`    // Current PCIe link width in lanes
+	LinkSpeed       int    `yaml:"linkSpeed,omitempty"`       // Current port speed in Mb/s
+	Mtu             int    `yaml:"mtu,omitempty"`             // Current MTU of the network interface
+}
+
+// GPUConfig describes a GPU of the worker nodes
+type GPUConfig struct {
+	PciAddress string `yaml:"pciAddress"`
+	NumaNode   *int   `yaml:"numaNode,omitempty"`
+}
+
+// RailConfig pairs an east-west PF with the GPUs closest to it in the PCIe topology, for GPUDirect RDMA
+type RailConfig struct {
+	Name             string   `yaml:"name"`               // e.g. rail0, used as suffix of the multirail resource and network names
+	PciAddress       string   `yaml:"pciAddress"`         // PCI address of the PF serving the rail
+	NetworkInterface string   `yaml:"networkInterface"`   // network interface of the PF serving the rail
+	GPUs             []string `yaml:"gpus,omitempty"`     // PCI addresses of the GPUs using the rail
+	Distance         string   `yaml:"distance,omitempty"` // PCIe distance between the GPUs and the PF: PIX, PXB, PHB, NODE or SYS
 }
 
 // LoadFullConfig loads and parses the cluster configuration from the specified path
@@ -144,9 +466,13 @@ func LoadFullConfig(configPath string, logger logr.Logger) (*LaunchKubernetesCon
 		return nil, fmt.Errorf("failed to parse cluster config YAML %s: %w", configPath, err)
 	}
 
-	logger.Info("Cluster configuration loaded successfully",
-		"networkOperatorVersion", config.NetworkOperator.Version,
-		"namespace", config.NetworkOperator.Namespace)
+	if config.NetworkOperator != nil {
+		logger.Info("Cluster configuration loaded successfully",
+			"networkOperatorVersion", config.NetworkOperator.Version,
+			"namespace", config.NetworkOperator.Namespace)
+	} else {
+		logger.Info("Cluster configuration loaded successfully")
+	}
 
 	return &config, nil
 }
@@ -0,0 +1,167 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEastWestRailsFollowRailMapOrder(t *testing.T) {
+	cluster := &ClusterConfig{
+		PFs: []PFConfig{
+			{PciAddress: "0000:08:00.0", Traffic: "east-west"},
+			{PciAddress: "0000:0c:00.0", Traffic: "north-south"},
+			{PciAddress: "0000:3b:00.0", Traffic: "east-west"},
+			{PciAddress: "0000:5e:00.0", Traffic: "east-west"},
+		},
+		// GPU order differs from the PF order
+		Rails: []RailConfig{
+			{Name: "rail0", PciAddress: "0000:3b:00.0"},
+			{Name: "rail1", PciAddress: "0000:08:00.0"},
+		},
+	}
+
+	if got, want := cluster.EastWestRailNames(), []string{"rail0", "rail1", "d"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("EastWestRailNames() = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		pci  string
+		want int
+	}{
+		{pci: "0000:3b:00.0", want: 0},
+		{pci: "0000:08:00.0", want: 1},
+		{pci: "0000:5e:00.0", want: 2},
+		{pci: "0000:0c:00.0", want: -1},
+		{pci: "0000:ff:00.0", want: -1},
+	}
+	for _, tt := range tests {
+		if got := cluster.RailIndex(tt.pci); got != tt.want {
+			t.Errorf("RailIndex(%q) = %d, want %d", tt.pci, got, tt.want)
+		}
+	}
+}
+
+func TestEastWestRailsWithoutRailMap(t *testing.T) {
+	cluster := &ClusterConfig{
+		PFs: []PFConfig{
+			{PciAddress: "0000:08:00.0", Traffic: "north-south"},
+			{PciAddress: "0000:3b:00.0", Traffic: "east-west"},
+			{PciAddress: "0000:5e:00.0", Traffic: "east-west"},
+		},
+	}
+
+	rails := cluster.EastWestRails()
+	if len(rails) != 2 || rails[0].Name != "b" || rails[0].Index != 0 || rails[1].Name != "c" || rails[1].Index != 1 {
+		t.Errorf("unexpected rails: %+v", rails)
+	}
+}
+
+func TestTimeoutsConfigSetDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeouts TimeoutsConfig
+		want     TimeoutsConfig
+	}{
+		{
+			name: "all unset",
+			want: TimeoutsConfig{
+				NicClusterPolicyReady: DefaultNicClusterPolicyReadyTimeout,
+				ClusterPolicyReady:    DefaultClusterPolicyReadyTimeout,
+				NicConfiguration:      DefaultNicConfigurationTimeout,
+				NicDevicesDiscovered:  DefaultNicDevicesDiscoveredTimeout,
+				NodeCollector:         DefaultNodeCollectorTimeout,
+				Verification:          DefaultVerificationTimeout,
+				OperatorInstall:       DefaultOperatorInstallTimeout,
+				PollInterval:          DefaultPollInterval,
+				MaxPollInterval:       DefaultMaxPollInterval,
+				ApplyRetries:          DefaultApplyRetries,
+			},
+		},
+		{
+			name: "set values are kept and the phases stay unlimited",
+			timeouts: TimeoutsConfig{
+				NicClusterPolicyReady: time.Minute,
+				NodeCollector:         10 * time.Second,
+				PollInterval:          time.Second,
+				MaxPollInterval:       5 * time.Second,
+				ApplyRetries:          2,
+			},
+			want: TimeoutsConfig{
+				NicClusterPolicyReady: time.Minute,
+				ClusterPolicyReady:    DefaultClusterPolicyReadyTimeout,
+				NicConfiguration:      DefaultNicConfigurationTimeout,
+				NicDevicesDiscovered:  DefaultNicDevicesDiscoveredTimeout,
+				NodeCollector:         10 * time.Second,
+				Verification:          DefaultVerificationTimeout,
+				OperatorInstall:       DefaultOperatorInstallTimeout,
+				PollInterval:          time.Second,
+				MaxPollInterval:       5 * time.Second,
+				ApplyRetries:          2,
+			},
+		},
+		{
+			name:     "max poll interval below the poll interval",
+			timeouts: TimeoutsConfig{PollInterval: time.Minute},
+			want: TimeoutsConfig{
+				NicClusterPolicyReady: DefaultNicClusterPolicyReadyTimeout,
+				ClusterPolicyReady:    DefaultClusterPolicyReadyTimeout,
+				NicConfiguration:      DefaultNicConfigurationTimeout,
+				NicDevicesDiscovered:  DefaultNicDevicesDiscoveredTimeout,
+				NodeCollector:         DefaultNodeCollectorTimeout,
+				Verification:          DefaultVerificationTimeout,
+				OperatorInstall:       DefaultOperatorInstallTimeout,
+				PollInterval:          time.Minute,
+				MaxPollInterval:       time.Minute,
+				ApplyRetries:          DefaultApplyRetries,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.timeouts
+			got.SetDefaults()
+			if got != tt.want {
+				t.Errorf("SetDefaults() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPollBackoffGrowsUpToTheCap(t *testing.T) {
+	timeouts := &TimeoutsConfig{PollInterval: time.Second, MaxPollInterval: 5 * time.Second}
+	timeouts.SetDefaults()
+
+	backoff := timeouts.PollBackoff()
+	// The jitter adds up to 10% on top of the exponential interval, the cap applies before it
+	for i, base := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second, 5 * time.Second} {
+		got := backoff.Step()
+		if got < base || got > base+base/10 {
+			t.Errorf("step %d: got %s, want between %s and %s", i, got, base, base+base/10)
+		}
+	}
+	// Polling never stops on its own, only the timeout of the wait ends it
+	for range 100 {
+		backoff.Step()
+	}
+	if got := backoff.Step(); got < 5*time.Second {
+		t.Errorf("backoff after many steps: got %s, want the 5s cap", got)
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package controller contains the controller of the l8k in-cluster mode, reconciling LaunchKitConfig objects
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/api/v1alpha1"
+	"github.com/nvidia/k8s-launch-kit/pkg/app"
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/networkoperatorplugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+)
+
+const (
+	// DefaultResyncPeriod is the interval between reconciliations of a LaunchKitConfig without resyncPeriod
+	DefaultResyncPeriod = 10 * time.Minute
+	// Finalizer holds a deleted LaunchKitConfig until its state directory is removed
+	Finalizer = "l8k.nvidia.com/cleanup"
+)
+
+// phaseConditions maps the workflow phases to the condition types reporting them
+var phaseConditions = map[string]string{
+	app.PhaseDiscovery:        v1alpha1.ConditionDiscovered,
+	app.PhaseProfileSelection: v1alpha1.ConditionProfilesSelected,
+	app.PhaseGeneration:       v1alpha1.ConditionRendered,
+	app.PhaseDeployment:       v1alpha1.ConditionDeployed,
+	app.PhaseVerification:     v1alpha1.ConditionVerified,
+}
+
+// LaunchKitConfigReconciler reconciles LaunchKitConfig objects: every reconciliation rediscovers the cluster,
+// renders the selected profiles and applies them, and reports the outcome of every phase as a status condition.
+// Resyncs of an unchanged spec reuse the previous discovery while the watched objects are unchanged, and deploy
+// only changed deployment files. The reconciliations are serialized, a single LaunchKitConfig is expected per cluster.
+type LaunchKitConfigReconciler struct {
+	client.Client
+
+	// KubeClient is the uncached client the workflow discovers and deploys with
+	KubeClient client.Client
+	// Options are the base options of the workflow, e.g. the plugins directory. SaveDeploymentFiles is the state
+	// directory of the controller, the files and history of every LaunchKitConfig are kept in a subdirectory.
+	Options options.Options
+	// DefaultsPath is the path of the l8k-config.yaml the config of the spec is merged over
+	DefaultsPath string
+
+	// discoveries are the latest discoveries by LaunchKitConfig name, with the generation of the spec they were made for
+	discoveries map[string]generationDiscovery
+}
+
+// generationDiscovery is a discovery made for a generation of a LaunchKitConfig spec
+type generationDiscovery struct {
+	generation int64
+	discovery  *app.Discovery
+}
+
+// Reconcile runs the workflow for a LaunchKitConfig and requeues it after its resync period
+func (r *LaunchKitConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	lkc := &v1alpha1.LaunchKitConfig{}
+	if err := r.Get(ctx, req.NamespacedName, lkc); err != nil {
+		if apierrors.IsNotFound(err) {
+			delete(r.discoveries, req.Name)
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !lkc.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.cleanup(ctx, lkc)
+	}
+	if controllerutil.AddFinalizer(lkc, Finalizer) {
+		if err := r.Update(ctx, lkc); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to add the finalizer to LaunchKitConfig %s: %w", lkc.Name, err)
+		}
+	}
+
+	resync := DefaultResyncPeriod
+	if lkc.Spec.ResyncPeriod != nil && lkc.Spec.ResyncPeriod.Duration > 0 {
+		resync = lkc.Spec.ResyncPeriod.Duration
+	}
+
+	cfg, err := r.loadConfig(lkc)
+	if err != nil {
+		// Retried once the spec is fixed
+		logger.Error(err, "Invalid LaunchKitConfig")
+		return ctrl.Result{}, r.updateStatus(ctx, lkc, func(status *v1alpha1.LaunchKitConfigStatus) {
+			status.ObservedGeneration = lkc.Generation
+			meta.SetStatusCondition(&status.Conditions, newCondition(v1alpha1.ConditionReady, lkc.Generation, "InvalidConfig", err))
+		})
+	}
+
+	report := func(phase string, err error) {
+		if err != nil {
+			logger.Error(err, "Phase failed", "phase", phase)
+		} else {
+			logger.Info("Phase succeeded", "phase", phase)
+		}
+		if err := r.updateStatus(ctx, lkc, func(status *v1alpha1.LaunchKitConfigStatus) {
+			meta.SetStatusCondition(&status.Conditions, newCondition(phaseConditions[phase], lkc.Generation, "Failed", err))
+		}); err != nil {
+			logger.Error(err, "Failed to update the status", "phase", phase)
+		}
+	}
+
+	// A spec change may change the discovery, e.g. the NIC node selector
+	var previous *app.Discovery
+	if last, ok := r.discoveries[lkc.Name]; ok && last.generation == lkc.Generation {
+		previous = last.discovery
+	}
+
+	logger.Info("Reconciling", "profile", lkc.Spec.Profile, "plugins", r.options(lkc).EnabledPlugins)
+	foundProfiles, discovery, err := app.New(r.options(lkc)).Reconcile(ctx, r.KubeClient, cfg, previous, report)
+	if discovery != nil {
+		if r.discoveries == nil {
+			r.discoveries = map[string]generationDiscovery{}
+		}
+		r.discoveries[lkc.Name] = generationDiscovery{generation: lkc.Generation, discovery: discovery}
+	}
+	if err != nil {
+		logger.Error(err, "Reconciliation failed, retrying after the resync period", "resyncPeriod", resync)
+	}
+
+	now := metav1.Now()
+	return ctrl.Result{RequeueAfter: resync}, r.updateStatus(ctx, lkc, func(status *v1alpha1.LaunchKitConfigStatus) {
+		status.ObservedGeneration = lkc.Generation
+		status.LastReconcileTime = &now
+		if err == nil {
+			status.Profiles = []string{}
+			for _, profile := range foundProfiles {
+				status.Profiles = append(status.Profiles, profile.Name)
+			}
+		}
+		if !lkc.Spec.Verify {
+			meta.RemoveStatusCondition(&status.Conditions, v1alpha1.ConditionVerified)
+		}
+		meta.SetStatusCondition(&status.Conditions, newCondition(v1alpha1.ConditionReady, lkc.Generation, "Failed", err))
+	})
+}
+
+// SetupWithManager registers the reconciler, reconciling on spec changes and periodically
+func (r *LaunchKitConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.LaunchKitConfig{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// cleanup forgets the discovery of a deleted LaunchKitConfig, removes its state directory and releases it.
+// The deployed objects, and the history stored in the cluster, are left in place.
+func (r *LaunchKitConfigReconciler) cleanup(ctx context.Context, lkc *v1alpha1.LaunchKitConfig) error {
+	delete(r.discoveries, lkc.Name)
+	if !controllerutil.ContainsFinalizer(lkc, Finalizer) {
+		return nil
+	}
+	if r.Options.SaveDeploymentFiles != "" {
+		dir := r.options(lkc).SaveDeploymentFiles
+		if err := os.RemoveAll(dir); err != nil {
+			return fmt.Errorf("failed to remove the state directory %s of LaunchKitConfig %s: %w", dir, lkc.Name, err)
+		}
+	}
+	log.FromContext(ctx).Info("Removed the state of the deleted LaunchKitConfig, the deployed objects are left in place")
+	controllerutil.RemoveFinalizer(lkc, Finalizer)
+	if err := r.Update(ctx, lkc); err != nil {
+		return fmt.Errorf("failed to remove the finalizer of LaunchKitConfig %s: %w", lkc.Name, err)
+	}
+	return nil
+}
+
+// loadConfig returns the defaults with the config of the spec merged over them.
+// Unknown fields of the spec config, e.g. misspelled keys, are rejected.
+func (r *LaunchKitConfigReconciler) loadConfig(lkc *v1alpha1.LaunchKitConfig) (*config.LaunchKubernetesConfig, error) {
+	cfg, err := config.LoadFullConfig(r.DefaultsPath, log.Log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the default config: %w", err)
+	}
+	// JSON is YAML, the fields of the spec config have the names of l8k-config.yaml
+	if len(lkc.Spec.Config.Raw) > 0 {
+		if err := yaml.UnmarshalStrict(lkc.Spec.Config.Raw, cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse spec.config: %w", err)
+		}
+	}
+	if cfg.NetworkOperator == nil {
+		return nil, fmt.Errorf("spec.config.networkOperator is not set and has no default")
+	}
+	return cfg, nil
+}
+
+// options returns the workflow options of the LaunchKitConfig
+func (r *LaunchKitConfigReconciler) options(lkc *v1alpha1.LaunchKitConfig) options.Options {
+	opts := r.Options
+	opts.EnabledPlugins = lkc.Spec.Plugins
+	if len(opts.EnabledPlugins) == 0 {
+		opts.EnabledPlugins = []string{"network-operator"}
+	}
+	opts.Fabric = lkc.Spec.Profile.Fabric
+	opts.DeploymentType = lkc.Spec.Profile.DeploymentType
+	opts.Multirail = lkc.Spec.Profile.Multirail
+	opts.SpectrumX = lkc.Spec.Profile.SpectrumX
+	opts.Ai = lkc.Spec.Profile.Ai
+	opts.GPUDirect = lkc.Spec.Profile.GPUDirect
+	opts.SaveDeploymentFiles = filepath.Join(r.Options.SaveDeploymentFiles, lkc.Name)
+	opts.Deploy = true
+	opts.Verify = lkc.Spec.Verify
+	// The NicClusterPolicy deployed by the previous reconciliation exists during the next discoveries
+	opts.ExistingNicClusterPolicy = networkoperatorplugin.ExistingPolicyReuse
+	return opts
+}
+
+// updateStatus patches the status of the LaunchKitConfig with the changes of update
+func (r *LaunchKitConfigReconciler) updateStatus(ctx context.Context, lkc *v1alpha1.LaunchKitConfig, update func(status *v1alpha1.LaunchKitConfigStatus)) error {
+	base := lkc.DeepCopy()
+	update(&lkc.Status)
+	if err := r.Status().Patch(ctx, lkc, client.MergeFrom(base)); err != nil {
+		return fmt.Errorf("failed to update the status of LaunchKitConfig %s: %w", lkc.Name, err)
+	}
+	return nil
+}
+
+// newCondition returns a true condition if err is nil, else a false condition with the reason and the error
+func newCondition(conditionType string, generation int64, reason string, err error) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             "Succeeded",
+	}
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reason
+		condition.Message = err.Error()
+	}
+	return condition
+}
@@ -0,0 +1,227 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/api/v1alpha1"
+	"github.com/nvidia/k8s-launch-kit/pkg/app"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+	sigsyaml "sigs.k8s.io/yaml"
+)
+
+func newReconciler(t *testing.T, objects ...client.Object) *LaunchKitConfigReconciler {
+	t.Helper()
+	scheme := kubeclient.NewScheme()
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).WithStatusSubresource(&v1alpha1.LaunchKitConfig{}).Build()
+	return &LaunchKitConfigReconciler{
+		Client:       c,
+		KubeClient:   c,
+		Options:      options.Options{SaveDeploymentFiles: t.TempDir(), SkipPreflight: true},
+		DefaultsPath: "../../l8k-config.yaml",
+	}
+}
+
+func newLaunchKitConfig(config string) *v1alpha1.LaunchKitConfig {
+	lkc := &v1alpha1.LaunchKitConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster", Generation: 1},
+		Spec: v1alpha1.LaunchKitConfigSpec{
+			Profile:      v1alpha1.ProfileSelection{Fabric: "ethernet", DeploymentType: "sriov"},
+			ResyncPeriod: &metav1.Duration{Duration: time.Minute},
+		},
+	}
+	if config != "" {
+		lkc.Spec.Config = runtime.RawExtension{Raw: []byte(config)}
+	}
+	return lkc
+}
+
+func reconcile(t *testing.T, r *LaunchKitConfigReconciler) (ctrl.Result, *v1alpha1.LaunchKitConfig, error) {
+	t.Helper()
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "cluster"}})
+	lkc := &v1alpha1.LaunchKitConfig{}
+	if getErr := r.Get(context.Background(), types.NamespacedName{Name: "cluster"}, lkc); getErr != nil {
+		lkc = nil
+	}
+	return result, lkc, err
+}
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantMTU int
+		wantErr string
+	}{
+		{name: "defaults", config: ""},
+		{name: "merged over the defaults", config: `{"sriov": {"mtu": 9000}}`, wantMTU: 9000},
+		{name: "unknown field", config: `{"sriov": {"mtus": 9000}}`, wantErr: "field mtus not found"},
+		{name: "invalid type", config: `{"sriov": {"mtu": "jumbo"}}`, wantErr: "failed to parse spec.config"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newReconciler(t)
+			cfg, err := r.loadConfig(newLaunchKitConfig(tt.config))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("loadConfig() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.NetworkOperator == nil {
+				t.Errorf("the defaults must be loaded")
+			}
+			if tt.wantMTU != 0 && cfg.Sriov.Mtu != tt.wantMTU {
+				t.Errorf("MTU = %d, want %d", cfg.Sriov.Mtu, tt.wantMTU)
+			}
+		})
+	}
+}
+
+func TestLoadConfigOfTheSample(t *testing.T) {
+	data, err := os.ReadFile("../../deploy/controller/launchkitconfig.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lkc := &v1alpha1.LaunchKitConfig{}
+	if err := sigsyaml.UnmarshalStrict(data, lkc); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := newReconciler(t).loadConfig(lkc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Sriov.NumVfs != 8 || cfg.History.Storage != "configmap" {
+		t.Errorf("the config of the sample must be merged: sriov %+v, history %+v", cfg.Sriov, cfg.History)
+	}
+}
+
+func TestReconcileInvalidConfig(t *testing.T) {
+	r := newReconciler(t, newLaunchKitConfig(`{"sriov": {"mtus": 9000}}`))
+
+	result, lkc, err := reconcile(t, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RequeueAfter != 0 {
+		t.Errorf("an invalid config must only be retried once the spec is fixed, requeued after %s", result.RequeueAfter)
+	}
+	ready := meta.FindStatusCondition(lkc.Status.Conditions, v1alpha1.ConditionReady)
+	if ready == nil || ready.Status != metav1.ConditionFalse || ready.Reason != "InvalidConfig" {
+		t.Errorf("Ready = %+v, want InvalidConfig", ready)
+	}
+	if lkc.Status.ObservedGeneration != 1 {
+		t.Errorf("ObservedGeneration = %d, want 1", lkc.Status.ObservedGeneration)
+	}
+	if !controllerutil.ContainsFinalizer(lkc, Finalizer) {
+		t.Errorf("the finalizer must be added")
+	}
+}
+
+func TestReconcileFailure(t *testing.T) {
+	lkc := newLaunchKitConfig("")
+	lkc.Spec.Plugins = []string{"missing"}
+	r := newReconciler(t, lkc)
+
+	result, lkc, err := reconcile(t, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RequeueAfter != time.Minute {
+		t.Errorf("a failed reconciliation must be retried after the resync period, requeued after %s", result.RequeueAfter)
+	}
+	ready := meta.FindStatusCondition(lkc.Status.Conditions, v1alpha1.ConditionReady)
+	if ready == nil || ready.Status != metav1.ConditionFalse || !strings.Contains(ready.Message, "unknown plugin: missing") {
+		t.Errorf("Ready = %+v, want the failure", ready)
+	}
+	if lkc.Status.LastReconcileTime == nil {
+		t.Errorf("LastReconcileTime must be set")
+	}
+}
+
+func TestReconcileDeletion(t *testing.T) {
+	lkc := newLaunchKitConfig("")
+	lkc.Finalizers = []string{Finalizer}
+	r := newReconciler(t, lkc)
+	stateDir := filepath.Join(r.Options.SaveDeploymentFiles, "cluster")
+	if err := os.MkdirAll(filepath.Join(stateDir, "network-operator"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	other := filepath.Join(r.Options.SaveDeploymentFiles, "other")
+	if err := os.MkdirAll(other, 0755); err != nil {
+		t.Fatal(err)
+	}
+	r.discoveries = map[string]generationDiscovery{
+		"cluster": {generation: 1, discovery: &app.Discovery{}},
+		"other":   {generation: 1, discovery: &app.Discovery{}},
+	}
+
+	if err := r.Delete(context.Background(), lkc); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := reconcile(t, r); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.Get(context.Background(), types.NamespacedName{Name: "cluster"}, &v1alpha1.LaunchKitConfig{}); !apierrors.IsNotFound(err) {
+		t.Errorf("the finalizer must be removed: %v", err)
+	}
+	if _, err := os.Stat(stateDir); !os.IsNotExist(err) {
+		t.Errorf("the state directory must be removed: %v", err)
+	}
+	if _, err := os.Stat(other); err != nil {
+		t.Errorf("the state of other LaunchKitConfigs must be kept: %v", err)
+	}
+	if _, ok := r.discoveries["cluster"]; ok {
+		t.Errorf("the discovery must be forgotten")
+	}
+	if _, ok := r.discoveries["other"]; !ok {
+		t.Errorf("the discoveries of other LaunchKitConfigs must be kept")
+	}
+
+	// Deleted without the reconciler seeing the deletion timestamp
+	r.discoveries["other"] = generationDiscovery{}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "other"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := r.discoveries["other"]; ok {
+		t.Errorf("the discovery of a missing LaunchKitConfig must be forgotten")
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gpuoperatorplugin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// clusterPolicyGVK is the kind of the GPU Operator configuration
+var clusterPolicyGVK = schema.GroupVersionKind{Group: "nvidia.com", Version: "v1", Kind: "ClusterPolicy"}
+
+// clusterPolicyReadyState is the status.state of a ClusterPolicy whose components are all ready
+const clusterPolicyReadyState = "ready"
+
+// DeployProfile applies the manifests in manifestsDir and waits for the ClusterPolicy to become ready.
+// The ClusterPolicy manifest only holds the settings of the profile and is applied onto the ClusterPolicy
+// created by the GPU Operator Helm chart, which must exist.
+func (p *GPUOperatorPlugin) DeployProfile(ctx context.Context, profile *profiles.Profile, config *config.LaunchKubernetesConfig, kubeClient client.Client, manifestsDir string) error {
+	objects, err := kubeclient.ReadManifests(manifestsDir)
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range objects {
+		isClusterPolicy := obj.GroupVersionKind() == clusterPolicyGVK
+		if isClusterPolicy {
+			existing := &unstructured.Unstructured{}
+			existing.SetGroupVersionKind(clusterPolicyGVK)
+			if err := kubeClient.Get(ctx, client.ObjectKey{Name: obj.GetName()}, existing); err != nil {
+				if apierrors.IsNotFound(err) {
+					return fmt.Errorf("ClusterPolicy %s not found, install the GPU Operator first", obj.GetName())
+				}
+				return fmt.Errorf("failed to get ClusterPolicy %s: %w", obj.GetName(), err)
+			}
+		}
+
+		log.Log.Info("Applying object", "kind", obj.GetKind(), "name", obj.GetName(), "version", obj.GetAPIVersion())
+		if err := kubeclient.Apply(ctx, kubeClient, obj, config.ForceConflicts()); err != nil {
+			return fmt.Errorf("failed to apply %s %s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+
+		if isClusterPolicy {
+			log.Log.Info("Waiting for ClusterPolicy to be ready", "name", obj.GetName())
+			if err := waitClusterPolicyReady(ctx, kubeClient, obj.GetName(), config.Timeouts); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// waitClusterPolicyReady polls the ClusterPolicy until its state is ready, logging state changes
+func waitClusterPolicyReady(ctx context.Context, c client.Client, name string, timeouts *config.TimeoutsConfig) error {
+	waitCtx, cancel := context.WithTimeout(ctx, timeouts.ClusterPolicyReady)
+	defer cancel()
+
+	lastState := ""
+	err := timeouts.PollBackoff().DelayFunc().Until(waitCtx, true, false, func(ctx context.Context) (bool, error) {
+		policy := &unstructured.Unstructured{}
+		policy.SetGroupVersionKind(clusterPolicyGVK)
+		if err := c.Get(ctx, client.ObjectKey{Name: name}, policy); err != nil {
+			log.Log.V(1).Info("failed to get ClusterPolicy", "name", name, "error", err.Error())
+			return false, nil
+		}
+		state, _, _ := unstructured.NestedString(policy.Object, "status", "state")
+		if state != lastState {
+			log.Log.Info("Waiting for ClusterPolicy", "name", name, "state", state)
+			lastState = state
+		}
+		return state == clusterPolicyReadyState, nil
+	})
+	if err != nil && wait.Interrupted(err) {
+		if ctx.Err() != nil {
+			return fmt.Errorf("interrupted while waiting for ClusterPolicy %s: %w", name, ctx.Err())
+		}
+		return fmt.Errorf("timeout waiting for ClusterPolicy %s to be ready after %s, last state: %q", name, timeouts.ClusterPolicyReady, lastState)
+	}
+	return err
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gpuoperatorplugin
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+	"github.com/nvidia/k8s-launch-kit/pkg/plugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// gpuPresentLabel is set on GPU nodes by the GPU Operator feature discovery
+	gpuPresentLabel = "nvidia.com/gpu.present"
+	// gpuCountLabel is set on GPU nodes by the GPU Operator feature discovery
+	gpuCountLabel = "nvidia.com/gpu.count"
+	// gpuProductLabel is set on GPU nodes by the GPU Operator feature discovery, e.g. NVIDIA-H100-80GB-HBM3
+	gpuProductLabel = "nvidia.com/gpu.product"
+	// nfdGPUPresentLabel is set by NFD on nodes with NVIDIA PCI devices
+	nfdGPUPresentLabel = "feature.node.kubernetes.io/pci-10de.present"
+)
+
+// DiscoverClusterConfig finds the GPU nodes from the labels set by NFD and the GPU Operator feature discovery.
+// Nothing is deployed; GPU capabilities found by other plugins are kept.
+func (p *GPUOperatorPlugin) DiscoverClusterConfig(ctx context.Context, c client.Client, defaultConfig *config.LaunchKubernetesConfig) error {
+	cluster := defaultConfig.ClusterConfig
+	if cluster == nil || cluster.Capabilities == nil || cluster.Capabilities.Nodes == nil {
+		return fmt.Errorf("cluster config is not initialized")
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := c.List(ctx, nodes); err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	cluster.GPUNodes = nil
+	products := map[string]bool{}
+	for _, node := range nodes.Items {
+		labels := node.Labels
+		if labels[gpuPresentLabel] != "true" && labels[nfdGPUPresentLabel] != "true" {
+			continue
+		}
+		cluster.GPUNodes = append(cluster.GPUNodes, node.Name)
+		cluster.Capabilities.Nodes.Gpu = true
+
+		if count, err := strconv.Atoi(labels[gpuCountLabel]); err == nil && count > 0 {
+			if cluster.Capabilities.Nodes.GpusPerNode == 0 || count < cluster.Capabilities.Nodes.GpusPerNode {
+				cluster.Capabilities.Nodes.GpusPerNode = count
+			}
+		}
+		if product := labels[gpuProductLabel]; product != "" {
+			products[product] = true
+		}
+	}
+	slices.Sort(cluster.GPUNodes)
+
+	if len(cluster.GPUNodes) == 0 {
+		log.Log.Info("No GPU nodes found, nodes are expected to be labeled by NFD or the GPU Operator", "labels", []string{nfdGPUPresentLabel, gpuPresentLabel})
+		return nil
+	}
+	log.Log.Info("Discovered GPU nodes", "nodes", cluster.GPUNodes, "gpusPerNode", cluster.Capabilities.Nodes.GpusPerNode, "products", slices.Sorted(maps.Keys(products)))
+
+	policies := &unstructured.UnstructuredList{}
+	policies.SetGroupVersionKind(clusterPolicyGVK.GroupVersion().WithKind(clusterPolicyGVK.Kind + "List"))
+	if err := c.List(ctx, policies); err != nil {
+		log.Log.Info("GPU Operator is not installed, it is required to deploy the gpu-operator profiles", "error", err.Error())
+	} else if len(policies.Items) == 0 {
+		log.Log.Info("No GPU Operator ClusterPolicy found, it is required to deploy the gpu-operator profiles")
+	}
+
+	return nil
+}
+
+// Permissions returns the permission to list the nodes for discovery, the ClusterPolicy read by the deployment
+// is part of the deployment files
+func (p *GPUOperatorPlugin) Permissions(phase string, profile *profiles.Profile, cfg *config.LaunchKubernetesConfig) ([]kubeclient.Permission, error) {
+	if phase == plugin.PhaseDiscover {
+		return []kubeclient.Permission{{Resource: "nodes", Verbs: []string{"list"}}}, nil
+	}
+	return nil, nil
+}
+
+var _ plugin.PermissionRequirer = &GPUOperatorPlugin{}
@@ -0,0 +1,135 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gpuoperatorplugin
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+	"github.com/nvidia/k8s-launch-kit/pkg/plugin"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	PluginName    = "gpu-operator"
+	PluginVersion = "1.0.0"
+)
+
+// GPUDirect modes, selecting the GPU Operator profile
+const (
+	GPUDirectNone    = "none"
+	GPUDirectRDMA    = "rdma"
+	GPUDirectStorage = "storage"
+)
+
+// GPUDirect RDMA implementations, see GPUOperatorConfig.RdmaMode
+const (
+	RdmaModeDMABuf  = "dmabuf"
+	RdmaModePeermem = "peermem"
+)
+
+// systemPromptAddendum is the only place the LLM is asked for the GPUDirect mode, the system prompt covers the network
+const systemPromptAddendum = `Additionally select the GPUDirect mode of the GPU Operator and add it to the JSON object as "gpuDirect": "none|rdma|storage":
+   - no GPUDirect requirements → "gpuDirect": "none"
+   - "GPUDirect RDMA", "GPU to GPU across nodes", "distributed training" → "gpuDirect": "rdma"
+   - "GPUDirect Storage", "GDS", "storage directly to GPU memory" → "gpuDirect": "storage"`
+
+func init() {
+	plugin.Register(plugin.Registration{
+		Metadata: plugin.Metadata{
+			Name:                PluginName,
+			Version:             PluginVersion,
+			ProfileRequirements: []string{"gpuDirect"},
+			// The GPU driver builds nvidia-peermem against the DOCA driver, which must be loaded first
+			DependsOn: []string{"network-operator"},
+		},
+		New:      func() plugin.Plugin { return &GPUOperatorPlugin{} },
+		AddFlags: addFlags,
+		Validate: validateOptions,
+	})
+}
+
+// GPUOperatorPlugin configures the ClusterPolicy of the GPU Operator consistently with the Network Operator,
+// for plain GPU workloads, GPUDirect RDMA and GPUDirect Storage
+type GPUOperatorPlugin struct {
+}
+
+// addFlags adds the profile selection flags of the plugin
+func addFlags(flags *pflag.FlagSet, opts *options.Options) {
+	flags.StringVar(&opts.GPUDirect, "gpu-direct", "", "Select the GPU Operator profile by GPUDirect mode (none, rdma, storage)")
+	flags.DurationVar(&opts.ClusterPolicyReadyTimeout, "cluster-policy-timeout", 0, "Timeout for the GPU Operator ClusterPolicy to become ready, e.g. while the GPU driver compiles (default 15m)")
+}
+
+// validateOptions validates the flag combinations of the plugin
+func validateOptions(options options.Options) error {
+	if options.GPUDirect != "" && !slices.Contains([]string{GPUDirectNone, GPUDirectRDMA, GPUDirectStorage}, options.GPUDirect) {
+		return fmt.Errorf("--gpu-direct must be one of: none, rdma, storage")
+	}
+
+	if options.GPUDirect != "" && options.Prompt != "" {
+		return fmt.Errorf("--gpu-direct and --prompt cannot be used together")
+	}
+
+	if options.GPUDirect == "" && options.Prompt == "" && options.Deploy {
+		return fmt.Errorf("--deploy requires --gpu-direct or --prompt to be specified")
+	}
+
+	if options.ClusterPolicyReadyTimeout < 0 {
+		return fmt.Errorf("--cluster-policy-timeout must not be negative")
+	}
+
+	return nil
+}
+
+func (p *GPUOperatorPlugin) GetName() string {
+	return PluginName
+}
+
+func (p *GPUOperatorPlugin) GetVersion() string {
+	return PluginVersion
+}
+
+func (p *GPUOperatorPlugin) ProfileConfiguredInCmd(_ context.Context, options options.Options) bool {
+	return options.GPUDirect != ""
+}
+
+func (p *GPUOperatorPlugin) BuildProfileFromOptions(_ context.Context, options options.Options, profile *config.Profile) error {
+	profile.GPUDirect = options.GPUDirect
+
+	log.Log.V(1).Info("Built profile for plugin", "plugin", p.GetName(), "profile", profile)
+	return nil
+}
+
+func (p *GPUOperatorPlugin) BuildProfileFromLLMResponse(_ context.Context, llmResponse map[string]string, profile *config.Profile) error {
+	profile.GPUDirect = llmResponse["gpuDirect"]
+	if profile.GPUDirect == "" {
+		profile.GPUDirect = GPUDirectNone
+	}
+
+	log.Log.V(1).Info("Built profile for plugin", "plugin", p.GetName(), "profile", profile)
+	return nil
+}
+
+func (p *GPUOperatorPlugin) GetSystemPromptAddendum(_ context.Context) (string, error) {
+	return systemPromptAddendum, nil
+}
+
+var _ plugin.Plugin = &GPUOperatorPlugin{}
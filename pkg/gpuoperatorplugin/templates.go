@@ -0,0 +1,124 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gpuoperatorplugin
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+)
+
+// GenerateProfileDeploymentFiles renders the ClusterPolicy of the profile after checking
+// that the GPU Operator settings are consistent with the GPUDirect mode and the DOCA driver
+func (p *GPUOperatorPlugin) GenerateProfileDeploymentFiles(_ context.Context, profile *profiles.Profile, config *config.LaunchKubernetesConfig) (map[string]string, error) {
+	if err := validateGPUOperatorConfig(config); err != nil {
+		return nil, fmt.Errorf("profile %s cannot be deployed with the given config: %w", profile.Name, err)
+	}
+
+	results := make(map[string]string)
+
+	for _, templatePath := range profile.Templates {
+		processed, err := profiles.ProcessTemplate(templatePath, config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to process template %s: %w", templatePath, err)
+		}
+
+		results[filepath.Base(templatePath)] = processed
+	}
+
+	return results, nil
+}
+
+// validateGPUOperatorConfig checks the gpuOperator section of the config against the selected GPUDirect mode.
+// GPUDirect RDMA over DMA-BUF and GPUDirect Storage need the open GPU kernel modules, GPUDirect Storage also needs
+// the DOCA driver to replace the inbox storage modules. Without a docaDriver section, DOCA is expected on the hosts.
+// The GPU driver must also be compatible with the DOCA driver, see checkDriverCompatibility.
+func validateGPUOperatorConfig(config *config.LaunchKubernetesConfig) error {
+	gpu := config.GPUOperator
+	if gpu == nil {
+		return fmt.Errorf("gpuOperator section is missing from the config")
+	}
+	if gpu.Repository == "" || gpu.DriverVersion == "" {
+		return fmt.Errorf("gpuOperator.repository and gpuOperator.driverVersion are required")
+	}
+
+	mode := config.Profile.GPUDirect
+	if mode == GPUDirectNone {
+		return nil
+	}
+
+	if gpu.RdmaMode != RdmaModeDMABuf && gpu.RdmaMode != RdmaModePeermem {
+		return fmt.Errorf("gpuOperator.rdmaMode must be one of: dmabuf, peermem")
+	}
+	if gpu.RdmaMode == RdmaModeDMABuf && gpu.KernelModuleType == "proprietary" {
+		return fmt.Errorf("GPUDirect RDMA over DMA-BUF requires gpuOperator.kernelModuleType open, use rdmaMode peermem with the proprietary modules")
+	}
+	if err := checkDriverCompatibility(gpu, config.DOCADriver); err != nil {
+		return err
+	}
+
+	if mode == GPUDirectStorage {
+		if gpu.KernelModuleType == "proprietary" {
+			return fmt.Errorf("GPUDirect Storage requires gpuOperator.kernelModuleType open")
+		}
+		if gpu.GdsRepository == "" || gpu.GdsVersion == "" {
+			return fmt.Errorf("gpuOperator.gdsRepository and gpuOperator.gdsVersion are required for GPUDirect Storage")
+		}
+		if config.DOCADriver != nil && !config.DOCADriver.UnloadStorageModules {
+			return fmt.Errorf("GPUDirect Storage requires docaDriver.unloadStorageModules: true, so that the DOCA driver provides the RDMA-enabled storage modules")
+		}
+	}
+
+	return nil
+}
+
+// minDMABufDriverBranch is the first GPU driver branch exporting GPU memory as DMA-BUF
+const minDMABufDriverBranch = 515
+
+// checkDriverCompatibility checks that the GPU driver version can be used for GPUDirect RDMA with the DOCA driver:
+// DMA-BUF needs a GPU driver from branch 515 on, and the branch must be in docaDriver.supportedGPUDrivers if set
+func checkDriverCompatibility(gpu *config.GPUOperatorConfig, doca *config.DOCADriverConfig) error {
+	branch, err := gpuDriverBranch(gpu.DriverVersion)
+	if err != nil {
+		return err
+	}
+	if gpu.RdmaMode == RdmaModeDMABuf && branch < minDMABufDriverBranch {
+		return fmt.Errorf("GPUDirect RDMA over DMA-BUF requires a GPU driver from branch %d on, gpuOperator.driverVersion is %s, use rdmaMode peermem with older drivers",
+			minDMABufDriverBranch, gpu.DriverVersion)
+	}
+	if doca != nil && len(doca.SupportedGPUDrivers) > 0 && !slices.Contains(doca.SupportedGPUDrivers, strconv.Itoa(branch)) {
+		return fmt.Errorf("GPU driver %s is not validated with DOCA driver %s for GPUDirect RDMA, supported GPU driver branches: %s",
+			gpu.DriverVersion, doca.Version, strings.Join(doca.SupportedGPUDrivers, ", "))
+	}
+	return nil
+}
+
+// gpuDriverBranch returns the branch of a GPU driver version, e.g. 580 for 580.82.07
+func gpuDriverBranch(version string) (int, error) {
+	major, _, _ := strings.Cut(version, ".")
+	branch, err := strconv.Atoi(major)
+	if err != nil {
+		return 0, fmt.Errorf("gpuOperator.driverVersion %q is not a GPU driver version, e.g. 580.82.07", version)
+	}
+	return branch, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gpuoperatorplugin
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+)
+
+func TestValidateGPUOperatorConfigDriverCompatibility(t *testing.T) {
+	tests := []struct {
+		name          string
+		driverVersion string
+		rdmaMode      string
+		gpuDirect     string
+		doca          *config.DOCADriverConfig
+		wantErr       string
+	}{
+		{name: "supported branch", driverVersion: "580.82.07", rdmaMode: RdmaModeDMABuf, gpuDirect: GPUDirectRDMA,
+			doca: &config.DOCADriverConfig{Version: "doca3.2.0", SupportedGPUDrivers: []string{"570", "580"}}},
+		{name: "unsupported branch", driverVersion: "550.163.01", rdmaMode: RdmaModeDMABuf, gpuDirect: GPUDirectRDMA,
+			doca: &config.DOCADriverConfig{Version: "doca3.2.0", SupportedGPUDrivers: []string{"570", "580"}}, wantErr: "not validated with DOCA driver"},
+		{name: "no support matrix", driverVersion: "550.163.01", rdmaMode: RdmaModeDMABuf, gpuDirect: GPUDirectRDMA,
+			doca: &config.DOCADriverConfig{Version: "doca3.2.0"}},
+		{name: "DMA-BUF before 515", driverVersion: "510.108.03", rdmaMode: RdmaModeDMABuf, gpuDirect: GPUDirectRDMA, wantErr: "from branch 515 on"},
+		{name: "peermem before 515", driverVersion: "510.108.03", rdmaMode: RdmaModePeermem, gpuDirect: GPUDirectRDMA},
+		{name: "invalid version", driverVersion: "latest", rdmaMode: RdmaModeDMABuf, gpuDirect: GPUDirectStorage, wantErr: "not a GPU driver version"},
+		{name: "no GPUDirect", driverVersion: "550.163.01", gpuDirect: GPUDirectNone,
+			doca: &config.DOCADriverConfig{Version: "doca3.2.0", SupportedGPUDrivers: []string{"580"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.LaunchKubernetesConfig{
+				Profile: &config.Profile{GPUDirect: tt.gpuDirect},
+				GPUOperator: &config.GPUOperatorConfig{Repository: "nvcr.io/nvidia", DriverVersion: tt.driverVersion, KernelModuleType: "open",
+					RdmaMode: tt.rdmaMode, GdsRepository: "nvcr.io/nvidia/cloud-native", GdsVersion: "2.20.5"},
+				DOCADriver: tt.doca,
+			}
+			if tt.doca != nil {
+				tt.doca.UnloadStorageModules = true
+			}
+
+			err := validateGPUOperatorConfig(cfg)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("error = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
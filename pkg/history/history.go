@@ -0,0 +1,324 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package history keeps the revisions of the deployments, i.e. the deployed files with the config and the profiles
+// they were rendered with, on disk and optionally in a ConfigMap or Secret of the cluster, to roll back to them.
+package history
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DirName is the directory of the revisions in the deployment files directory
+	DirName = "history"
+	// RevisionLabel holds the revision number of the ConfigMaps and Secrets storing revisions in the cluster
+	RevisionLabel = "nvidia.com/l8k-revision"
+
+	// StorageAny selects the revisions of both ConfigMaps and Secrets in LoadFromCluster
+	StorageAny = "any"
+
+	revisionFile = "revision.yaml"
+	configFile   = "config.yaml"
+	// keySeparator separates the plugin from the file name in the keys of the ConfigMaps and Secrets
+	keySeparator = "__"
+)
+
+// Revision is a deployment recorded in the history
+type Revision struct {
+	Number     int                `yaml:"number"`
+	Time       time.Time          `yaml:"time"`
+	RollbackOf int                `yaml:"rollbackOf,omitempty"` // revision this one rolled back to, 0 if none
+	Profiles   []profiles.Profile `yaml:"profiles"`             // deployed profiles, in deployment order
+
+	// Config the files were rendered with, stored as config.yaml
+	Config *config.LaunchKubernetesConfig `yaml:"-"`
+	// Files are the deployed files by plugin and file name, stored in a directory per plugin
+	Files map[string]map[string]string `yaml:"-"`
+}
+
+// encode returns the content of the revision by relative path
+func (r *Revision) encode() (map[string][]byte, error) {
+	content := map[string][]byte{}
+	revision, err := yaml.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	content[revisionFile] = revision
+	cfg, err := yaml.Marshal(r.Config)
+	if err != nil {
+		return nil, err
+	}
+	content[configFile] = cfg
+	for plugin, files := range r.Files {
+		for name, file := range files {
+			content[filepath.Join(plugin, name)] = []byte(file)
+		}
+	}
+	return content, nil
+}
+
+// decode returns the revision from its content by relative path
+func decode(content map[string][]byte) (*Revision, error) {
+	r := &Revision{Config: &config.LaunchKubernetesConfig{}, Files: map[string]map[string]string{}}
+	if err := yaml.Unmarshal(content[revisionFile], r); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", revisionFile, err)
+	}
+	if err := yaml.Unmarshal(content[configFile], r.Config); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", configFile, err)
+	}
+	for path, file := range content {
+		plugin, name, ok := strings.Cut(path, string(filepath.Separator))
+		if !ok {
+			continue
+		}
+		if r.Files[plugin] == nil {
+			r.Files[plugin] = map[string]string{}
+		}
+		r.Files[plugin][name] = string(file)
+	}
+	return r, nil
+}
+
+// Save writes the revision to the history directory of deploymentDir and removes the revisions older than the last maxRevisions
+func Save(deploymentDir string, r *Revision, maxRevisions int) error {
+	content, err := r.encode()
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(deploymentDir, DirName, strconv.Itoa(r.Number))
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	for path, file := range content {
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, file, 0644); err != nil {
+			return err
+		}
+	}
+
+	numbers, err := List(deploymentDir)
+	if err != nil {
+		return err
+	}
+	for len(numbers) > maxRevisions {
+		if err := os.RemoveAll(filepath.Join(deploymentDir, DirName, strconv.Itoa(numbers[0]))); err != nil {
+			return err
+		}
+		numbers = numbers[1:]
+	}
+	return nil
+}
+
+// List returns the numbers of the revisions in the history directory of deploymentDir, in ascending order
+func List(deploymentDir string) ([]int, error) {
+	entries, err := os.ReadDir(filepath.Join(deploymentDir, DirName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	numbers := []int{}
+	for _, entry := range entries {
+		if number, err := strconv.Atoi(entry.Name()); err == nil && entry.IsDir() {
+			numbers = append(numbers, number)
+		}
+	}
+	sort.Ints(numbers)
+	return numbers, nil
+}
+
+// Load reads a revision from the history directory of deploymentDir
+func Load(deploymentDir string, number int) (*Revision, error) {
+	dir := filepath.Join(deploymentDir, DirName, strconv.Itoa(number))
+	content := map[string][]byte{}
+	err := filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		file, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		relative, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		content[relative] = file
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read revision %d: %w", number, err)
+	}
+	return decode(content)
+}
+
+// SaveToCluster stores the revision in a ConfigMap or Secret, as selected by storage, in namespace
+// and removes the revisions older than the last maxRevisions
+func SaveToCluster(ctx context.Context, c client.Client, storage, namespace string, r *Revision, maxRevisions int) error {
+	content, err := r.encode()
+	if err != nil {
+		return err
+	}
+	data := map[string][]byte{}
+	for path, file := range content {
+		data[strings.ReplaceAll(path, string(filepath.Separator), keySeparator)] = file
+	}
+
+	obj := newStorageObject(storage)
+	obj.SetName(fmt.Sprintf("l8k-revision-%d", r.Number))
+	obj.SetNamespace(namespace)
+	obj.SetLabels(map[string]string{kubeclient.ManagedByLabel: kubeclient.FieldOwner, RevisionLabel: strconv.Itoa(r.Number)})
+	switch o := obj.(type) {
+	case *corev1.Secret:
+		o.Data = data
+	case *corev1.ConfigMap:
+		o.Data = map[string]string{}
+		for key, value := range data {
+			o.Data[key] = string(value)
+		}
+	}
+	err = c.Create(ctx, obj)
+	if apierrors.IsAlreadyExists(err) {
+		// The revision was stored by a deployment from another deployment files directory, it is replaced
+		existing := newStorageObject(storage)
+		if err = c.Get(ctx, client.ObjectKeyFromObject(obj), existing); err == nil {
+			obj.SetResourceVersion(existing.GetResourceVersion())
+			err = c.Update(ctx, obj)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to store revision %d in %s %s/%s: %w", r.Number, storage, namespace, obj.GetName(), err)
+	}
+
+	stored, err := listCluster(ctx, c, storage, client.InNamespace(namespace))
+	if err != nil {
+		return err
+	}
+	for len(stored) > maxRevisions {
+		if err := c.Delete(ctx, stored[0]); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		stored = stored[1:]
+	}
+	return nil
+}
+
+// LoadFromCluster returns the revision from the ConfigMaps or Secrets, as selected by storage, of all namespaces.
+// A number of 0 selects the latest revision. It returns nil if the revision is not found.
+func LoadFromCluster(ctx context.Context, c client.Client, storage string, number int) (*Revision, error) {
+	stored, err := listCluster(ctx, c, storage)
+	if err != nil || len(stored) == 0 {
+		return nil, err
+	}
+	obj := stored[len(stored)-1]
+	if number > 0 {
+		obj = nil
+		for _, o := range stored {
+			if storedNumber(o) == number {
+				obj = o
+			}
+		}
+		if obj == nil {
+			return nil, nil
+		}
+	}
+
+	content := map[string][]byte{}
+	add := func(key string, value []byte) {
+		content[strings.ReplaceAll(key, keySeparator, string(filepath.Separator))] = value
+	}
+	switch o := obj.(type) {
+	case *corev1.Secret:
+		for key, value := range o.Data {
+			add(key, value)
+		}
+	case *corev1.ConfigMap:
+		for key, value := range o.Data {
+			add(key, []byte(value))
+		}
+	}
+	return decode(content)
+}
+
+// LatestInCluster returns the number of the latest revision stored in the ConfigMaps or Secrets, as selected by storage,
+// of all namespaces, 0 if there is none
+func LatestInCluster(ctx context.Context, c client.Client, storage string) (int, error) {
+	stored, err := listCluster(ctx, c, storage)
+	if err != nil || len(stored) == 0 {
+		return 0, err
+	}
+	return storedNumber(stored[len(stored)-1]), nil
+}
+
+// listCluster returns the ConfigMaps or Secrets storing revisions, in ascending revision order
+func listCluster(ctx context.Context, c client.Client, storage string, opts ...client.ListOption) ([]client.Object, error) {
+	opts = append(opts, client.HasLabels{RevisionLabel})
+	objects := []client.Object{}
+	if storage == config.HistoryStorageSecret || storage == StorageAny {
+		list := &corev1.SecretList{}
+		if err := c.List(ctx, list, opts...); err != nil {
+			return nil, fmt.Errorf("failed to list the revisions stored in Secrets: %w", err)
+		}
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	}
+	if storage != config.HistoryStorageSecret {
+		list := &corev1.ConfigMapList{}
+		if err := c.List(ctx, list, opts...); err != nil {
+			return nil, fmt.Errorf("failed to list the revisions stored in ConfigMaps: %w", err)
+		}
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	}
+
+	sort.Slice(objects, func(i, j int) bool { return storedNumber(objects[i]) < storedNumber(objects[j]) })
+	return objects, nil
+}
+
+// storedNumber returns the number of the revision stored in a ConfigMap or Secret
+func storedNumber(obj client.Object) int {
+	n, _ := strconv.Atoi(obj.GetLabels()[RevisionLabel])
+	return n
+}
+
+func newStorageObject(storage string) client.Object {
+	if storage == config.HistoryStorageSecret {
+		return &corev1.Secret{}
+	}
+	return &corev1.ConfigMap{}
+}
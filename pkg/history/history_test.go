@@ -0,0 +1,163 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package history
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newRevision(number int) *Revision {
+	return &Revision{
+		Number:   number,
+		Time:     time.Date(2025, 6, 1, 12, 0, number, 0, time.UTC),
+		Profiles: []profiles.Profile{{Name: "SR-IOV Ethernet RDMA", Plugin: "network-operator", Templates: []string{"nicclusterpolicy.yaml"}}},
+		Config: &config.LaunchKubernetesConfig{
+			NetworkOperator: &config.NetworkOperatorConfig{Namespace: "nvidia-network-operator"},
+		},
+		Files: map[string]map[string]string{
+			"network-operator": {
+				"10-nicclusterpolicy.yaml": "kind: NicClusterPolicy\n",
+				"20-sriovnetwork.yaml":     "kind: SriovNetwork\n",
+			},
+		},
+	}
+}
+
+func TestSaveLoadList(t *testing.T) {
+	dir := t.TempDir()
+
+	numbers, err := List(dir)
+	if err != nil || len(numbers) != 0 {
+		t.Fatalf("List() of a directory without history = %v, %v", numbers, err)
+	}
+
+	for number := 1; number <= 4; number++ {
+		if err := Save(dir, newRevision(number), 3); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Directories that are not revisions are ignored
+	if err := os.MkdirAll(filepath.Join(dir, DirName, "backup"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	numbers, err = List(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{2, 3, 4}; !reflect.DeepEqual(numbers, want) {
+		t.Errorf("List() = %v, want %v, the oldest revision must be removed", numbers, want)
+	}
+
+	got, err := Load(dir, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := newRevision(3); !reflect.DeepEqual(got, want) {
+		t.Errorf("Load() = %+v, want %+v", got, want)
+	}
+
+	if _, err := Load(dir, 1); err == nil {
+		t.Errorf("Load() of a removed revision must fail")
+	}
+}
+
+func TestSaveToCluster(t *testing.T) {
+	for _, storage := range []string{config.HistoryStorageConfigMap, config.HistoryStorageSecret} {
+		t.Run(storage, func(t *testing.T) {
+			ctx := context.Background()
+			c := fake.NewClientBuilder().WithScheme(kubeclient.NewScheme()).Build()
+
+			for number := 1; number <= 3; number++ {
+				if err := SaveToCluster(ctx, c, storage, "nvidia-network-operator", newRevision(number), 2); err != nil {
+					t.Fatal(err)
+				}
+			}
+			// A revision stored from another deployment files directory is replaced
+			replaced := newRevision(3)
+			replaced.Files["network-operator"]["30-ippool.yaml"] = "kind: IPPool\n"
+			if err := SaveToCluster(ctx, c, storage, "nvidia-network-operator", replaced, 2); err != nil {
+				t.Fatalf("SaveToCluster() of an existing revision: %v", err)
+			}
+
+			stored, err := listCluster(ctx, c, storage)
+			if err != nil {
+				t.Fatal(err)
+			}
+			names := []string{}
+			for _, obj := range stored {
+				names = append(names, obj.GetName())
+				if obj.GetLabels()[kubeclient.ManagedByLabel] != kubeclient.FieldOwner {
+					t.Errorf("%s is not labelled as managed by l8k", obj.GetName())
+				}
+			}
+			if want := []string{"l8k-revision-2", "l8k-revision-3"}; !reflect.DeepEqual(names, want) {
+				t.Errorf("stored revisions = %v, want %v", names, want)
+			}
+
+			latest, err := LatestInCluster(ctx, c, storage)
+			if err != nil || latest != 3 {
+				t.Errorf("LatestInCluster() = %d, %v, want 3", latest, err)
+			}
+			got, err := LoadFromCluster(ctx, c, StorageAny, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, replaced) {
+				t.Errorf("LoadFromCluster() = %+v, want %+v", got, replaced)
+			}
+			got, err = LoadFromCluster(ctx, c, storage, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := newRevision(2); !reflect.DeepEqual(got, want) {
+				t.Errorf("LoadFromCluster(2) = %+v, want %+v", got, want)
+			}
+			if got, err := LoadFromCluster(ctx, c, storage, 1); err != nil || got != nil {
+				t.Errorf("LoadFromCluster() of a removed revision = %+v, %v, want nil", got, err)
+			}
+		})
+	}
+}
+
+func TestLoadFromClusterWithoutRevisions(t *testing.T) {
+	// ConfigMaps without the revision label are not revisions
+	c := fake.NewClientBuilder().WithScheme(kubeclient.NewScheme()).WithObjects(
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "l8k-revision-1", Namespace: "default"}},
+	).Build()
+
+	got, err := LoadFromCluster(context.Background(), c, StorageAny, 0)
+	if err != nil || got != nil {
+		t.Errorf("LoadFromCluster() = %+v, %v, want nil", got, err)
+	}
+	latest, err := LatestInCluster(context.Background(), c, config.HistoryStorageConfigMap)
+	if err != nil || latest != 0 {
+		t.Errorf("LatestInCluster() = %d, %v, want 0", latest, err)
+	}
+}
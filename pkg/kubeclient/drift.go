@@ -0,0 +1,260 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kubeclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// FieldDrift is a field of a manifest whose live value differs, or a manifest whose object does not exist
+type FieldDrift struct {
+	Kind      string   `json:"kind"`
+	Namespace string   `json:"namespace,omitempty"`
+	Name      string   `json:"name"`
+	Field     string   `json:"field"`              // e.g. .spec.ofedDriver.version, empty if the object does not exist
+	Rendered  string   `json:"rendered,omitempty"` // JSON value of the manifest
+	Live      string   `json:"live,omitempty"`     // JSON value of the live object, empty if not set
+	Managers  []string `json:"managers,omitempty"` // field managers owning the live field
+	Conflict  bool     `json:"conflict"`           // owned by another manager than l8k, overwritten only when forcing conflicts
+}
+
+// Drift compares obj with the live object and returns the fields of obj whose live value differs,
+// with the managers owning them according to the managedFields of the live object.
+// Metadata other than labels and annotations, and the status, are not compared.
+func Drift(ctx context.Context, c client.Client, obj *unstructured.Unstructured) ([]FieldDrift, error) {
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(obj.GroupVersionKind())
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), live); err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return []FieldDrift{{Kind: obj.GetKind(), Namespace: obj.GetNamespace(), Name: obj.GetName()}}, nil
+		}
+		return nil, fmt.Errorf("failed to get %s %s: %w", obj.GetKind(), obj.GetName(), err)
+	}
+
+	owners := fieldOwners(live)
+	drifts := []FieldDrift{}
+	for _, field := range diffFields(comparable(obj.Object), comparable(live.Object)) {
+		drift := FieldDrift{
+			Kind:      obj.GetKind(),
+			Namespace: obj.GetNamespace(),
+			Name:      obj.GetName(),
+			Field:     field.path,
+			Rendered:  toJSON(field.rendered),
+			Managers:  owners.of(field.path),
+		}
+		if field.live != nil {
+			drift.Live = toJSON(field.live)
+		}
+		drift.Conflict = slices.ContainsFunc(drift.Managers, func(manager string) bool { return manager != FieldOwner })
+		drifts = append(drifts, drift)
+	}
+	return drifts, nil
+}
+
+// comparable returns the labels, annotations and spec-like content of an object
+func comparable(object map[string]any) map[string]any {
+	content := map[string]any{}
+	for key, value := range object {
+		switch key {
+		case "apiVersion", "kind", "status":
+		case "metadata":
+			metadata, _ := value.(map[string]any)
+			filtered := map[string]any{}
+			for _, field := range []string{"labels", "annotations"} {
+				if v, ok := metadata[field]; ok {
+					filtered[field] = v
+				}
+			}
+			content[key] = filtered
+		default:
+			content[key] = value
+		}
+	}
+	return content
+}
+
+type fieldDiff struct {
+	path     string
+	rendered any
+	live     any
+}
+
+// diffFields returns the leaves of rendered that differ from live, lists of the same length are compared by index
+func diffFields(rendered, live map[string]any) []fieldDiff {
+	diffs := []fieldDiff{}
+	var walk func(path string, rendered, live any)
+	walk = func(path string, rendered, live any) {
+		switch r := rendered.(type) {
+		case map[string]any:
+			if l, ok := live.(map[string]any); ok {
+				keys := make([]string, 0, len(r))
+				for key := range r {
+					keys = append(keys, key)
+				}
+				sort.Strings(keys)
+				for _, key := range keys {
+					walk(path+"."+key, r[key], l[key])
+				}
+				return
+			}
+		case []any:
+			if l, ok := live.([]any); ok && len(l) == len(r) {
+				for i := range r {
+					walk(path+"["+strconv.Itoa(i)+"]", r[i], l[i])
+				}
+				return
+			}
+		}
+		if toJSON(rendered) != toJSON(live) {
+			diffs = append(diffs, fieldDiff{path: path, rendered: rendered, live: live})
+		}
+	}
+	walk("", rendered, live)
+	return diffs
+}
+
+func toJSON(value any) string {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(b)
+}
+
+// owners maps the paths of the owned fields of a live object to their managers
+type owners map[string][]string
+
+// fieldOwners resolves the managedFields of the live object, except the status, to paths in the format of diffFields.
+// Keyed and set list items are resolved to their index in the live object. The ownership of list items and map entries
+// themselves (".") is ignored, only fields conflict.
+func fieldOwners(live *unstructured.Unstructured) owners {
+	o := owners{}
+	for _, entry := range live.GetManagedFields() {
+		if entry.Subresource != "" || entry.FieldsV1 == nil {
+			continue
+		}
+		fields := map[string]any{}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			continue
+		}
+		o.add(entry.Manager, "", fields, live.Object)
+	}
+	return o
+}
+
+func (o owners) add(manager, path string, fields map[string]any, live any) {
+	for key, value := range fields {
+		sub, _ := value.(map[string]any)
+		kind, name, _ := strings.Cut(key, ":")
+
+		var childPath string
+		var child any
+		switch kind {
+		case "f":
+			childPath = path + "." + name
+			if m, ok := live.(map[string]any); ok {
+				child = m[name]
+			}
+		case "k", "v", "i":
+			items, _ := live.([]any)
+			index := listIndex(kind, name, items)
+			if index < 0 {
+				continue
+			}
+			childPath = path + "[" + strconv.Itoa(index) + "]"
+			child = items[index]
+		default:
+			continue
+		}
+
+		if len(sub) == 0 {
+			o.own(childPath, manager)
+		} else {
+			o.add(manager, childPath, sub, child)
+		}
+	}
+}
+
+func (o owners) own(path, manager string) {
+	if !slices.Contains(o[path], manager) {
+		o[path] = append(o[path], manager)
+	}
+}
+
+// listIndex returns the index of the live list item matching a keyed (k), set (v) or indexed (i) managedFields entry
+func listIndex(kind, name string, items []any) int {
+	switch kind {
+	case "i":
+		if i, err := strconv.Atoi(name); err == nil && i < len(items) {
+			return i
+		}
+	case "v":
+		for i, item := range items {
+			if toJSON(item) == name {
+				return i
+			}
+		}
+	case "k":
+		key := map[string]any{}
+		if err := json.Unmarshal([]byte(name), &key); err != nil {
+			return -1
+		}
+		for i, item := range items {
+			m, _ := item.(map[string]any)
+			if m != nil && slices.IndexFunc(mapKeys(key), func(k string) bool { return toJSON(m[k]) != toJSON(key[k]) }) < 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func mapKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// of returns the managers owning path, a field of it or one of its parents, sorted
+func (o owners) of(path string) []string {
+	managers := []string{}
+	for owned, ownedBy := range o {
+		if owned == path || strings.HasPrefix(path, owned+".") || strings.HasPrefix(path, owned+"[") ||
+			strings.HasPrefix(owned, path+".") || strings.HasPrefix(owned, path+"[") {
+			for _, manager := range ownedBy {
+				if !slices.Contains(managers, manager) {
+					managers = append(managers, manager)
+				}
+			}
+		}
+	}
+	sort.Strings(managers)
+	return managers
+}
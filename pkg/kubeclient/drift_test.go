@@ -0,0 +1,229 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kubeclient
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	sigsyaml "sigs.k8s.io/yaml"
+)
+
+// livePod is a pod applied by l8k whose image was changed by kubectl and a status written by the kubelet
+const livePod = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: test-rail0
+  namespace: default
+  labels:
+    app: test
+    app.kubernetes.io/managed-by: l8k
+  finalizers:
+  - example.com/cleanup
+  managedFields:
+  - manager: l8k
+    operation: Apply
+    apiVersion: v1
+    fieldsType: FieldsV1
+    fieldsV1:
+      f:metadata:
+        f:labels:
+          f:app: {}
+          f:app.kubernetes.io/managed-by: {}
+        f:finalizers:
+          v:"example.com/cleanup": {}
+      f:spec:
+        f:containers:
+          k:{"name":"test"}:
+            .: {}
+            f:name: {}
+            f:env:
+              k:{"name":"RAIL"}:
+                .: {}
+                f:name: {}
+                f:value: {}
+            f:resources:
+              f:limits:
+                f:nvidia.com/rail0: {}
+  - manager: kubectl-edit
+    operation: Update
+    apiVersion: v1
+    fieldsType: FieldsV1
+    fieldsV1:
+      f:spec:
+        f:containers:
+          k:{"name":"sidecar"}:
+            f:image: {}
+          k:{"name":"test"}:
+            f:image: {}
+  - manager: kubelet
+    operation: Update
+    apiVersion: v1
+    subresource: status
+    fieldsType: FieldsV1
+    fieldsV1:
+      f:status:
+        f:phase: {}
+spec:
+  containers:
+  - name: sidecar
+    image: busybox
+  - name: test
+    image: mellanox/rping-test
+    env:
+    - name: RAIL
+      value: rail0
+    resources:
+      limits:
+        nvidia.com/rail0: "1"
+status:
+  phase: Running
+`
+
+func decode(t *testing.T, manifest string) *unstructured.Unstructured {
+	t.Helper()
+	obj := &unstructured.Unstructured{}
+	if err := sigsyaml.Unmarshal([]byte(manifest), &obj.Object); err != nil {
+		t.Fatal(err)
+	}
+	return obj
+}
+
+func TestFieldOwners(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		want     owners
+	}{
+		{
+			name:     "fields owned by l8k and by another manager",
+			manifest: livePod,
+			want: owners{
+				".metadata.labels.app":                                  {"l8k"},
+				".metadata.labels.app.kubernetes.io/managed-by":         {"l8k"},
+				".metadata.finalizers[0]":                               {"l8k"},
+				".spec.containers[1].name":                              {"l8k"},
+				".spec.containers[1].env[0].name":                       {"l8k"},
+				".spec.containers[1].env[0].value":                      {"l8k"},
+				".spec.containers[1].resources.limits.nvidia.com/rail0": {"l8k"},
+				".spec.containers[0].image":                             {"kubectl-edit"},
+				".spec.containers[1].image":                             {"kubectl-edit"},
+			},
+		},
+		{
+			name: "list items keyed by index",
+			manifest: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+  managedFields:
+  - manager: l8k
+    operation: Apply
+    fieldsType: FieldsV1
+    fieldsV1:
+      f:data:
+        f:items:
+          i:1:
+            f:value: {}
+          i:5:
+            f:value: {}
+data:
+  items: [{value: a}, {value: b}]
+`,
+			want: owners{".data.items[1].value": {"l8k"}},
+		},
+		{
+			name: "no managedFields",
+			manifest: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  key: value
+`,
+			want: owners{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fieldOwners(decode(t, tt.manifest)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fieldOwners() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOwnersOf(t *testing.T) {
+	o := fieldOwners(decode(t, livePod))
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{path: ".spec.containers[1].image", want: []string{"kubectl-edit"}},
+		{path: ".spec.containers[1].resources.limits.nvidia.com/rail0", want: []string{"l8k"}},
+		// The container is compared as a whole when the live list has another length
+		{path: ".spec.containers", want: []string{"kubectl-edit", "l8k"}},
+		{path: ".spec.nodeName", want: []string{}},
+		{path: ".status.phase", want: []string{}},
+	}
+	for _, tt := range tests {
+		if got := o.of(tt.path); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("of(%s) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestListIndex(t *testing.T) {
+	items := []any{
+		map[string]any{"name": "rail0", "port": int64(1)},
+		map[string]any{"name": "rail1", "port": int64(1)},
+		map[string]any{"name": "rail1", "port": int64(2)},
+	}
+	values := []any{"a", "b", int64(3)}
+
+	tests := []struct {
+		name  string
+		kind  string
+		key   string
+		items []any
+		want  int
+	}{
+		{name: "keyed by name", kind: "k", key: `{"name":"rail1"}`, items: items, want: 1},
+		{name: "keyed by several fields", kind: "k", key: `{"name":"rail1","port":2}`, items: items, want: 2},
+		{name: "missing key", kind: "k", key: `{"name":"rail2"}`, items: items, want: -1},
+		{name: "invalid key", kind: "k", key: `{"name"`, items: items, want: -1},
+		{name: "set value", kind: "v", key: `"b"`, items: values, want: 1},
+		{name: "set number", kind: "v", key: `3`, items: values, want: 2},
+		{name: "missing set value", kind: "v", key: `"c"`, items: values, want: -1},
+		{name: "index", kind: "i", key: "2", items: items, want: 2},
+		{name: "index out of range", kind: "i", key: "3", items: items, want: -1},
+		{name: "invalid index", kind: "i", key: "x", items: items, want: -1},
+		{name: "empty list", kind: "k", key: `{"name":"rail0"}`, want: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := listIndex(tt.kind, tt.key, tt.items); got != tt.want {
+				t.Errorf("listIndex() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kubeclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// HelmRelease is a Helm chart to install or upgrade in the cluster
+type HelmRelease struct {
+	Name       string                 // Release name
+	Namespace  string                 // Namespace of the release, created if missing
+	Chart      string                 // Chart name in Repository, or path of a local chart archive or directory
+	Repository string                 // URL of the chart repository, https:// or oci://, ignored for local charts
+	Version    string                 // Chart version, the latest one if empty, ignored for local charts
+	Values     map[string]interface{} // Values of the release
+	Timeout    time.Duration          // Timeout for the resources of the release to become ready
+}
+
+// InstallOrUpgrade installs the release, or upgrades it if it already exists, like helm upgrade --install --wait.
+// It returns once the resources of the release are ready.
+func InstallOrUpgrade(ctx context.Context, opts Options, release HelmRelease) error {
+	logger := log.Log.WithName("helm")
+	actionConfig, err := newHelmConfig(opts, release.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to initialize Helm for release %s: %w", release.Name, err)
+	}
+
+	history := action.NewHistory(actionConfig)
+	history.Max = 1
+	_, err = history.Run(release.Name)
+	if err != nil && !errors.Is(err, driver.ErrReleaseNotFound) {
+		return fmt.Errorf("failed to get the history of release %s: %w", release.Name, err)
+	}
+	exists := err == nil
+
+	install := action.NewInstall(actionConfig)
+	chart, err := loadChart(actionConfig, install, release)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		logger.Info("Upgrading Helm release", "release", release.Name, "namespace", release.Namespace, "chart", chart.Metadata.Name, "version", chart.Metadata.Version)
+		upgrade := action.NewUpgrade(actionConfig)
+		upgrade.Namespace = release.Namespace
+		upgrade.Wait = true
+		upgrade.Timeout = release.Timeout
+		if _, err := upgrade.RunWithContext(ctx, release.Name, chart, release.Values); err != nil {
+			return fmt.Errorf("failed to upgrade release %s: %w", release.Name, err)
+		}
+	} else {
+		logger.Info("Installing Helm release", "release", release.Name, "namespace", release.Namespace, "chart", chart.Metadata.Name, "version", chart.Metadata.Version)
+		install.ReleaseName = release.Name
+		install.Namespace = release.Namespace
+		install.CreateNamespace = true
+		install.Wait = true
+		install.Timeout = release.Timeout
+		if _, err := install.RunWithContext(ctx, chart, release.Values); err != nil {
+			return fmt.Errorf("failed to install release %s: %w", release.Name, err)
+		}
+	}
+
+	logger.Info("Helm release ready", "release", release.Name, "namespace", release.Namespace)
+	return nil
+}
+
+// FindHelmRelease returns the name of the release of namespace to upgrade instead of installing release name: name itself
+// if it exists, else the release of a chart named chart, e.g. installed with another release name. It is empty if none exists.
+func FindHelmRelease(opts Options, namespace, name, chart string) (string, error) {
+	actionConfig, err := newHelmConfig(opts, namespace)
+	if err != nil {
+		return "", fmt.Errorf("failed to initialize Helm in namespace %s: %w", namespace, err)
+	}
+	list := action.NewList(actionConfig)
+	list.All = true
+	list.SetStateMask()
+	releases, err := list.Run()
+	if err != nil {
+		return "", fmt.Errorf("failed to list the Helm releases of namespace %s: %w", namespace, err)
+	}
+
+	found := ""
+	for _, r := range releases {
+		if r.Name == name {
+			return name, nil
+		}
+		if found == "" && r.Chart != nil && r.Chart.Metadata != nil && r.Chart.Metadata.Name == chart {
+			found = r.Name
+		}
+	}
+	return found, nil
+}
+
+// RenderHelmRelease returns the objects of the release, CRDs included, rendered without the cluster like helm template.
+// Namespaced objects without a namespace are in the namespace of the release.
+func RenderHelmRelease(opts Options, release HelmRelease) ([]*unstructured.Unstructured, error) {
+	actionConfig, err := newHelmConfig(opts, release.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize Helm for release %s: %w", release.Name, err)
+	}
+	install := action.NewInstall(actionConfig)
+	chart, err := loadChart(actionConfig, install, release)
+	if err != nil {
+		return nil, err
+	}
+
+	install.ReleaseName = release.Name
+	install.Namespace = release.Namespace
+	install.DryRun = true
+	install.ClientOnly = true
+	install.IncludeCRDs = true
+	rendered, err := install.Run(chart, release.Values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render release %s: %w", release.Name, err)
+	}
+	objects, err := DecodeManifests([]byte(rendered.Manifest))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the manifests of release %s: %w", release.Name, err)
+	}
+	for _, obj := range objects {
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(release.Namespace)
+		}
+	}
+	return objects, nil
+}
+
+// newHelmConfig returns the Helm configuration of the releases of namespace, stored in secrets like the helm CLI does
+func newHelmConfig(opts Options, namespace string) (*action.Configuration, error) {
+	getter := genericclioptions.NewConfigFlags(false)
+	getter.KubeConfig = &opts.Kubeconfig
+	getter.Context = &opts.Context
+	getter.Namespace = &namespace
+	getter.WrapConfigFn = func(restCfg *rest.Config) *rest.Config {
+		opts.apply(restCfg)
+		return restCfg
+	}
+
+	actionConfig := &action.Configuration{}
+	debug := func(format string, v ...interface{}) {
+		log.Log.WithName("helm").V(1).Info(fmt.Sprintf(format, v...))
+	}
+	if err := actionConfig.Init(getter, namespace, "secret", debug); err != nil {
+		return nil, err
+	}
+	return actionConfig, nil
+}
+
+// loadChart locates the chart of the release, downloading it from its repository, and loads it
+func loadChart(actionConfig *action.Configuration, install *action.Install, release HelmRelease) (*chart.Chart, error) {
+	settings := cli.New()
+	chartRef := release.Chart
+	repoURL := release.Repository
+	if registry.IsOCI(repoURL) {
+		chartRef = strings.TrimSuffix(repoURL, "/") + "/" + release.Chart
+		repoURL = ""
+		registryClient, err := registry.NewClient(registry.ClientOptCredentialsFile(settings.RegistryConfig))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the Helm registry client: %w", err)
+		}
+		actionConfig.RegistryClient = registryClient
+		install.SetRegistryClient(registryClient)
+	}
+
+	install.RepoURL = repoURL
+	install.Version = release.Version
+	chartPath, err := install.LocateChart(chartRef, settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate chart %s: %w", chartRef, err)
+	}
+	loaded, err := loader.Load(chartPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chart %s: %w", chartPath, err)
+	}
+	return loaded, nil
+}
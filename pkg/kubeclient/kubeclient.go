@@ -17,8 +17,13 @@
 package kubeclient
 
 import (
+	"context"
+	"fmt"
+
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -27,21 +32,99 @@ import (
 	corev1 "k8s.io/api/core/v1"
 )
 
-// New builds a controller-runtime client using the provided kubeconfig path
-// and registers required schemes.
-func New(kubeconfigPath string) (client.Client, error) {
-	// Build REST config from kubeconfig path
-	restCfg, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+// Options select the cluster and the credentials of the client, like the kubectl flags
+type Options struct {
+	Kubeconfig string   // Path to the kubeconfig file, the KUBECONFIG files or ~/.kube/config if empty, else the in-cluster config
+	Context    string   // Context of the kubeconfig, the current context if empty
+	As         string   // User to impersonate
+	AsGroups   []string // Groups to impersonate
+	QPS        float32  // Maximum queries per second to the API server, DefaultQPS if 0
+	Burst      int      // Maximum burst of queries to the API server, DefaultBurst if 0
+}
+
+// Default rate limits of the clients
+const (
+	DefaultQPS   = 20
+	DefaultBurst = 30
+)
+
+// New builds a controller-runtime client with the standard kubeconfig loading rules
+// and registers required schemes. The client also implements PodLogReader.
+func New(opts Options) (client.Client, error) {
+	restCfg, err := NewConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := client.New(restCfg, client.Options{Scheme: NewScheme()})
 	if err != nil {
 		return nil, err
 	}
+	clientset, err := kubernetes.NewForConfig(restCfg)
+	if err != nil {
+		return nil, err
+	}
+	return &logClient{Client: c, clientset: clientset}, nil
+}
+
+// PodLogReader is implemented by the clients of New, which read the logs of pods unlike the controller-runtime clients
+type PodLogReader interface {
+	// PodLogs returns the logs of the container of the pod
+	PodLogs(ctx context.Context, namespace, name, container string) (string, error)
+}
+
+// logClient is a controller-runtime client reading the logs of pods with a clientset
+type logClient struct {
+	client.Client
+	clientset kubernetes.Interface
+}
 
-	// Prepare scheme and client
+func (c *logClient) PodLogs(ctx context.Context, namespace, name, container string) (string, error) {
+	logs, err := c.clientset.CoreV1().Pods(namespace).GetLogs(name, &corev1.PodLogOptions{Container: container}).DoRaw(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the logs of pod %s/%s: %w", namespace, name, err)
+	}
+	return string(logs), nil
+}
+
+// NewConfig builds the REST config with the standard kubeconfig loading rules: the explicit kubeconfig path, else the
+// KUBECONFIG files merged, else ~/.kube/config, falling back to the in-cluster config when none is found
+func NewConfig(opts Options) (*rest.Config, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = opts.Kubeconfig
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: opts.Context}
+
+	restCfg, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the kubeconfig: %w", err)
+	}
+
+	opts.apply(restCfg)
+	return restCfg, nil
+}
+
+// apply sets the impersonation and rate limits of opts on restCfg. They are set here rather than in the kubeconfig
+// overrides, which the in-cluster config ignores.
+func (opts Options) apply(restCfg *rest.Config) {
+	if opts.As != "" || len(opts.AsGroups) > 0 {
+		restCfg.Impersonate = rest.ImpersonationConfig{UserName: opts.As, Groups: opts.AsGroups}
+	}
+	restCfg.QPS = DefaultQPS
+	if opts.QPS > 0 {
+		restCfg.QPS = opts.QPS
+	}
+	restCfg.Burst = DefaultBurst
+	if opts.Burst > 0 {
+		restCfg.Burst = opts.Burst
+	}
+}
+
+// NewScheme returns a scheme with the Kubernetes, network-operator and nic-configuration-operator types
+func NewScheme() *runtime.Scheme {
 	scheme := runtime.NewScheme()
 	_ = clientgoscheme.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
 	_ = netop.AddToScheme(scheme)
 	_ = nicop.AddToScheme(scheme)
-
-	return client.New(restCfg, client.Options{Scheme: scheme})
+	return scheme
 }
@@ -0,0 +1,106 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kubeclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// FieldOwner is the field manager of the objects applied by l8k
+	FieldOwner = "l8k"
+	// ManagedByLabel is set to FieldOwner on the objects applied by l8k, to find them with l8k status
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+)
+
+// ReadManifests decodes the YAML documents of the .yaml files in dir, in file name order
+func ReadManifests(dir string) ([]*unstructured.Unstructured, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	objects := []*unstructured.Unstructured{}
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		decoded, err := DecodeManifests(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode manifest %s: %w", path, err)
+		}
+		objects = append(objects, decoded...)
+	}
+
+	return objects, nil
+}
+
+// DecodeManifests decodes the YAML documents of content, skipping empty documents
+func DecodeManifests(content []byte) ([]*unstructured.Unstructured, error) {
+	objects := []*unstructured.Unstructured{}
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(content), 4096)
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				return objects, nil
+			}
+			return nil, err
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+		objects = append(objects, obj)
+	}
+}
+
+// Apply server-side applies obj, labelled as managed by l8k. Unless forceConflicts is set, it fails when obj
+// changes fields owned by other field managers, like kubectl apply --server-side without --force-conflicts.
+func Apply(ctx context.Context, c client.Client, obj *unstructured.Unstructured, forceConflicts bool) error {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[ManagedByLabel] = FieldOwner
+	obj.SetLabels(labels)
+
+	opts := []client.PatchOption{client.FieldOwner(FieldOwner)}
+	if forceConflicts {
+		opts = append(opts, client.ForceOwnership)
+	}
+	if err := c.Patch(ctx, obj, client.Apply, opts...); err != nil {
+		if apierrors.IsConflict(err) && !forceConflicts {
+			return fmt.Errorf("%s %s has fields owned by other field managers, use --force-conflicts to take them over: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kubeclient
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Permission allows verbs on a resource, in a namespace or in all namespaces if empty
+type Permission struct {
+	Group     string   `json:"group"`    // API group, empty for the core group
+	Resource  string   `json:"resource"` // plural name of the resource, e.g. nicclusterpolicies, or resource/subresource, e.g. pods/log
+	Namespace string   `json:"namespace,omitempty"`
+	Verbs     []string `json:"verbs"`
+}
+
+// GroupResource returns the resource qualified by its group, like kubectl, e.g. nicclusterpolicies.mellanox.com
+func (p Permission) GroupResource() string {
+	return schema.GroupResource{Group: p.Group, Resource: p.Resource}.String()
+}
+
+// KindPermissions returns the permissions of verbs on the resources of kinds, in namespace for the namespaced ones.
+// The resources are resolved with the REST mapper of c, and guessed from the kinds not served yet, e.g. the kinds
+// installed by an operator that is deployed first.
+func KindPermissions(c client.Client, kinds []schema.GroupVersionKind, namespace string, verbs ...string) []Permission {
+	permissions := []Permission{}
+	for _, gvk := range kinds {
+		resource, namespaced := resourceOf(c, gvk)
+		permission := Permission{Group: gvk.Group, Resource: resource, Verbs: verbs}
+		if namespaced {
+			permission.Namespace = namespace
+		}
+		permissions = append(permissions, permission)
+	}
+	return MergePermissions(permissions)
+}
+
+// ObjectPermissions returns the permissions of verbs on the objects, in their namespaces, see KindPermissions
+func ObjectPermissions(c client.Client, objects []*unstructured.Unstructured, verbs ...string) []Permission {
+	permissions := []Permission{}
+	for _, obj := range objects {
+		permissions = append(permissions, KindPermissions(c, []schema.GroupVersionKind{obj.GroupVersionKind()}, obj.GetNamespace(), verbs...)...)
+	}
+	return MergePermissions(permissions)
+}
+
+// resourceOf returns the resource of gvk and whether it is namespaced, guessed as namespaced if gvk is not served
+func resourceOf(c client.Client, gvk schema.GroupVersionKind) (string, bool) {
+	mapping, err := c.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		plural, _ := meta.UnsafeGuessKindToResource(gvk)
+		return plural.Resource, true
+	}
+	return mapping.Resource.Resource, mapping.Scope.Name() == meta.RESTScopeNameNamespace
+}
+
+// MergePermissions merges the verbs of the permissions on the same resource and namespace,
+// sorted by group, resource and namespace
+func MergePermissions(permissions []Permission) []Permission {
+	type resourceKey struct{ group, resource, namespace string }
+	merged := map[resourceKey]*Permission{}
+	for _, p := range permissions {
+		key := resourceKey{p.Group, p.Resource, p.Namespace}
+		if merged[key] == nil {
+			merged[key] = &Permission{Group: p.Group, Resource: p.Resource, Namespace: p.Namespace}
+		}
+		for _, verb := range p.Verbs {
+			if !slices.Contains(merged[key].Verbs, verb) {
+				merged[key].Verbs = append(merged[key].Verbs, verb)
+			}
+		}
+	}
+
+	result := make([]Permission, 0, len(merged))
+	for _, p := range merged {
+		sort.Strings(p.Verbs)
+		result = append(result, *p)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		return strings.Join([]string{a.Group, a.Resource, a.Namespace}, "/") < strings.Join([]string{b.Group, b.Resource, b.Namespace}, "/")
+	})
+	return result
+}
+
+// CheckPermissions returns the permissions not granted to the user of c, with only the missing verbs.
+// Every verb is checked with a SelfSubjectAccessReview, which any authenticated user may create.
+func CheckPermissions(ctx context.Context, c client.Client, permissions []Permission) ([]Permission, error) {
+	missing := []Permission{}
+	for _, p := range MergePermissions(permissions) {
+		denied := Permission{Group: p.Group, Resource: p.Resource, Namespace: p.Namespace}
+		resource, subresource, _ := strings.Cut(p.Resource, "/")
+		for _, verb := range p.Verbs {
+			review := &authorizationv1.SelfSubjectAccessReview{
+				Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+					ResourceAttributes: &authorizationv1.ResourceAttributes{
+						Namespace:   p.Namespace,
+						Verb:        verb,
+						Group:       p.Group,
+						Resource:    resource,
+						Subresource: subresource,
+					},
+				},
+			}
+			if err := c.Create(ctx, review); err != nil {
+				return nil, fmt.Errorf("failed to review the permission to %s %s: %w", verb, p.GroupResource(), err)
+			}
+			if !review.Status.Allowed {
+				denied.Verbs = append(denied.Verbs, verb)
+			}
+		}
+		if len(denied.Verbs) > 0 {
+			missing = append(missing, denied)
+		}
+	}
+	return missing, nil
+}
+
+// ClusterRole returns a ClusterRole named name granting the permissions in all namespaces,
+// with one rule per API group and set of verbs
+func ClusterRole(name string, permissions []Permission) *rbacv1.ClusterRole {
+	role := &rbacv1.ClusterRole{
+		TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRole"},
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+	}
+
+	// Namespaces are dropped, the verbs of a resource in several namespaces are merged
+	cluster := []Permission{}
+	for _, p := range permissions {
+		cluster = append(cluster, Permission{Group: p.Group, Resource: p.Resource, Verbs: p.Verbs})
+	}
+	rules := map[string]*rbacv1.PolicyRule{}
+	keys := []string{}
+	for _, p := range MergePermissions(cluster) {
+		key := p.Group + "/" + strings.Join(p.Verbs, ",")
+		if rules[key] == nil {
+			rules[key] = &rbacv1.PolicyRule{APIGroups: []string{p.Group}, Verbs: p.Verbs}
+			keys = append(keys, key)
+		}
+		rules[key].Resources = append(rules[key].Resources, p.Resource)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		role.Rules = append(role.Rules, *rules[key])
+	}
+	return role
+}
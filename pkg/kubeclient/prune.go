@@ -0,0 +1,67 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kubeclient
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Prune deletes the objects of previous missing from current, in reverse order, i.e. dependent objects first.
+// Only the live objects labelled as managed by l8k are deleted.
+func Prune(ctx context.Context, c client.Client, previous, current []*unstructured.Unstructured) error {
+	kept := map[string]bool{}
+	for _, obj := range current {
+		kept[objectKey(obj)] = true
+	}
+
+	for _, obj := range slices.Backward(previous) {
+		if kept[objectKey(obj)] {
+			continue
+		}
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(obj.GroupVersionKind())
+		if err := c.Get(ctx, client.ObjectKeyFromObject(obj), live); err != nil {
+			if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get %s %s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		if live.GetLabels()[ManagedByLabel] != FieldOwner {
+			log.Log.Info("Not pruning object not managed by l8k", "kind", obj.GetKind(), "namespace", obj.GetNamespace(), "name", obj.GetName())
+			continue
+		}
+
+		uid := live.GetUID()
+		log.Log.Info("Pruning object", "kind", obj.GetKind(), "namespace", obj.GetNamespace(), "name", obj.GetName())
+		if err := c.Delete(ctx, live, client.Preconditions{UID: &uid}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to prune %s %s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+	}
+	return nil
+}
+
+func objectKey(obj *unstructured.Unstructured) string {
+	return obj.GroupVersionKind().GroupKind().String() + "/" + obj.GetNamespace() + "/" + obj.GetName()
+}
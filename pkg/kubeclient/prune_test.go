@@ -0,0 +1,91 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kubeclient
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func configMap(name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetNamespace("default")
+	obj.SetName(name)
+	return obj
+}
+
+func TestPrune(t *testing.T) {
+	managed := map[string]string{ManagedByLabel: FieldOwner}
+	deleted := []string{}
+	c := fake.NewClientBuilder().WithScheme(NewScheme()).WithObjects(
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "kept", Namespace: "default", Labels: managed}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "removed-first", Namespace: "default", Labels: managed}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "removed-last", Namespace: "default", Labels: managed}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "taken-over", Namespace: "default"}},
+	).WithInterceptorFuncs(interceptor.Funcs{
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			deleted = append(deleted, obj.GetName())
+			return c.Delete(ctx, obj, opts...)
+		},
+	}).Build()
+
+	previous := []*unstructured.Unstructured{configMap("kept"), configMap("removed-first"), configMap("taken-over"), configMap("already-deleted"), configMap("removed-last")}
+	current := []*unstructured.Unstructured{configMap("kept"), configMap("added")}
+	if err := Prune(context.Background(), c, previous, current); err != nil {
+		t.Fatal(err)
+	}
+
+	// Dependent objects, deployed last, are deleted first
+	if want := []string{"removed-last", "removed-first"}; !reflect.DeepEqual(deleted, want) {
+		t.Errorf("deleted %v, want %v", deleted, want)
+	}
+	for name, exists := range map[string]bool{"kept": true, "taken-over": true, "removed-first": false, "removed-last": false} {
+		err := c.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: name}, &corev1.ConfigMap{})
+		if exists && err != nil {
+			t.Errorf("%s must be kept: %v", name, err)
+		}
+		if !exists && !apierrors.IsNotFound(err) {
+			t.Errorf("%s must be pruned: %v", name, err)
+		}
+	}
+}
+
+func TestPruneComparesTheKind(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(NewScheme()).WithObjects(
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Labels: map[string]string{ManagedByLabel: FieldOwner}}},
+	).Build()
+
+	secret := configMap("test")
+	secret.SetKind("Secret")
+	if err := Prune(context.Background(), c, []*unstructured.Unstructured{configMap("test")}, []*unstructured.Unstructured{secret}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "test"}, &corev1.ConfigMap{}); !apierrors.IsNotFound(err) {
+		t.Errorf("the ConfigMap is no longer deployed and must be pruned: %v", err)
+	}
+}
@@ -28,7 +28,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
-func SelectPrompt(promptPath string, config config.ClusterConfig, llmApiKey string, llmApiUrl string, llmVendor string) (map[string]string, error) {
+func SelectPrompt(ctx context.Context, promptPath string, config config.ClusterConfig, llmApiKey string, llmApiUrl string, llmVendor string) (map[string]string, error) {
 	options := []openai.Option{}
 
 	if llmVendor == "openai-azure" {
@@ -68,7 +68,7 @@ func SelectPrompt(promptPath string, config config.ClusterConfig, llmApiKey stri
 
 	log.Log.V(1).Info("User prompt", "prompt", string(data))
 
-	response, err := llms.GenerateFromSinglePrompt(context.Background(), llm, prompt, llms.WithTemperature(0.5))
+	response, err := llms.GenerateFromSinglePrompt(ctx, llm, prompt, llms.WithTemperature(0.5))
 	if err != nil {
 		return nil, err
 	}
@@ -18,6 +18,9 @@ package log
 
 import (
 	"flag"
+	"io"
+	"os"
+	"sync/atomic"
 
 	zzap "go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -29,9 +32,13 @@ const (
 	DebugLevel = int(zapcore.DebugLevel)
 )
 
+// stderr is the destination of the log, counting its writes
+var stderr = &countingWriter{w: os.Stderr}
+
 // Options stores controller-runtime (zap) log config
 var Options = &zap.Options{
 	Development: true,
+	DestWriter:  stderr,
 	// we dont log with panic level, so this essentially
 	// disables stacktrace, for now, it avoids un-needed clutter in logs
 	StacktraceLevel: zapcore.DPanicLevel,
@@ -74,3 +81,26 @@ func SetLogLevel(lvl string) error {
 func GetLogLevel() string {
 	return Options.Level.(zzap.AtomicLevel).Level().String()
 }
+
+// Stderr returns the writer the log is written to. Output interleaved with the log,
+// e.g. the stderr of child processes, should be written to it, so that it is counted by StderrWrites.
+func Stderr() io.Writer {
+	return stderr
+}
+
+// StderrWrites returns the number of writes to Stderr so far. Live views redrawn in place on stderr
+// use it to tell whether something else was written below them since their last redraw.
+func StderrWrites() uint64 {
+	return stderr.writes.Load()
+}
+
+// countingWriter is a writer counting the writes to the wrapped writer
+type countingWriter struct {
+	w      io.Writer
+	writes atomic.Uint64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	c.writes.Add(1)
+	return c.w.Write(p)
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package networkoperatorplugin
+
+import (
+	"context"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// controlPlaneNamespace holds the static pods of the control plane on kubeadm-like clusters
+const controlPlaneNamespace = "kube-system"
+
+// discoverClusterCIDRs fills the node, pod and service CIDRs of the cluster config, used to reject overlapping nv-ipam subnets.
+// Pod and service CIDRs are read from the ServiceCIDR API and the flags of the control plane static pods when available,
+// the pod CIDRs assigned to the nodes are used as fallback. CIDRs that cannot be read are left empty and logged.
+func discoverClusterCIDRs(ctx context.Context, c client.Client, cluster *config.ClusterConfig) {
+	cluster.NodeCIDRs = nil
+	cluster.PodCIDRs = nil
+	cluster.ServiceCIDRs = nil
+
+	nodes := &corev1.NodeList{}
+	if err := c.List(ctx, nodes); err != nil {
+		log.Log.Info("failed to list nodes, node and pod CIDRs are unknown", "error", err.Error())
+	}
+	nodePodCIDRs := []string{}
+	for _, node := range nodes.Items {
+		for _, address := range node.Status.Addresses {
+			if address.Type != corev1.NodeInternalIP && address.Type != corev1.NodeExternalIP {
+				continue
+			}
+			if addr, err := netip.ParseAddr(address.Address); err == nil {
+				cluster.NodeCIDRs = appendCIDR(cluster.NodeCIDRs, netip.PrefixFrom(addr, addr.BitLen()).String())
+			}
+		}
+		for _, cidr := range node.Spec.PodCIDRs {
+			nodePodCIDRs = appendCIDR(nodePodCIDRs, cidr)
+		}
+	}
+
+	serviceCIDRs := &networkingv1beta1.ServiceCIDRList{}
+	if err := c.List(ctx, serviceCIDRs); err != nil {
+		log.Log.V(1).Info("ServiceCIDR API not available, reading the service CIDRs from the kube-apiserver flags", "error", err.Error())
+	}
+	for _, serviceCIDR := range serviceCIDRs.Items {
+		for _, cidr := range serviceCIDR.Spec.CIDRs {
+			cluster.ServiceCIDRs = appendCIDR(cluster.ServiceCIDRs, cidr)
+		}
+	}
+	if len(cluster.ServiceCIDRs) == 0 {
+		for _, cidr := range controlPlaneFlag(ctx, c, "kube-apiserver", "--service-cluster-ip-range") {
+			cluster.ServiceCIDRs = appendCIDR(cluster.ServiceCIDRs, cidr)
+		}
+	}
+
+	for _, cidr := range controlPlaneFlag(ctx, c, "kube-controller-manager", "--cluster-cidr") {
+		cluster.PodCIDRs = appendCIDR(cluster.PodCIDRs, cidr)
+	}
+	if len(cluster.PodCIDRs) == 0 {
+		cluster.PodCIDRs = nodePodCIDRs
+	}
+
+	if len(cluster.PodCIDRs) == 0 || len(cluster.ServiceCIDRs) == 0 {
+		log.Log.Info("Pod or service CIDRs of the cluster are unknown, set clusterConfig.podCIDRs and clusterConfig.serviceCIDRs to validate the nv-ipam subnets against them")
+	}
+	log.Log.Info("Discovered cluster CIDRs", "nodes", len(cluster.NodeCIDRs), "pods", cluster.PodCIDRs, "services", cluster.ServiceCIDRs)
+}
+
+// controlPlaneFlag returns the comma-separated values of a flag of the static pods of a control plane component,
+// e.g. --service-cluster-ip-range of kube-apiserver. Nothing is returned on managed clusters without such pods.
+func controlPlaneFlag(ctx context.Context, c client.Client, component, flag string) []string {
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods, client.InNamespace(controlPlaneNamespace), client.MatchingLabels{"component": component}); err != nil {
+		log.Log.V(1).Info("failed to list control plane pods", "component", component, "error", err.Error())
+		return nil
+	}
+
+	values := []string{}
+	for _, pod := range pods.Items {
+		for _, container := range pod.Spec.Containers {
+			for _, arg := range slices.Concat(container.Command, container.Args) {
+				if value, ok := strings.CutPrefix(arg, flag+"="); ok {
+					for _, v := range strings.Split(value, ",") {
+						values = appendCIDR(values, strings.TrimSpace(v))
+					}
+				}
+			}
+		}
+	}
+	return values
+}
+
+// appendCIDR appends a valid, not yet listed CIDR to cidrs
+func appendCIDR(cidrs []string, cidr string) []string {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return cidrs
+	}
+	cidr = prefix.Masked().String()
+	if slices.Contains(cidrs, cidr) {
+		return cidrs
+	}
+	return append(cidrs, cidr)
+}
@@ -0,0 +1,241 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package networkoperatorplugin
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// nodeCollectorScript prints the attributes of the PFs given as PCI address arguments and of the NVIDIA GPUs
+// of the node to the pod logs, one device per line:
+// pf|pci|deviceID|totalVfs|numVfs|numaNode|pciLinkSpeed|pciLinkWidth|mtu|linkSpeed|path
+// gpu|pci|numaNode|path
+// where numVfs is - if the PF has no SR-IOV capability, i.e. SR-IOV is disabled in firmware, and path is the PCIe path
+// of the device under /sys/devices, used to compute the GPU to NIC distance.
+// The output is copied to the termination log for the clients that cannot read pod logs, where the kubelet truncates it
+// to 4096 bytes.
+const nodeCollectorScript = `
+{
+for pci in "$@"; do
+  d=/host/sys/bus/pci/devices/$pci
+  [ -d "$d" ] || continue
+  netif=$(ls "$d/net" 2>/dev/null | head -n 1)
+  echo "pf|$pci|$(cat $d/device)|$(cat $d/sriov_totalvfs 2>/dev/null)|$(cat $d/sriov_numvfs 2>/dev/null || echo -)|$(cat $d/numa_node 2>/dev/null)|$(cat $d/current_link_speed 2>/dev/null)|$(cat $d/current_link_width 2>/dev/null)|$(cat $d/net/$netif/mtu 2>/dev/null)|$(cat $d/net/$netif/speed 2>/dev/null)|$(readlink -f $d | sed 's|^/host/sys/devices/||')"
+done
+for d in /host/sys/bus/pci/devices/*; do
+  [ "$(cat $d/vendor)" = "0x10de" ] || continue
+  case "$(cat $d/class)" in 0x0300*|0x0302*) ;; *) continue ;; esac
+  echo "gpu|${d##*/}|$(cat $d/numa_node 2>/dev/null)|$(readlink -f $d | sed 's|^/host/sys/devices/||')"
+done
+} | tee /dev/termination-log
+`
+
+// nodeCollectorContainer is the name of the container of the collector pods
+const nodeCollectorContainer = "collector"
+
+// nodeAttributes are the device attributes read from sysfs on a node
+type nodeAttributes struct {
+	PFs  map[string]pfAttributes // by PCI address
+	GPUs []gpuAttributes
+}
+
+// pf returns the attributes of the PF with the given PCI address, if collected
+func (n *nodeAttributes) pf(pciAddress string) (pfAttributes, bool) {
+	if n == nil {
+		return pfAttributes{}, false
+	}
+	attrs, ok := n.PFs[pciAddress]
+	return attrs, ok
+}
+
+// pfAttributes are the PF attributes read from sysfs on the node
+type pfAttributes struct {
+	DeviceID     string
+	SriovEnabled bool // the PF exposes sriov_numvfs and can have VFs
+	TotalVfs     int
+	NumaNode     *int
+	PciLinkSpeed string
+	PciLinkWidth int
+	Mtu          int
+	LinkSpeed    int
+	Path         string
+}
+
+// gpuAttributes are the GPU attributes read from sysfs on the node
+type gpuAttributes struct {
+	PciAddress string
+	NumaNode   *int
+	Path       string
+}
+
+// collectNodeAttributes runs a short-lived pod on every node reading the attributes of the given PFs and of the GPUs from sysfs.
+// pciByNode maps node names to the PCI addresses of their PFs. The result is indexed by node name.
+// Nodes whose collector fails are missing from the result; the pods are always removed before returning.
+func collectNodeAttributes(ctx context.Context, c client.Client, namespace, image string, pciByNode map[string][]string, timeouts *config.TimeoutsConfig) map[string]*nodeAttributes {
+	result := map[string]*nodeAttributes{}
+	pods := []*corev1.Pod{}
+
+	defer func() {
+		cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cleanupTimeout)
+		defer cancel()
+		for _, pod := range pods {
+			if err := c.Delete(cleanupCtx, pod, client.Preconditions{UID: &pod.UID}); err != nil && !apierrors.IsNotFound(err) {
+				log.Log.Error(err, "failed to delete node collector pod", "pod", pod.Name)
+			}
+		}
+	}()
+
+	for node, pcis := range pciByNode {
+		pod := newNodeCollectorPod(namespace, image, node, pcis)
+		if err := c.Create(ctx, pod); err != nil {
+			log.Log.Error(err, "failed to create node collector pod, skipping node", "node", node)
+			continue
+		}
+		pods = append(pods, pod)
+	}
+
+	err := waitFor(ctx, timeouts.NodeCollector, timeouts, "node collector pods to complete", func(ctx context.Context) (bool, error) {
+		for _, pod := range pods {
+			current := &corev1.Pod{}
+			if err := c.Get(ctx, client.ObjectKeyFromObject(pod), current); err != nil {
+				return false, nil
+			}
+			if current.Status.Phase != corev1.PodSucceeded && current.Status.Phase != corev1.PodFailed {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		log.Log.Error(err, "Node collector did not complete on every node, continuing with partial results")
+	}
+
+	for _, pod := range pods {
+		current := &corev1.Pod{}
+		if err := c.Get(ctx, client.ObjectKeyFromObject(pod), current); err != nil || current.Status.Phase != corev1.PodSucceeded {
+			log.Log.Info("No device attributes collected from node", "node", pod.Spec.NodeName)
+			continue
+		}
+		if output, ok := nodeCollectorOutput(ctx, c, current); ok {
+			result[pod.Spec.NodeName] = parseNodeAttributes(output)
+		}
+	}
+
+	return result
+}
+
+// nodeCollectorOutput returns the output of a completed collector pod, read from its logs when the client can read them
+// and from its termination message otherwise
+func nodeCollectorOutput(ctx context.Context, c client.Client, pod *corev1.Pod) (string, bool) {
+	if reader, ok := c.(kubeclient.PodLogReader); ok {
+		logs, err := reader.PodLogs(ctx, pod.Namespace, pod.Name, nodeCollectorContainer)
+		if err == nil {
+			return logs, true
+		}
+		log.Log.Error(err, "failed to read node collector logs, falling back to the termination message", "pod", pod.Name)
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name == nodeCollectorContainer && cs.State.Terminated != nil {
+			return cs.State.Terminated.Message, true
+		}
+	}
+	return "", false
+}
+
+// newNodeCollectorPod returns the collector pod for node, mounting the host sysfs read-only
+func newNodeCollectorPod(namespace, image, node string, pcis []string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "l8k-node-collector-",
+			Namespace:    namespace,
+			Labels:       map[string]string{discoveryLabel: "true"},
+		},
+		Spec: corev1.PodSpec{
+			NodeName:      node,
+			RestartPolicy: corev1.RestartPolicyNever,
+			Tolerations:   []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+			Containers: []corev1.Container{{
+				Name:                     nodeCollectorContainer,
+				Image:                    image,
+				Command:                  append([]string{"sh", "-c", nodeCollectorScript, "collector"}, pcis...),
+				TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+				VolumeMounts:             []corev1.VolumeMount{{Name: "sys", MountPath: "/host/sys", ReadOnly: true}},
+			}},
+			Volumes: []corev1.Volume{{
+				Name:         "sys",
+				VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/sys"}},
+			}},
+		},
+	}
+}
+
+// parseNodeAttributes parses the collector output
+func parseNodeAttributes(message string) *nodeAttributes {
+	attributes := &nodeAttributes{PFs: map[string]pfAttributes{}}
+	for _, line := range strings.Split(strings.TrimSpace(message), "\n") {
+		fields := strings.Split(strings.TrimSpace(line), "|")
+		switch {
+		case fields[0] == "pf" && len(fields) == 11:
+			attributes.PFs[fields[1]] = pfAttributes{
+				DeviceID:     strings.TrimPrefix(fields[2], "0x"),
+				SriovEnabled: fields[4] != "-" && atoiOrZero(fields[3]) > 0,
+				TotalVfs:     atoiOrZero(fields[3]),
+				NumaNode:     parseNumaNode(fields[5]),
+				PciLinkSpeed: fields[6],
+				PciLinkWidth: atoiOrZero(fields[7]),
+				Mtu:          atoiOrZero(fields[8]),
+				LinkSpeed:    atoiOrZero(fields[9]),
+				Path:         fields[10],
+			}
+		case fields[0] == "gpu" && len(fields) == 4:
+			attributes.GPUs = append(attributes.GPUs, gpuAttributes{
+				PciAddress: fields[1],
+				NumaNode:   parseNumaNode(fields[2]),
+				Path:       fields[3],
+			})
+		}
+	}
+	return attributes
+}
+
+// parseNumaNode returns nil if the NUMA node is unknown (-1 or missing)
+func parseNumaNode(s string) *int {
+	numa, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || numa < 0 {
+		return nil
+	}
+	return &numa
+}
+
+// atoiOrZero parses a positive integer, returning 0 for empty, invalid or negative (unknown) values
+func atoiOrZero(s string) int {
+	v, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || v < 0 {
+		return 0
+	}
+	return v
+}
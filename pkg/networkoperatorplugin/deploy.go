@@ -25,6 +25,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
 	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -36,10 +38,11 @@ import (
 // Apply reads Kubernetes manifests from dirPath and applies them to the cluster.
 // If a NicClusterPolicy is present, it is applied first and the function waits
 // for it to become ready before applying the remaining manifests.
-func (p *NetworkOperatorPlugin) DeployProfile(ctx context.Context, profile *profiles.Profile, kubeClient client.Client, manifestsDir string) error {
+func (p *NetworkOperatorPlugin) DeployProfile(ctx context.Context, profile *profiles.Profile, config *config.LaunchKubernetesConfig, kubeClient client.Client, manifestsDir string) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	timeouts := config.Timeouts
 
 	// List files in directory (non-recursive) and sort
 	entries, err := os.ReadDir(manifestsDir)
@@ -99,12 +102,12 @@ func (p *NetworkOperatorPlugin) DeployProfile(ctx context.Context, profile *prof
 				obj.SetGroupVersionKind(gv.WithKind(kind))
 			}
 		}
-		if err := applyUnstructured(ctx, kubeClient, obj); err != nil {
+		if err := applyUnstructured(ctx, kubeClient, obj, config.ForceConflicts()); err != nil {
 			return err
 		}
 
 		log.Log.Info("Waiting for NicClusterPolicy to be ready")
-		if err := WaitNicClusterPolicyReady(ctx, kubeClient, obj.GetName()); err != nil {
+		if err := WaitNicClusterPolicyReady(ctx, kubeClient, obj.GetName(), config.NetworkOperator.Namespace, timeouts); err != nil {
 			return err
 		}
 	}
@@ -126,14 +129,19 @@ func (p *NetworkOperatorPlugin) DeployProfile(ctx context.Context, profile *prof
 		}
 		log.Log.Info("Applying object", "kind", obj.GetKind(), "name", obj.GetName(), "version", obj.GetAPIVersion())
 
-		// Apply with retry for Pod kind
-		applyErr := applyUnstructured(ctx, kubeClient, obj)
+		// Apply with exponential backoff retry for Pod kind
+		applyErr := applyUnstructured(ctx, kubeClient, obj, config.ForceConflicts())
 		if applyErr != nil && strings.EqualFold(obj.GetKind(), "Pod") {
-			const maxAttempts = 3
-			for attempt := 2; attempt <= maxAttempts && applyErr != nil; attempt++ {
-				log.Log.Info("Pod apply failed, retrying", "name", obj.GetName(), "attempt", attempt, "delay", "30s", "error", applyErr.Error())
-				time.Sleep(30 * time.Second)
-				applyErr = applyUnstructured(ctx, kubeClient, obj)
+			nextDelay := timeouts.PollBackoff().DelayFunc()
+			for attempt := 2; attempt <= timeouts.ApplyRetries && applyErr != nil; attempt++ {
+				delay := nextDelay()
+				log.Log.Info("Pod apply failed, retrying", "name", obj.GetName(), "attempt", attempt, "delay", delay.String(), "error", applyErr.Error())
+				select {
+				case <-ctx.Done():
+					return fmt.Errorf("interrupted while applying pod %q: %w", obj.GetName(), ctx.Err())
+				case <-time.After(delay):
+				}
+				applyErr = applyUnstructured(ctx, kubeClient, obj, config.ForceConflicts())
 			}
 		}
 		if applyErr != nil {
@@ -156,9 +164,9 @@ func containsNicClusterPolicyKind(b []byte) bool {
 	return mo.Kind == "NicClusterPolicy"
 }
 
-func applyUnstructured(ctx context.Context, c client.Client, obj *unstructured.Unstructured) error {
+func applyUnstructured(ctx context.Context, c client.Client, obj *unstructured.Unstructured, forceConflicts bool) error {
 	// kubectl-style server-side apply
-	return c.Patch(ctx, obj, client.Apply, client.FieldOwner("l8k"), client.ForceOwnership)
+	return kubeclient.Apply(ctx, c, obj, forceConflicts)
 }
 
 // splitYAMLDocuments splits a YAML stream by lines that start with '---' (doc separators)
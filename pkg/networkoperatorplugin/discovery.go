@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -32,8 +33,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+// cleanupTimeout bounds the removal of discovery objects once the main context is done
+const cleanupTimeout = 1 * time.Minute
+
 func (p *NetworkOperatorPlugin) DiscoverClusterConfig(ctx context.Context, c client.Client, defaultConfig *config.LaunchKubernetesConfig) error {
-	// Ensure a NicClusterPolicy exists (error if any already exists, else create one)
+	// Ensure a NicClusterPolicy deploying the nic-configuration-operator exists (create one, or reuse an existing one if allowed)
 	policy := &netop.NicClusterPolicy{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "nic-cluster-policy",
@@ -57,18 +61,30 @@ func (p *NetworkOperatorPlugin) DiscoverClusterConfig(ctx context.Context, c cli
 
 	log.Log.Info("Deploying a thin NicClusterPolicy for cluster config discovery")
 
-	if err := EnsureNicClusterPolicy(ctx, c, policy); err != nil {
-		return err
+	timeouts := defaultConfig.Timeouts
+
+	existingPolicy := defaultConfig.NetworkOperator.ExistingNicClusterPolicy
+	if existingPolicy == "" {
+		existingPolicy = ExistingPolicyRefuse
 	}
 
-	// Always attempt cleanup of the NicClusterPolicy at the end of discovery
-	defer func() {
-		if err := DeleteNicClusterPolicy(ctx, c, "nic-cluster-policy"); err != nil {
-			log.Log.Error(err, "failed to delete NicClusterPolicy after discovery")
-		} else {
-			log.Log.Info("NicClusterPolicy deleted after discovery")
-		}
-	}()
+	tracked, err := EnsureNicClusterPolicy(ctx, c, policy, existingPolicy, defaultConfig.NetworkOperator.Namespace, timeouts)
+
+	// Revert only the changes discovery made, even if ctx was cancelled by a signal or a timeout
+	if tracked != nil {
+		defer func() {
+			cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cleanupTimeout)
+			defer cancel()
+			if err := tracked.Revert(cleanupCtx, c); err != nil {
+				log.Log.Error(err, "failed to revert NicClusterPolicy after discovery", "name", tracked.Name)
+			} else {
+				log.Log.Info("NicClusterPolicy reverted after discovery", "name", tracked.Name)
+			}
+		}()
+	}
+	if err != nil {
+		return err
+	}
 
 	// After creation, list pods in the target namespace and ensure all pods
 	// from the nic-configuration-daemon DaemonSet are Ready
@@ -83,7 +99,7 @@ func (p *NetworkOperatorPlugin) DiscoverClusterConfig(ctx context.Context, c cli
 	}
 	if len(devices.Items) == 0 {
 		log.Log.Info("No NicDevice resources found yet; waiting for discovery", "namespace", defaultConfig.NetworkOperator.Namespace)
-		if err := waitNicDevicesDiscovered(ctx, c, defaultConfig.NetworkOperator.Namespace); err != nil {
+		if err := waitNicDevicesDiscovered(ctx, c, defaultConfig.NetworkOperator.Namespace, timeouts); err != nil {
 			return err
 		}
 		// re-list after wait
@@ -93,9 +109,27 @@ func (p *NetworkOperatorPlugin) DiscoverClusterConfig(ctx context.Context, c cli
 		log.Log.Info("NicDevice resources discovered", "count", len(devices.Items))
 	}
 
-	buildClusterConfigFromNicDevices(devices.Items, defaultConfig.ClusterConfig)
+	// Read the PF attributes not exposed by NicDevice (SR-IOV limits, link, NUMA) and the GPU topology from sysfs on every node
+	var attributes map[string]*nodeAttributes
+	if image := defaultConfig.NetworkOperator.NodeCollectorImage; image != "" {
+		pciByNode := map[string][]string{}
+		for _, d := range devices.Items {
+			for _, p := range d.Status.Ports {
+				if d.Status.Node != "" && p.PCI != "" {
+					pciByNode[d.Status.Node] = append(pciByNode[d.Status.Node], p.PCI)
+				}
+			}
+		}
+		log.Log.Info("Collecting device attributes from nodes", "nodes", len(pciByNode))
+		attributes = collectNodeAttributes(ctx, c, defaultConfig.NetworkOperator.Namespace, image, pciByNode, timeouts)
+	}
 
-	return nil
+	buildClusterConfigFromNicDevices(devices.Items, attributes, defaultConfig.ClusterConfig)
+	discoverGPUTopology(ctx, c, attributes, defaultConfig.ClusterConfig)
+	discoverClusterCIDRs(ctx, c, defaultConfig.ClusterConfig)
+
+	// Record the subnet plan in the saved config, so that later runs use the same subnets
+	return carveSubnets(defaultConfig)
 }
 
 // checkDaemonSetPodsReady verifies that all pods owned by the given DaemonSet
@@ -139,43 +173,26 @@ func isPodReady(pod *corev1.Pod) bool {
 }
 
 // waitNicDevicesDiscovered polls until one or more NicDevice objects exist in the given namespace.
-func waitNicDevicesDiscovered(parentCtx context.Context, c client.Client, namespace string) error {
-	// Use a bounded timeout if none supplied
-	ctx := parentCtx
-	if _, hasDeadline := parentCtx.Deadline(); !hasDeadline {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(parentCtx, 5*time.Minute)
-		defer cancel()
-	}
-
-	ticker := time.NewTicker(5 * time.Second)
-	defer ticker.Stop()
-
-	for {
-		list := &nicop.NicDeviceList{}
-		if err := c.List(ctx, list, client.InNamespace(namespace)); err == nil {
-			if len(list.Items) > 0 {
-				return nil
+func waitNicDevicesDiscovered(ctx context.Context, c client.Client, namespace string, timeouts *config.TimeoutsConfig) error {
+	return waitFor(ctx, timeouts.NicDevicesDiscovered, timeouts, fmt.Sprintf("NicDevice resources in namespace %q", namespace),
+		func(ctx context.Context) (bool, error) {
+			list := &nicop.NicDeviceList{}
+			if err := c.List(ctx, list, client.InNamespace(namespace)); err != nil {
+				return false, nil
 			}
-		}
-
-		select {
-		case <-ctx.Done():
-			return fmt.Errorf("timeout waiting for NicDevice resources in namespace %q", namespace)
-		case <-ticker.C:
-			// continue polling
-		}
-	}
+			return len(list.Items) > 0, nil
+		})
 }
 
-// buildClusterConfigFromNicDevices constructs ClusterConfig.NvidiaNICs based on NicDevice statuses.
-func buildClusterConfigFromNicDevices(devices []nicop.NicDevice, cluster *config.ClusterConfig) {
+// buildClusterConfigFromNicDevices constructs ClusterConfig.NvidiaNICs based on NicDevice statuses
+// and the PF attributes collected from the nodes, indexed by node name and PCI address.
+func buildClusterConfigFromNicDevices(devices []nicop.NicDevice, attributes map[string]*nodeAttributes, cluster *config.ClusterConfig) {
 	cluster.Capabilities.Nodes.Rdma = false
 	cluster.Capabilities.Nodes.Sriov = false
 	cluster.Capabilities.Nodes.Ib = true // TODO fix
 
 	cluster.PFs = []config.PFConfig{}
-	pfs := map[config.PFConfig]interface{}{}
+	pfs := map[pfKey]*config.PFConfig{}
 	workerNodes := map[string]interface{}{}
 
 	for _, d := range devices {
@@ -183,16 +200,39 @@ func buildClusterConfigFromNicDevices(devices []nicop.NicDevice, cluster *config
 			if p.RdmaInterface != "" {
 				cluster.Capabilities.Nodes.Rdma = true
 			}
-			if p.PCI != "" {
-				cluster.Capabilities.Nodes.Sriov = true
-			}
 
-			pfs[config.PFConfig{
+			pf := config.PFConfig{
 				RdmaDevice:       p.RdmaInterface,
 				PciAddress:       p.PCI,
 				NetworkInterface: p.NetworkInterface,
 				Traffic:          "east-west", // TODO fix
-			}] = struct{}{}
+				DeviceType:       d.Status.Type,
+				PartNumber:       d.Status.PartNumber,
+				PSID:             d.Status.PSID,
+				FirmwareVersion:  d.Status.FirmwareVersion,
+			}
+			if attrs, ok := attributes[d.Status.Node].pf(p.PCI); ok {
+				sriovEnabled := attrs.SriovEnabled
+				pf.DeviceID = attrs.DeviceID
+				pf.SriovEnabled = &sriovEnabled
+				pf.TotalVfs = attrs.TotalVfs
+				pf.NumaNode = attrs.NumaNode
+				pf.PciLinkSpeed = attrs.PciLinkSpeed
+				pf.PciLinkWidth = attrs.PciLinkWidth
+				pf.Mtu = attrs.Mtu
+				pf.LinkSpeed = attrs.LinkSpeed
+			}
+
+			if p.PCI != "" && (pf.SriovEnabled == nil || *pf.SriovEnabled) {
+				cluster.Capabilities.Nodes.Sriov = true
+			}
+
+			key := pfKey{pciAddress: pf.PciAddress, rdmaDevice: pf.RdmaDevice, networkInterface: pf.NetworkInterface}
+			if existing, ok := pfs[key]; ok {
+				mergePFConfig(existing, pf)
+			} else {
+				pfs[key] = &pf
+			}
 		}
 
 		workerNodes[d.Status.Node] = struct{}{}
@@ -206,11 +246,70 @@ func buildClusterConfigFromNicDevices(devices []nicop.NicDevice, cluster *config
 
 	slices.Sort(cluster.WorkerNodes)
 
-	for pf := range pfs {
-		cluster.PFs = append(cluster.PFs, pf)
+	for _, pf := range pfs {
+		cluster.PFs = append(cluster.PFs, *pf)
 	}
 
 	slices.SortFunc(cluster.PFs, func(a, b config.PFConfig) int {
 		return strings.Compare(a.PciAddress, b.PciAddress)
 	})
 }
+
+// pfKey identifies the same PF across nodes
+type pfKey struct {
+	pciAddress       string
+	rdmaDevice       string
+	networkInterface string
+}
+
+// mergePFConfig merges the attributes of the same PF seen on another node into pf,
+// keeping the most restrictive values so that generated policies fit every node
+func mergePFConfig(pf *config.PFConfig, other config.PFConfig) {
+	if pf.FirmwareVersion != other.FirmwareVersion || pf.PSID != other.PSID {
+		log.Log.Info("PF differs between nodes, keeping the first one seen", "pciAddress", pf.PciAddress,
+			"firmwareVersion", []string{pf.FirmwareVersion, other.FirmwareVersion}, "psid", []string{pf.PSID, other.PSID})
+	}
+
+	if other.SriovEnabled != nil {
+		enabled := *other.SriovEnabled && (pf.SriovEnabled == nil || *pf.SriovEnabled)
+		pf.SriovEnabled = &enabled
+	}
+	if pf.NumaNode == nil {
+		pf.NumaNode = other.NumaNode
+	}
+	if pf.DeviceID == "" {
+		pf.DeviceID = other.DeviceID
+	}
+	pf.PciLinkSpeed = minLinkSpeed(pf.PciLinkSpeed, other.PciLinkSpeed)
+	pf.TotalVfs = minKnown(pf.TotalVfs, other.TotalVfs)
+	pf.PciLinkWidth = minKnown(pf.PciLinkWidth, other.PciLinkWidth)
+	pf.Mtu = minKnown(pf.Mtu, other.Mtu)
+	pf.LinkSpeed = minKnown(pf.LinkSpeed, other.LinkSpeed)
+}
+
+// minLinkSpeed returns the slower of the PCIe link speeds a and b, e.g. "8.0 GT/s PCIe", ignoring unknown values
+func minLinkSpeed(a, b string) string {
+	speedA, speedB := parseLinkSpeed(a), parseLinkSpeed(b)
+	if speedA == 0 || (speedB != 0 && speedB < speedA) {
+		return b
+	}
+	return a
+}
+
+// parseLinkSpeed returns the transfer rate in GT/s of a sysfs PCIe link speed, 0 if unknown
+func parseLinkSpeed(s string) float64 {
+	rate, _, _ := strings.Cut(strings.TrimSpace(s), " ")
+	speed, err := strconv.ParseFloat(rate, 64)
+	if err != nil || speed < 0 {
+		return 0
+	}
+	return speed
+}
+
+// minKnown returns the smaller of a and b, ignoring unknown (zero) values
+func minKnown(a, b int) int {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package networkoperatorplugin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestMergePFConfigKeepsMostRestrictiveValues(t *testing.T) {
+	enabled, disabled := true, false
+	pf := config.PFConfig{SriovEnabled: &enabled, TotalVfs: 127, PciLinkSpeed: "16.0 GT/s PCIe", PciLinkWidth: 16, Mtu: 9000, LinkSpeed: 200000}
+	mergePFConfig(&pf, config.PFConfig{SriovEnabled: &disabled, TotalVfs: 64, PciLinkSpeed: "8.0 GT/s PCIe", PciLinkWidth: 8, Mtu: 1500, LinkSpeed: 100000})
+
+	if *pf.SriovEnabled || pf.TotalVfs != 64 || pf.PciLinkSpeed != "8.0 GT/s PCIe" || pf.PciLinkWidth != 8 || pf.Mtu != 1500 || pf.LinkSpeed != 100000 {
+		t.Errorf("unexpected merged PF: %+v", pf)
+	}
+}
+
+func TestMinLinkSpeed(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want string
+	}{
+		{a: "16.0 GT/s PCIe", b: "8.0 GT/s PCIe", want: "8.0 GT/s PCIe"},
+		{a: "8.0 GT/s PCIe", b: "16.0 GT/s PCIe", want: "8.0 GT/s PCIe"},
+		{a: "", b: "16.0 GT/s PCIe", want: "16.0 GT/s PCIe"},
+		{a: "16.0 GT/s PCIe", b: "Unknown", want: "16.0 GT/s PCIe"},
+		{a: "Unknown", b: "", want: ""},
+	}
+	for _, tt := range tests {
+		if got := minLinkSpeed(tt.a, tt.b); got != tt.want {
+			t.Errorf("minLinkSpeed(%q, %q) = %q, want %q", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestParseNodeAttributesSriovEnabled(t *testing.T) {
+	attributes := parseNodeAttributes(`pf|0000:03:00.0|0x101d|127|0|0|16.0 GT/s PCIe|16|1500|100000|pci0000:00/0000:03:00.0
+pf|0000:03:00.1|0x101d|127|8|1|16.0 GT/s PCIe|16|1500|100000|pci0000:00/0000:03:00.1
+pf|0000:81:00.0|0x101d||-|-1|8.0 GT/s PCIe|8|1500|100000|pci0000:80/0000:81:00.0
+pf|0000:82:00.0|0x101d|0|0|0|8.0 GT/s PCIe|8|1500|100000|pci0000:80/0000:82:00.0`)
+
+	tests := []struct {
+		pci  string
+		want bool
+	}{
+		{pci: "0000:03:00.0", want: true},
+		{pci: "0000:03:00.1", want: true},
+		{pci: "0000:81:00.0", want: false}, // no SR-IOV capability
+		{pci: "0000:82:00.0", want: false}, // no VFs in firmware
+	}
+	for _, tt := range tests {
+		pf, ok := attributes.pf(tt.pci)
+		if !ok {
+			t.Fatalf("PF %s not parsed", tt.pci)
+		}
+		if pf.SriovEnabled != tt.want {
+			t.Errorf("PF %s: SriovEnabled = %v, want %v", tt.pci, pf.SriovEnabled, tt.want)
+		}
+	}
+	if pf, _ := attributes.pf("0000:81:00.0"); pf.NumaNode != nil || pf.PciLinkWidth != 8 {
+		t.Errorf("unexpected attributes of PF 0000:81:00.0: %+v", pf)
+	}
+}
+
+// logReader is a client reading the collector output from fake pod logs
+type logReader struct {
+	client.Client
+	logs string
+}
+
+func (r *logReader) PodLogs(_ context.Context, _, _, _ string) (string, error) {
+	return r.logs, nil
+}
+
+func TestNodeCollectorOutputPrefersPodLogs(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(kubeclient.NewScheme()).Build()
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "l8k-node-collector-x", Namespace: "nvidia-network-operator"},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  nodeCollectorContainer,
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: "truncated"}},
+		}}},
+	}
+
+	if output, ok := nodeCollectorOutput(context.Background(), &logReader{Client: c, logs: "full"}, pod); !ok || output != "full" {
+		t.Errorf("output with pod logs = %q, %v, want the logs", output, ok)
+	}
+	if output, ok := nodeCollectorOutput(context.Background(), c, pod); !ok || output != "truncated" {
+		t.Errorf("output without pod logs = %q, %v, want the termination message", output, ok)
+	}
+}
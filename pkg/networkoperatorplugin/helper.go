@@ -22,72 +22,193 @@ import (
 	"time"
 
 	netop "github.com/Mellanox/network-operator/api/v1alpha1"
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
-// EnsureNicClusterPolicy creates the provided NicClusterPolicy if none exist and waits until it's ready.
-func EnsureNicClusterPolicy(ctx context.Context, c client.Client, policy *netop.NicClusterPolicy) error {
-	// Ensure no NicClusterPolicy exists yet
+const (
+	// ExistingPolicyRefuse makes discovery fail if a NicClusterPolicy already exists
+	ExistingPolicyRefuse = "refuse"
+	// ExistingPolicyReuse makes discovery temporarily add the nic-configuration-operator to an existing NicClusterPolicy
+	ExistingPolicyReuse = "reuse"
+
+	// discoveryLabel marks the objects created by l8k for cluster discovery
+	discoveryLabel = "nvidia.com/l8k-discovery"
+
+	// nicConfigurationOperatorState is the applied state of the nic-configuration-operator in the NicClusterPolicy status
+	nicConfigurationOperatorState = "state-nic-configuration-operator"
+)
+
+// DiscoveryPolicy records the changes made to the cluster's NicClusterPolicy for discovery,
+// so that only those changes are reverted afterwards.
+type DiscoveryPolicy struct {
+	Name    string
+	UID     types.UID
+	Created bool // the policy was created by l8k and is deleted on revert
+	Patched bool // the nic-configuration-operator section was added to an existing policy and is removed on revert
+}
+
+// EnsureNicClusterPolicy makes sure a NicClusterPolicy deploying the nic-configuration-operator from policy exists
+// and waits until the operator components in namespace are ready. If a NicClusterPolicy already exists, it is
+// reused or refused depending on existingPolicy. The returned DiscoveryPolicy is set whenever the cluster was
+// changed, even if an error is returned, and should be reverted by the caller.
+func EnsureNicClusterPolicy(ctx context.Context, c client.Client, policy *netop.NicClusterPolicy, existingPolicy, namespace string, timeouts *config.TimeoutsConfig) (*DiscoveryPolicy, error) {
 	list := &netop.NicClusterPolicyList{}
 	if err := c.List(ctx, list); err != nil {
-		return err
-	}
-	if len(list.Items) > 0 {
-		return fmt.Errorf("NicClusterPolicy already exists (count=%d)", len(list.Items))
+		return nil, err
 	}
 
-	if err := c.Create(ctx, policy); err != nil {
-		return err
+	var tracked *DiscoveryPolicy
+	switch {
+	case len(list.Items) == 0:
+		labels := policy.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[discoveryLabel] = "true"
+		policy.SetLabels(labels)
+
+		if err := c.Create(ctx, policy); err != nil {
+			return nil, err
+		}
+		tracked = &DiscoveryPolicy{Name: policy.Name, UID: policy.UID, Created: true}
+	case existingPolicy != ExistingPolicyReuse:
+		return nil, fmt.Errorf("NicClusterPolicy %q already exists, use --existing-nic-cluster-policy=%s to run discovery with it", list.Items[0].Name, ExistingPolicyReuse)
+	case len(list.Items) > 1:
+		return nil, fmt.Errorf("multiple NicClusterPolicies exist (count=%d), cannot select one to reuse", len(list.Items))
+	default:
+		existing := &list.Items[0]
+		if existing.Spec.NicConfigurationOperator != nil {
+			log.Log.Info("Existing NicClusterPolicy already deploys the nic-configuration-operator, reusing it as is", "name", existing.Name)
+			return nil, WaitNicClusterPolicyReady(ctx, c, existing.Name, namespace, timeouts)
+		}
+
+		log.Log.Info("Temporarily adding the nic-configuration-operator to the existing NicClusterPolicy", "name", existing.Name)
+		patch := client.MergeFromWithOptions(existing.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		existing.Spec.NicConfigurationOperator = policy.Spec.NicConfigurationOperator
+		if err := c.Patch(ctx, existing, patch); err != nil {
+			return nil, fmt.Errorf("failed to patch NicClusterPolicy %q: %w", existing.Name, err)
+		}
+		tracked = &DiscoveryPolicy{Name: existing.Name, UID: existing.UID, Patched: true}
+
+		// The status of the existing policy may still be the ready state from before the patch
+		return tracked, waitNicClusterPolicyReady(ctx, c, tracked.Name, namespace, timeouts, nicConfigurationOperatorState)
 	}
 
-	return WaitNicClusterPolicyReady(ctx, c, policy.Name)
+	return tracked, WaitNicClusterPolicyReady(ctx, c, tracked.Name, namespace, timeouts)
 }
 
-// WaitNicClusterPolicyReady polls NicClusterPolicy until Status.State is ready or error, with a timeout.
-func WaitNicClusterPolicyReady(parentCtx context.Context, c client.Client, name string) error {
-	// Use a bounded timeout if none supplied
-	ctx := parentCtx
-	if _, hasDeadline := parentCtx.Deadline(); !hasDeadline {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(parentCtx, 15*time.Minute)
-		defer cancel()
+// Revert undoes the discovery changes: it deletes the policy if l8k created it, or removes the
+// nic-configuration-operator section it added to an existing policy. Policies that were replaced
+// in the meantime (different UID) are left untouched.
+func (d *DiscoveryPolicy) Revert(ctx context.Context, c client.Client) error {
+	switch {
+	case d.Created:
+		return DeleteNicClusterPolicy(ctx, c, d.Name, d.UID)
+	case d.Patched:
+		existing := &netop.NicClusterPolicy{}
+		if err := c.Get(ctx, client.ObjectKey{Name: d.Name}, existing); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		if existing.UID != d.UID {
+			log.Log.Info("NicClusterPolicy was replaced during discovery, not reverting it", "name", d.Name)
+			return nil
+		}
+
+		patch := client.MergeFromWithOptions(existing.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		existing.Spec.NicConfigurationOperator = nil
+		return c.Patch(ctx, existing, patch)
 	}
+	return nil
+}
+
+// WaitNicClusterPolicyReady polls NicClusterPolicy until Status.State is ready or error,
+// backing off exponentially between polls, until timeouts.NicClusterPolicyReady elapses.
+// While waiting, the progress of the components deployed in namespace is reported.
+func WaitNicClusterPolicyReady(ctx context.Context, c client.Client, name, namespace string, timeouts *config.TimeoutsConfig) error {
+	return waitNicClusterPolicyReady(ctx, c, name, namespace, timeouts, "")
+}
+
+// waitNicClusterPolicyReady is WaitNicClusterPolicyReady for a policy that was just changed. Unless requiredState is empty,
+// the ready state is only accepted once the applied state requiredState is ready or the policy state left ready, since the
+// NicClusterPolicy status has no observed generation telling whether it reflects the change.
+func waitNicClusterPolicyReady(ctx context.Context, c client.Client, name, namespace string, timeouts *config.TimeoutsConfig, requiredState string) error {
+	progress := newProgressReporter(c, namespace)
+	defer progress.Done()
+
+	leftReady := false
 
-	ticker := time.NewTicker(3 * time.Second)
-	defer ticker.Stop()
+	return waitFor(ctx, timeouts.NicClusterPolicyReady, timeouts, fmt.Sprintf("NicClusterPolicy %q to become ready", name),
+		func(ctx context.Context) (bool, error) {
+			// Try to get by name (cluster-scoped)
+			policy := &netop.NicClusterPolicy{}
+			if err := c.Get(ctx, client.ObjectKey{Name: name}, policy); err != nil {
+				return false, nil
+			}
+			progress.Update(ctx, policy)
 
-	for {
-		// Try to get by name (cluster-scoped)
-		policy := &netop.NicClusterPolicy{}
-		if err := c.Get(ctx, client.ObjectKey{Name: name}, policy); err == nil {
 			switch policy.Status.State {
 			case netop.StateReady:
+				if requiredState != "" && !leftReady && !appliedStateReady(policy, requiredState) {
+					log.Log.V(1).Info("NicClusterPolicy status predates the change, waiting", "name", name, "state", requiredState)
+					return false, nil
+				}
 				log.Log.Info("NicClusterPolicy is ready")
-				return nil
+				return true, nil
 			case netop.StateError:
-				return fmt.Errorf("NicClusterPolicy in error state: %s", policy.Status.Reason)
+				return false, fmt.Errorf("NicClusterPolicy in error state: %s", policy.Status.Reason)
 			}
+			leftReady = true
+			return false, nil
+		})
+}
+
+// appliedStateReady returns whether the applied state with the given name is ready in the status of policy
+func appliedStateReady(policy *netop.NicClusterPolicy, name string) bool {
+	for _, state := range policy.Status.AppliedStates {
+		if state.Name == name {
+			return state.State == netop.StateReady
 		}
+	}
+	return false
+}
+
+// waitFor polls condition with the exponential backoff from timeouts until it returns true or an error,
+// the timeout elapses or the parent context is cancelled. what describes the awaited state in errors.
+func waitFor(ctx context.Context, timeout time.Duration, timeouts *config.TimeoutsConfig, what string, condition wait.ConditionWithContextFunc) error {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-		select {
-		case <-ctx.Done():
-			return fmt.Errorf("timeout waiting for NicClusterPolicy %q to become ready", name)
-		case <-ticker.C:
-			// continue
+	err := timeouts.PollBackoff().DelayFunc().Until(waitCtx, true, false, condition)
+	if err != nil && wait.Interrupted(err) {
+		if ctx.Err() != nil {
+			return fmt.Errorf("interrupted while waiting for %s: %w", what, ctx.Err())
 		}
+		return fmt.Errorf("timeout waiting for %s after %s", what, timeout)
 	}
+	return err
 }
 
-// DeleteNicClusterPolicy deletes the NicClusterPolicy by name, ignoring NotFound errors.
-func DeleteNicClusterPolicy(ctx context.Context, c client.Client, name string) error {
+// DeleteNicClusterPolicy deletes the NicClusterPolicy by name if its UID matches, ignoring NotFound errors.
+// A policy with the same name but a different UID was not created by the caller and is kept.
+func DeleteNicClusterPolicy(ctx context.Context, c client.Client, name string, uid types.UID) error {
 	obj := &netop.NicClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: name}}
-	if err := c.Delete(ctx, obj); err != nil {
+	if err := c.Delete(ctx, obj, client.Preconditions{UID: &uid}); err != nil {
 		if apierrors.IsNotFound(err) {
 			return nil
 		}
+		if apierrors.IsConflict(err) {
+			log.Log.Info("NicClusterPolicy was replaced, not deleting it", "name", name)
+			return nil
+		}
 		return err
 	}
 	return nil
@@ -0,0 +1,151 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package networkoperatorplugin
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	netop "github.com/Mellanox/network-operator/api/v1alpha1"
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEnsureNicClusterPolicyReuseWaitsForPatchedStatus(t *testing.T) {
+	tests := []struct {
+		name          string
+		appliedStates []netop.AppliedState
+		wantErr       string
+	}{
+		{
+			name:    "ready status from before the patch",
+			wantErr: "timeout",
+		},
+		{
+			name:          "nic-configuration-operator not ready yet",
+			appliedStates: []netop.AppliedState{{Name: nicConfigurationOperatorState, State: netop.StateNotReady}},
+			wantErr:       "timeout",
+		},
+		{
+			name:          "nic-configuration-operator ready",
+			appliedStates: []netop.AppliedState{{Name: nicConfigurationOperatorState, State: netop.StateReady}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing := &netop.NicClusterPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "nic-cluster-policy"},
+				Status:     netop.NicClusterPolicyStatus{State: netop.StateReady, AppliedStates: tt.appliedStates},
+			}
+			c := fake.NewClientBuilder().WithScheme(kubeclient.NewScheme()).WithObjects(existing).Build()
+			policy := &netop.NicClusterPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "nic-cluster-policy"},
+				Spec:       netop.NicClusterPolicySpec{NicConfigurationOperator: &netop.NicConfigurationOperatorSpec{}},
+			}
+			timeouts := &config.TimeoutsConfig{NicClusterPolicyReady: 200 * time.Millisecond, PollInterval: 20 * time.Millisecond}
+			timeouts.SetDefaults()
+
+			tracked, err := EnsureNicClusterPolicy(context.Background(), c, policy, ExistingPolicyReuse, "nvidia-network-operator", timeouts)
+			if tracked == nil || !tracked.Patched {
+				t.Fatalf("expected the existing policy to be patched, got %+v", tracked)
+			}
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+
+			patched := &netop.NicClusterPolicy{}
+			if err := c.Get(context.Background(), client.ObjectKey{Name: existing.Name}, patched); err != nil {
+				t.Fatal(err)
+			}
+			if patched.Spec.NicConfigurationOperator == nil {
+				t.Errorf("nic-configuration-operator was not added to the existing policy")
+			}
+		})
+	}
+}
+
+func TestWaitFor(t *testing.T) {
+	timeouts := &config.TimeoutsConfig{PollInterval: 10 * time.Millisecond, MaxPollInterval: 20 * time.Millisecond}
+	timeouts.SetDefaults()
+	conditionErr := errors.New("policy is broken")
+
+	tests := []struct {
+		name      string
+		cancel    bool
+		condition func(polls int) (bool, error)
+		wantPolls int
+		wantErr   string
+	}{
+		{
+			name:      "condition met after some polls",
+			condition: func(polls int) (bool, error) { return polls == 3, nil },
+			wantPolls: 3,
+		},
+		{
+			name:      "condition error stops polling",
+			condition: func(polls int) (bool, error) { return false, conditionErr },
+			wantPolls: 1,
+			wantErr:   "policy is broken",
+		},
+		{
+			name:      "timeout",
+			condition: func(polls int) (bool, error) { return false, nil },
+			wantErr:   "timeout waiting for the policy after 100ms",
+		},
+		{
+			name:      "parent context cancelled",
+			cancel:    true,
+			condition: func(polls int) (bool, error) { return false, nil },
+			wantErr:   "interrupted while waiting for the policy: context canceled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancel {
+				cancel()
+			}
+
+			polls := 0
+			err := waitFor(ctx, 100*time.Millisecond, timeouts, "the policy", func(context.Context) (bool, error) {
+				polls++
+				return tt.condition(polls)
+			})
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if tt.wantPolls != 0 && polls != tt.wantPolls {
+				t.Errorf("condition polled %d times, want %d", polls, tt.wantPolls)
+			}
+		})
+	}
+}
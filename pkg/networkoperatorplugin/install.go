@@ -0,0 +1,149 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package networkoperatorplugin
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+	"github.com/nvidia/k8s-launch-kit/pkg/plugin"
+	"helm.sh/helm/v3/pkg/chartutil"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// chartRepository is the NGC repository of the network-operator Helm chart, used unless networkOperator.helm sets another one
+	chartRepository = "https://helm.ngc.nvidia.com/nvidia"
+	// chartName is the name of the network-operator Helm chart, and of its release unless networkOperator.helm sets another one
+	chartName = "network-operator"
+	// nfdName is the name of the Node Feature Discovery chart, part of the names of its DaemonSets, e.g. nfd-node-feature-discovery-worker
+	nfdName = "node-feature-discovery"
+)
+
+// InstallOperator installs or upgrades the network-operator Helm chart, see operatorRelease
+func (p *NetworkOperatorPlugin) InstallOperator(ctx context.Context, requirements *config.Profile, cfg *config.LaunchKubernetesConfig, c client.Client, cluster kubeclient.Options) error {
+	release, err := operatorRelease(ctx, requirements, cfg, c, cluster)
+	if err != nil {
+		return err
+	}
+	return kubeclient.InstallOrUpgrade(ctx, cluster, *release)
+}
+
+// OperatorObjects returns the objects of the network-operator Helm chart rendered with the values InstallOperator installs it with
+func (p *NetworkOperatorPlugin) OperatorObjects(ctx context.Context, requirements *config.Profile, cfg *config.LaunchKubernetesConfig, c client.Client, cluster kubeclient.Options) ([]*unstructured.Unstructured, error) {
+	release, err := operatorRelease(ctx, requirements, cfg, c, cluster)
+	if err != nil {
+		return nil, err
+	}
+	return kubeclient.RenderHelmRelease(cluster, *release)
+}
+
+// operatorRelease returns the release of the network-operator Helm chart at the network-operator version of the config, in its
+// namespace. An existing release of the chart in the namespace is upgraded whatever its name, a network-operator running there
+// without a release of the chart is not taken over. The SR-IOV Network Operator of the chart is only enabled for the sriov
+// deployments, or for any deployment when the requirements are not known, and NFD unless it already runs in another namespace,
+// e.g. deployed by the GPU Operator. The values of networkOperator.helm override the derived ones.
+func operatorRelease(ctx context.Context, requirements *config.Profile, cfg *config.LaunchKubernetesConfig, c client.Client, cluster kubeclient.Options) (*kubeclient.HelmRelease, error) {
+	if cfg.NetworkOperator == nil {
+		return nil, fmt.Errorf("networkOperator section is missing from the config")
+	}
+
+	chart := &config.HelmChartConfig{}
+	if cfg.NetworkOperator.Helm != nil {
+		chart = cfg.NetworkOperator.Helm
+	}
+	release := &kubeclient.HelmRelease{
+		Name:       chart.Release,
+		Namespace:  cfg.NetworkOperator.Namespace,
+		Chart:      chart.Chart,
+		Repository: chart.Repository,
+		Version:    strings.TrimPrefix(cfg.NetworkOperator.Version, "v"),
+		Timeout:    cfg.Timeouts.OperatorInstall,
+	}
+	if release.Chart == "" {
+		release.Chart = chartName
+	}
+	if release.Repository == "" {
+		release.Repository = chartRepository
+	}
+	if release.Name == "" {
+		release.Name = chartName
+	}
+
+	existing, err := kubeclient.FindHelmRelease(cluster, release.Namespace, release.Name, chartName)
+	if err != nil {
+		return nil, err
+	}
+	if existing == "" {
+		deployment, _, err := findOperatorDeployment(ctx, c, release.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		if deployment != nil {
+			return nil, fmt.Errorf("the network-operator already runs in namespace %s as Deployment %s without a Helm release of chart %s, "+
+				"upgrade it the way it was installed instead of using --install-operators", release.Namespace, deployment.Name, chartName)
+		}
+	} else if existing != release.Name {
+		log.Log.Info("Upgrading the existing release of the network-operator chart", "release", existing, "namespace", release.Namespace)
+		release.Name = existing
+	}
+
+	nfd, err := nfdRunsElsewhere(ctx, c, release.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	values, err := chart.ChartValues()
+	if err != nil {
+		return nil, err
+	}
+	release.Values = chartutil.CoalesceTables(values, chartValues(requirements, !nfd))
+	return release, nil
+}
+
+// chartValues returns the values of the network-operator chart derived from the profile requirements and whether NFD is needed
+func chartValues(requirements *config.Profile, nfd bool) map[string]interface{} {
+	sriov := requirements == nil || requirements.Deployment == "" || requirements.Deployment == "sriov"
+	return map[string]interface{}{
+		"nfd": map[string]interface{}{
+			"enabled": nfd,
+		},
+		"sriovNetworkOperator": map[string]interface{}{
+			"enabled": sriov,
+		},
+	}
+}
+
+// nfdRunsElsewhere returns whether an NFD DaemonSet runs outside namespace, a second NFD would label the nodes concurrently
+func nfdRunsElsewhere(ctx context.Context, c client.Client, namespace string) (bool, error) {
+	daemonSets := &appsv1.DaemonSetList{}
+	if err := c.List(ctx, daemonSets); err != nil {
+		return false, fmt.Errorf("failed to list the daemonsets: %w", err)
+	}
+	return slices.ContainsFunc(daemonSets.Items, func(ds appsv1.DaemonSet) bool {
+		nfd := ds.Labels["app.kubernetes.io/name"] == nfdName || strings.Contains(ds.Name, nfdName) || strings.HasPrefix(ds.Name, "nfd-")
+		return nfd && ds.Namespace != namespace
+	}), nil
+}
+
+var _ plugin.Installer = &NetworkOperatorPlugin{}
@@ -0,0 +1,78 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package networkoperatorplugin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNfdRunsElsewhere(t *testing.T) {
+	tests := []struct {
+		name      string
+		daemonSet metav1.ObjectMeta
+		want      bool
+	}{
+		{name: "NFD of the GPU Operator", daemonSet: metav1.ObjectMeta{Name: "gpu-operator-node-feature-discovery-worker", Namespace: "gpu-operator"}, want: true},
+		{name: "NFD deployed alone", daemonSet: metav1.ObjectMeta{Name: "nfd-worker", Namespace: "node-feature-discovery"}, want: true},
+		{name: "NFD by label", daemonSet: metav1.ObjectMeta{Name: "worker", Namespace: "nfd", Labels: map[string]string{"app.kubernetes.io/name": nfdName}}, want: true},
+		{name: "NFD of the network-operator release", daemonSet: metav1.ObjectMeta{Name: "network-operator-node-feature-discovery-worker", Namespace: "nvidia-network-operator"}},
+		{name: "other DaemonSet", daemonSet: metav1.ObjectMeta{Name: "kube-proxy", Namespace: "kube-system"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(kubeclient.NewScheme()).WithObjects(&appsv1.DaemonSet{ObjectMeta: tt.daemonSet}).Build()
+			got, err := nfdRunsElsewhere(context.Background(), c, "nvidia-network-operator")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("nfdRunsElsewhere() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChartValues(t *testing.T) {
+	tests := []struct {
+		name         string
+		requirements *config.Profile
+		nfd          bool
+		wantSriov    bool
+	}{
+		{name: "unknown requirements", nfd: true, wantSriov: true},
+		{name: "sriov", requirements: &config.Profile{Deployment: "sriov"}, wantSriov: true},
+		{name: "host device", requirements: &config.Profile{Deployment: "host-device"}, nfd: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := chartValues(tt.requirements, tt.nfd)
+			if got := values["nfd"].(map[string]interface{})["enabled"]; got != tt.nfd {
+				t.Errorf("nfd.enabled = %v, want %v", got, tt.nfd)
+			}
+			if got := values["sriovNetworkOperator"].(map[string]interface{})["enabled"]; got != tt.wantSriov {
+				t.Errorf("sriovNetworkOperator.enabled = %v, want %v", got, tt.wantSriov)
+			}
+		})
+	}
+}
@@ -0,0 +1,339 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package networkoperatorplugin
+
+import (
+	"fmt"
+	"math/bits"
+	"net/netip"
+	"slices"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+)
+
+// maxPerNodeBlockSize bounds the computed per-node blocks, so that most of a large subnet stays free for nodes added later
+const maxPerNodeBlockSize = 256
+
+// nodeHeadroom is the factor of the worker nodes computed blocks and carved subnets leave room for when the subnet is large
+// enough, so that nodes joining later get a block
+const nodeHeadroom = 2
+
+// planNvIpam validates the nv-ipam subnets used by the profile and fills the per-node block of the pools left unset:
+// the largest block, up to maxPerNodeBlockSize addresses, that gives nodeHeadroom times the worker nodes their own block,
+// or every worker node if the subnet is too small for the headroom.
+// Subnets must exist for every pool, must not overlap each other nor the node, pod and service CIDRs of the cluster,
+// and must leave every node enough addresses for the pods of the profile.
+// Subnets missing from the config are carved from the supernet first.
+// The plan is made on a copy of cfg, returned for rendering, so that cfg can be planned again, e.g. by the preflight checks.
+func planNvIpam(profile *profiles.Profile, cfg *config.LaunchKubernetesConfig) (*config.LaunchKubernetesConfig, error) {
+	if cfg.ClusterConfig == nil {
+		return cfg, nil
+	}
+	if cfg.NvIpam == nil {
+		return nil, fmt.Errorf("nvIpam section is missing from the config")
+	}
+	planned := *cfg
+	nvIpam := *cfg.NvIpam
+	nvIpam.Subnets = slices.Clone(cfg.NvIpam.Subnets)
+	planned.NvIpam = &nvIpam
+	if err := planSubnets(profile, &planned); err != nil {
+		return nil, err
+	}
+	return &planned, nil
+}
+
+// planSubnets carves the missing subnets of cfg, then validates and completes the subnets used by the profile in place
+func planSubnets(profile *profiles.Profile, cfg *config.LaunchKubernetesConfig) error {
+	if err := carveSubnets(cfg); err != nil {
+		return err
+	}
+
+	spectrumX := profile.ProfileRequirements.SpectrumX.Requires("true")
+	switch cfg.NvIpam.PoolType {
+	case "":
+		cfg.NvIpam.PoolType = config.PoolTypeIPPool
+		if spectrumX {
+			cfg.NvIpam.PoolType = config.PoolTypeCIDRPool
+		}
+	case config.PoolTypeIPPool:
+		if spectrumX {
+			return fmt.Errorf("Spectrum-X profiles allocate /31 point-to-point subnets and need nvIpam.poolType %s", config.PoolTypeCIDRPool)
+		}
+	case config.PoolTypeCIDRPool:
+	default:
+		return fmt.Errorf("invalid nvIpam.poolType %q, must be %s or %s", cfg.NvIpam.PoolType, config.PoolTypeIPPool, config.PoolTypeCIDRPool)
+	}
+
+	indexes, err := poolSubnetIndexes(profile, cfg)
+	if err != nil {
+		return err
+	}
+
+	clusterCIDRs, err := parseClusterCIDRs(cfg.ClusterConfig)
+	if err != nil {
+		return err
+	}
+
+	workers := max(len(cfg.ClusterConfig.WorkerNodes), 1)
+	perNode := addressesPerNode(profile, cfg)
+
+	subnets := []netip.Prefix{}
+	for _, i := range indexes {
+		pool := &cfg.NvIpam.Subnets[i]
+		subnet, err := netip.ParsePrefix(pool.Subnet)
+		if err != nil {
+			return fmt.Errorf("invalid nvIpam subnet %q: %w", pool.Subnet, err)
+		}
+		if subnet != subnet.Masked() {
+			return fmt.Errorf("nvIpam subnet %s has host bits set, use %s", subnet, subnet.Masked())
+		}
+
+		for _, other := range subnets {
+			if subnet.Overlaps(other) {
+				return fmt.Errorf("nvIpam subnets %s and %s overlap, every pool needs its own subnet", other, subnet)
+			}
+		}
+		for _, cidr := range clusterCIDRs {
+			if subnet.Overlaps(cidr.prefix) {
+				return fmt.Errorf("nvIpam subnet %s overlaps the %s CIDR %s of the cluster", subnet, cidr.kind, cidr.prefix)
+			}
+		}
+		subnets = append(subnets, subnet)
+
+		if err := validateExclusions(subnet, pool.Exclusions); err != nil {
+			return err
+		}
+
+		if cfg.NvIpam.PoolType == config.PoolTypeCIDRPool {
+			err = planCIDRPool(subnet, pool, workers, perNode, spectrumX)
+		} else {
+			err = planIPPool(subnet, pool, workers, perNode)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// poolSubnetIndexes returns the indexes of the nvIpam subnets used by the profile: the rail indexes of the east-west PFs
+// for the profiles with a pool per rail, the first subnet otherwise
+func poolSubnetIndexes(profile *profiles.Profile, cfg *config.LaunchKubernetesConfig) ([]int, error) {
+	perRail := (cfg.Profile != nil && cfg.Profile.Multirail) || profile.ProfileRequirements.Ai.Requires("true") || profile.ProfileRequirements.SpectrumX.Requires("true")
+	if !perRail {
+		if len(cfg.NvIpam.Subnets) == 0 {
+			return nil, fmt.Errorf("nvIpam.subnets is empty, at least one subnet is required")
+		}
+		return []int{0}, nil
+	}
+
+	rails := cfg.ClusterConfig.EastWestRails()
+	indexes := []int{}
+	for _, rail := range rails {
+		if rail.Index >= len(cfg.NvIpam.Subnets) {
+			return nil, fmt.Errorf("nvIpam.subnets has no subnet for rail %s of PF %s (%s): a pool is created per rail from the subnet with the index of the rail, %d subnets are configured for %d rails",
+				rail.Name, rail.PF.PciAddress, rail.PF.NetworkInterface, len(cfg.NvIpam.Subnets), len(rails))
+		}
+		indexes = append(indexes, rail.Index)
+	}
+	return indexes, nil
+}
+
+// clusterCIDR is a node, pod or service CIDR of the cluster
+type clusterCIDR struct {
+	kind   string
+	prefix netip.Prefix
+}
+
+// parseClusterCIDRs returns the node, pod and service CIDRs of the cluster config
+func parseClusterCIDRs(cluster *config.ClusterConfig) ([]clusterCIDR, error) {
+	cidrs := []clusterCIDR{}
+	for _, kind := range []struct {
+		name   string
+		values []string
+	}{{"node", cluster.NodeCIDRs}, {"pod", cluster.PodCIDRs}, {"service", cluster.ServiceCIDRs}} {
+		for _, value := range kind.values {
+			prefix, err := netip.ParsePrefix(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s CIDR %q in clusterConfig: %w", kind.name, value, err)
+			}
+			cidrs = append(cidrs, clusterCIDR{kind: kind.name, prefix: prefix})
+		}
+	}
+	return cidrs, nil
+}
+
+// addressesPerNode returns the number of addresses every node needs from a pool of the profile:
+// one per VF for SR-IOV, one per PF for host devices, and at least one otherwise
+func addressesPerNode(profile *profiles.Profile, cfg *config.LaunchKubernetesConfig) int {
+	switch {
+	case profile.ProfileRequirements.Deployment.Requires("sriov") && cfg.Sriov != nil:
+		return max(cfg.Sriov.NumVfs, 1)
+	case profile.ProfileRequirements.Deployment.Requires("host_device"):
+		return max(len(cfg.ClusterConfig.EastWestRailNames()), 1)
+	default:
+		return 1
+	}
+}
+
+// planIPPool sets or validates the number of addresses per node of an IPPool.
+// The network and broadcast addresses are never allocated, the gateway takes an address of one of the blocks.
+func planIPPool(subnet netip.Prefix, pool *config.NvIpamSubnetConfig, workers, perNode int) error {
+	if len(pool.StaticAllocations) > 0 || pool.GatewayIndex != nil || pool.PerNodeNetworkPrefix != 0 {
+		return fmt.Errorf("nvIpam subnet %s: staticAllocations, gatewayIndex and perNodeNetworkPrefix need nvIpam.poolType %s", subnet, config.PoolTypeCIDRPool)
+	}
+
+	if pool.Gateway != "" {
+		gateway, err := netip.ParseAddr(pool.Gateway)
+		if err != nil || !subnet.Contains(gateway) {
+			return fmt.Errorf("gateway %q of nvIpam subnet %s is not an address of the subnet", pool.Gateway, subnet)
+		}
+	}
+	perNode = ipPoolBlockSize(perNode, pool.Gateway != "")
+
+	usable := usableAddresses(subnet)
+	if pool.PerNodeBlockSize == 0 {
+		pool.PerNodeBlockSize = min(usable/(workers*nodeHeadroom), maxPerNodeBlockSize)
+		if pool.PerNodeBlockSize < perNode {
+			pool.PerNodeBlockSize = min(usable/workers, maxPerNodeBlockSize)
+		}
+	} else if pool.PerNodeBlockSize*workers > usable {
+		return fmt.Errorf("nvIpam subnet %s has %d usable addresses, too few for a block of %d per worker node (%d)", subnet, usable, pool.PerNodeBlockSize, workers)
+	}
+	if pool.PerNodeBlockSize < perNode {
+		return fmt.Errorf("nvIpam subnet %s is too small: %d worker nodes need blocks of at least %d addresses, the subnet allows %d",
+			subnet, workers, perNode, pool.PerNodeBlockSize)
+	}
+
+	return nil
+}
+
+// ipPoolBlockSize returns the smallest IPPool block for perNode addresses: the gateway takes an address of one of the blocks,
+// and nv-ipam rejects blocks of a single address
+func ipPoolBlockSize(perNode int, gateway bool) int {
+	if gateway {
+		perNode++
+	}
+	return max(perNode, 2)
+}
+
+// planCIDRPool sets or validates the per-node subnets of a CIDRPool and its static allocations.
+// Spectrum-X pools use /31 point-to-point subnets with the switch port as gateway, regardless of perNode.
+func planCIDRPool(subnet netip.Prefix, pool *config.NvIpamSubnetConfig, workers, perNode int, spectrumX bool) error {
+	if pool.PerNodeBlockSize != 0 {
+		return fmt.Errorf("nvIpam subnet %s: perNodeBlockSize needs nvIpam.poolType %s, use perNodeNetworkPrefix", subnet, config.PoolTypeIPPool)
+	}
+
+	bitLen := subnet.Addr().BitLen()
+	if spectrumX {
+		if !subnet.Addr().Is4() {
+			return fmt.Errorf("nvIpam subnet %s must be an IPv4 subnet, Spectrum-X rails use /31 point-to-point subnets", subnet)
+		}
+		if pool.PerNodeNetworkPrefix != 0 && pool.PerNodeNetworkPrefix != 31 {
+			return fmt.Errorf("nvIpam subnet %s: Spectrum-X rails use /31 point-to-point subnets, perNodeNetworkPrefix must be 31 or unset", subnet)
+		}
+		pool.PerNodeNetworkPrefix = 31
+		if pool.GatewayIndex == nil {
+			gatewayIndex := 0
+			pool.GatewayIndex = &gatewayIndex
+		}
+		perNode = 1
+	}
+
+	if pool.PerNodeNetworkPrefix == 0 {
+		needed := perNode
+		if pool.GatewayIndex != nil {
+			needed++
+		}
+		pool.PerNodeNetworkPrefix = perNodeNetworkPrefix(subnet, workers*nodeHeadroom)
+		if usableAddresses(netip.PrefixFrom(subnet.Addr(), pool.PerNodeNetworkPrefix)) < needed {
+			pool.PerNodeNetworkPrefix = perNodeNetworkPrefix(subnet, workers)
+		}
+	}
+	if pool.PerNodeNetworkPrefix <= subnet.Bits() || pool.PerNodeNetworkPrefix > bitLen {
+		return fmt.Errorf("nvIpam subnet %s: perNodeNetworkPrefix %d must be longer than the prefix of the subnet and at most %d", subnet, pool.PerNodeNetworkPrefix, bitLen)
+	}
+	if pool.PerNodeNetworkPrefix-subnet.Bits() < 31 && 1<<(pool.PerNodeNetworkPrefix-subnet.Bits()) < workers {
+		return fmt.Errorf("nvIpam subnet %s is too small for a /%d subnet per worker node (%d)", subnet, pool.PerNodeNetworkPrefix, workers)
+	}
+
+	nodeAddresses := usableAddresses(netip.PrefixFrom(subnet.Addr(), pool.PerNodeNetworkPrefix))
+	if pool.GatewayIndex != nil {
+		if *pool.GatewayIndex < 0 || *pool.GatewayIndex >= nodeAddresses {
+			return fmt.Errorf("nvIpam subnet %s: gatewayIndex %d is out of the /%d per-node subnets", subnet, *pool.GatewayIndex, pool.PerNodeNetworkPrefix)
+		}
+		if !spectrumX {
+			perNode++
+		}
+	}
+	if nodeAddresses < perNode {
+		return fmt.Errorf("nvIpam subnet %s: /%d per-node subnets have %d usable addresses, at least %d are needed", subnet, pool.PerNodeNetworkPrefix, nodeAddresses, perNode)
+	}
+
+	for _, allocation := range pool.StaticAllocations {
+		prefix, err := netip.ParsePrefix(allocation.Prefix)
+		if err != nil {
+			return fmt.Errorf("invalid static allocation %q of nvIpam subnet %s: %w", allocation.Prefix, subnet, err)
+		}
+		if prefix.Bits() != pool.PerNodeNetworkPrefix || prefix != prefix.Masked() || !subnet.Contains(prefix.Addr()) {
+			return fmt.Errorf("static allocation %s must be a /%d subnet of nvIpam subnet %s", prefix, pool.PerNodeNetworkPrefix, subnet)
+		}
+		if allocation.Gateway != "" {
+			gateway, err := netip.ParseAddr(allocation.Gateway)
+			if err != nil || !prefix.Contains(gateway) {
+				return fmt.Errorf("gateway %q of static allocation %s is not an address of the allocation", allocation.Gateway, prefix)
+			}
+		}
+	}
+
+	return nil
+}
+
+// perNodeNetworkPrefix returns the smallest prefix giving nodes a subnet of subnet each, with at most maxPerNodeBlockSize
+// addresses and at least two subnets
+func perNodeNetworkPrefix(subnet netip.Prefix, nodes int) int {
+	bitLen := subnet.Addr().BitLen()
+	return max(subnet.Bits()+max(bits.Len(uint(nodes-1)), 1), bitLen-bits.Len(uint(maxPerNodeBlockSize-1)))
+}
+
+// validateExclusions checks that the excluded ranges are ordered address ranges of the subnet
+func validateExclusions(subnet netip.Prefix, exclusions []config.NvIpamExclusionConfig) error {
+	for _, exclusion := range exclusions {
+		start, errStart := netip.ParseAddr(exclusion.StartIP)
+		end, errEnd := netip.ParseAddr(exclusion.EndIP)
+		if errStart != nil || errEnd != nil || !subnet.Contains(start) || !subnet.Contains(end) || end.Less(start) {
+			return fmt.Errorf("exclusion %s-%s of nvIpam subnet %s must be an address range of the subnet", exclusion.StartIP, exclusion.EndIP, subnet)
+		}
+	}
+	return nil
+}
+
+// usableAddresses returns the number of allocatable addresses of a subnet, capped to fit an int.
+// IPv4 network and broadcast addresses are not allocatable, except in /31 and /32 subnets.
+func usableAddresses(subnet netip.Prefix) int {
+	hostBits := subnet.Addr().BitLen() - subnet.Bits()
+	if hostBits > 30 {
+		return 1 << 30
+	}
+	addresses := 1 << hostBits
+	if subnet.Addr().Is4() && hostBits > 1 {
+		addresses -= 2
+	}
+	return addresses
+}
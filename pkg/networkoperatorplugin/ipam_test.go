@@ -0,0 +1,150 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package networkoperatorplugin
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+)
+
+func TestUsableAddresses(t *testing.T) {
+	tests := []struct {
+		subnet string
+		want   int
+	}{
+		{subnet: "10.0.0.0/24", want: 254},
+		{subnet: "10.0.0.0/30", want: 2},
+		{subnet: "10.0.0.0/31", want: 2},
+		{subnet: "10.0.0.0/32", want: 1},
+		{subnet: "fd00::/120", want: 256},
+		{subnet: "fd00::/64", want: 1 << 30},
+	}
+	for _, tt := range tests {
+		if got := usableAddresses(netip.MustParsePrefix(tt.subnet)); got != tt.want {
+			t.Errorf("usableAddresses(%s) = %d, want %d", tt.subnet, got, tt.want)
+		}
+	}
+}
+
+func TestPlanIPPool(t *testing.T) {
+	tests := []struct {
+		name      string
+		subnet    string
+		pool      config.NvIpamSubnetConfig
+		workers   int
+		perNode   int
+		wantBlock int
+		wantErr   string
+	}{
+		{name: "room for as many nodes again", subnet: "10.0.0.0/24", workers: 4, perNode: 1, wantBlock: 31},
+		{name: "equal share without room", subnet: "10.0.0.0/24", workers: 60, perNode: 3, wantBlock: 4},
+		{name: "capped for nodes added later", subnet: "10.0.0.0/16", workers: 2, perNode: 1, wantBlock: maxPerNodeBlockSize},
+		{name: "gateway", subnet: "10.0.0.0/24", pool: config.NvIpamSubnetConfig{Gateway: "10.0.0.1"}, workers: 127, perNode: 1, wantBlock: 2},
+		{name: "gateway outside", subnet: "10.0.0.0/24", pool: config.NvIpamSubnetConfig{Gateway: "10.0.1.1"}, workers: 1, perNode: 1, wantErr: "not an address of the subnet"},
+		{name: "blocks of one address", subnet: "10.0.0.0/24", workers: 200, perNode: 1, wantErr: "too small"},
+		{name: "one address per VF", subnet: "10.0.0.0/24", workers: 32, perNode: 8, wantErr: "too small"},
+		{name: "exact fit", subnet: "10.0.0.0/27", workers: 15, perNode: 2, wantBlock: 2},
+		{name: "explicit block", subnet: "10.0.0.0/24", pool: config.NvIpamSubnetConfig{PerNodeBlockSize: 16}, workers: 4, perNode: 1, wantBlock: 16},
+		{name: "explicit block too large", subnet: "10.0.0.0/24", pool: config.NvIpamSubnetConfig{PerNodeBlockSize: 100}, workers: 4, perNode: 1, wantErr: "too few"},
+		{name: "CIDRPool settings", subnet: "10.0.0.0/24", pool: config.NvIpamSubnetConfig{PerNodeNetworkPrefix: 28}, workers: 4, perNode: 1, wantErr: "need nvIpam.poolType cidrpool"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := tt.pool
+			err := planIPPool(netip.MustParsePrefix(tt.subnet), &pool, tt.workers, tt.perNode)
+			checkPlanError(t, err, tt.wantErr)
+			if tt.wantErr == "" && pool.PerNodeBlockSize != tt.wantBlock {
+				t.Errorf("perNodeBlockSize = %d, want %d", pool.PerNodeBlockSize, tt.wantBlock)
+			}
+		})
+	}
+}
+
+func TestPlanCIDRPool(t *testing.T) {
+	gatewayIndex := 0
+	tests := []struct {
+		name       string
+		subnet     string
+		pool       config.NvIpamSubnetConfig
+		workers    int
+		perNode    int
+		spectrumX  bool
+		wantPrefix int
+		wantErr    string
+	}{
+		{name: "room for as many nodes again", subnet: "10.0.0.0/24", workers: 4, perNode: 1, wantPrefix: 27},
+		{name: "subnet per worker without room", subnet: "10.0.0.0/24", workers: 20, perNode: 5, wantPrefix: 29},
+		{name: "single worker", subnet: "10.0.0.0/24", workers: 1, perNode: 1, wantPrefix: 25},
+		{name: "capped for nodes added later", subnet: "10.0.0.0/16", workers: 2, perNode: 1, wantPrefix: 24},
+		{name: "too many workers", subnet: "10.0.0.0/24", pool: config.NvIpamSubnetConfig{PerNodeNetworkPrefix: 28}, workers: 17, perNode: 1, wantErr: "too small for a /28 subnet per worker node"},
+		{name: "too few addresses per node", subnet: "10.0.0.0/24", pool: config.NvIpamSubnetConfig{PerNodeNetworkPrefix: 30, GatewayIndex: &gatewayIndex}, workers: 4, perNode: 2, wantErr: "at least 3 are needed"},
+		{name: "Spectrum-X /31", subnet: "10.0.0.0/26", workers: 32, perNode: 8, spectrumX: true, wantPrefix: 31},
+		{name: "Spectrum-X too many workers", subnet: "10.0.0.0/26", workers: 33, perNode: 1, spectrumX: true, wantErr: "too small for a /31 subnet per worker node"},
+		{name: "Spectrum-X IPv6", subnet: "fd00::/64", workers: 2, perNode: 1, spectrumX: true, wantErr: "must be an IPv4 subnet"},
+		{name: "static allocation", subnet: "10.0.0.0/24", pool: config.NvIpamSubnetConfig{PerNodeNetworkPrefix: 28,
+			StaticAllocations: []config.NvIpamStaticAllocationConfig{{NodeName: "node-1", Prefix: "10.0.0.16/28"}}}, workers: 4, perNode: 1, wantPrefix: 28},
+		{name: "static allocation of another size", subnet: "10.0.0.0/24", pool: config.NvIpamSubnetConfig{PerNodeNetworkPrefix: 28,
+			StaticAllocations: []config.NvIpamStaticAllocationConfig{{NodeName: "node-1", Prefix: "10.0.0.0/27"}}}, workers: 4, perNode: 1, wantErr: "must be a /28 subnet"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := tt.pool
+			err := planCIDRPool(netip.MustParsePrefix(tt.subnet), &pool, tt.workers, tt.perNode, tt.spectrumX)
+			checkPlanError(t, err, tt.wantErr)
+			if tt.wantErr == "" && pool.PerNodeNetworkPrefix != tt.wantPrefix {
+				t.Errorf("perNodeNetworkPrefix = %d, want %d", pool.PerNodeNetworkPrefix, tt.wantPrefix)
+			}
+		})
+	}
+}
+
+func TestPlanNvIpamLeavesConfigUnchanged(t *testing.T) {
+	profile := &profiles.Profile{ProfileRequirements: profiles.ProfileRequirements{Deployment: profiles.Values{"sriov"}}}
+	cfg := &config.LaunchKubernetesConfig{
+		NvIpam:        &config.NvIpamConfig{PoolName: "nv-ipam-pool", Supernet: "10.100.0.0/16"},
+		Sriov:         &config.SriovConfig{NumVfs: 4},
+		Profile:       &config.Profile{},
+		ClusterConfig: &config.ClusterConfig{PFs: []config.PFConfig{{PciAddress: "0000:08:00.0", Traffic: "east-west"}}, WorkerNodes: []string{"node-1", "node-2"}},
+	}
+
+	for range 2 {
+		planned, err := planNvIpam(profile, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if planned.NvIpam.PoolType != config.PoolTypeIPPool || len(planned.NvIpam.Subnets) != 1 || planned.NvIpam.Subnets[0].PerNodeBlockSize == 0 {
+			t.Errorf("unexpected plan: %+v", planned.NvIpam)
+		}
+	}
+	if cfg.NvIpam.PoolType != "" || len(cfg.NvIpam.Subnets) != 0 {
+		t.Errorf("planNvIpam modified the config: %+v", cfg.NvIpam)
+	}
+}
+
+func checkPlanError(t *testing.T, err error, wantErr string) {
+	t.Helper()
+	if wantErr == "" && err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if wantErr != "" && (err == nil || !strings.Contains(err.Error(), wantErr)) {
+		t.Errorf("error = %v, want an error containing %q", err, wantErr)
+	}
+}
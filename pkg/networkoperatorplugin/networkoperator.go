@@ -17,12 +17,16 @@
 package networkoperatorplugin
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"slices"
 
 	"github.com/nvidia/k8s-launch-kit/pkg/config"
 	"github.com/nvidia/k8s-launch-kit/pkg/options"
 	"github.com/nvidia/k8s-launch-kit/pkg/plugin"
 	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+	"github.com/spf13/pflag"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -31,7 +35,73 @@ const (
 	PluginVersion = "1.0.0"
 )
 
+func init() {
+	plugin.Register(plugin.Registration{
+		Metadata: plugin.Metadata{
+			Name:                PluginName,
+			Version:             PluginVersion,
+			ProfileRequirements: []string{"fabric", "deployment", "multirail", "spectrumX", "ai"},
+		},
+		New:      func() plugin.Plugin { return &NetworkOperatorPlugin{} },
+		AddFlags: addFlags,
+		Validate: validateOptions,
+	})
+}
+
 type NetworkOperatorPlugin struct {
+	// attributes collected from the nodes by RefreshClusterConfig, once per node
+	attributes map[string]*nodeAttributes
+	collected  map[string]bool
+}
+
+// addFlags adds the profile selection and NicClusterPolicy flags of the plugin
+func addFlags(flags *pflag.FlagSet, opts *options.Options) {
+	flags.StringVar(&opts.Fabric, "fabric", "", "Select the fabric type to deploy (infiniband, ethernet)")
+	flags.StringVar(&opts.DeploymentType, "deployment-type", "", "Select the deployment type (sriov, rdma_shared, host_device)")
+	flags.BoolVar(&opts.Multirail, "multirail", false, "Enable multirail deployment")
+	flags.BoolVar(&opts.SpectrumX, "spectrum-x", false, "Enable Spectrum X deployment")
+	flags.BoolVar(&opts.Ai, "ai", false, "Enable AI deployment")
+	flags.StringVar(&opts.ExistingNicClusterPolicy, "existing-nic-cluster-policy", "", "How discovery treats an existing NicClusterPolicy: refuse, or reuse it by temporarily adding the nic-configuration-operator (default refuse)")
+	flags.DurationVar(&opts.NicClusterPolicyReadyTimeout, "nic-cluster-policy-timeout", 0, "Timeout for the NicClusterPolicy to become ready, e.g. while the DOCA driver compiles (default 15m)")
+}
+
+// validateOptions validates the flag combinations of the plugin
+func validateOptions(options options.Options) error {
+	// If profile is selected, either save-deployment-files or deploy options should be provided
+	if (options.Fabric != "" || options.DeploymentType != "" || options.Prompt != "") && options.SaveDeploymentFiles == "" && !options.Deploy {
+		return fmt.Errorf("when --deployment-type or --prompt is specified, either --save-deployment-files or --deploy must be provided")
+	}
+
+	// Save-deployment-files or deploy can't work without profile
+	if options.Fabric == "" && options.DeploymentType == "" && options.Prompt == "" && options.Deploy {
+		return fmt.Errorf("--deploy requires --deployment-type or --prompt to be specified")
+	}
+
+	if options.Prompt != "" && (options.Fabric != "" || options.DeploymentType != "") {
+		return fmt.Errorf("--fabric and --prompt cannot be used together")
+	}
+
+	if (options.DeploymentType != "" && options.Fabric == "") || (options.Fabric != "" && options.DeploymentType == "") {
+		return fmt.Errorf("--deployment-type requires --fabric to be specified")
+	}
+
+	if options.Fabric != "" && !slices.Contains([]string{"infiniband", "ethernet"}, options.Fabric) {
+		return fmt.Errorf("--fabric must be one of: infiniband, ethernet")
+	}
+
+	if options.DeploymentType != "" && !slices.Contains([]string{"sriov", "rdma_shared", "host_device"}, options.DeploymentType) {
+		return fmt.Errorf("--deployment-type must be one of: sriov, rdma_shared, host_device")
+	}
+
+	if options.ExistingNicClusterPolicy != "" && !slices.Contains([]string{ExistingPolicyRefuse, ExistingPolicyReuse}, options.ExistingNicClusterPolicy) {
+		return fmt.Errorf("--existing-nic-cluster-policy must be one of: refuse, reuse")
+	}
+
+	if options.NicClusterPolicyReadyTimeout < 0 {
+		return fmt.Errorf("--nic-cluster-policy-timeout must not be negative")
+	}
+
+	return nil
 }
 
 func (p *NetworkOperatorPlugin) GetName() string {
@@ -42,11 +112,11 @@ func (p *NetworkOperatorPlugin) GetVersion() string {
 	return PluginVersion
 }
 
-func (p *NetworkOperatorPlugin) ProfileConfiguredInCmd(options options.Options) bool {
+func (p *NetworkOperatorPlugin) ProfileConfiguredInCmd(_ context.Context, options options.Options) bool {
 	return options.Fabric != "" || options.DeploymentType != ""
 }
 
-func (p *NetworkOperatorPlugin) BuildProfileFromOptions(options options.Options, profile *config.Profile) error {
+func (p *NetworkOperatorPlugin) BuildProfileFromOptions(_ context.Context, options options.Options, profile *config.Profile) error {
 	profile.Fabric = options.Fabric
 	profile.Deployment = options.DeploymentType
 	profile.Multirail = options.Multirail
@@ -57,7 +127,7 @@ func (p *NetworkOperatorPlugin) BuildProfileFromOptions(options options.Options,
 	return nil
 }
 
-func (p *NetworkOperatorPlugin) BuildProfileFromLLMResponse(llmResponse map[string]string, profile *config.Profile) error {
+func (p *NetworkOperatorPlugin) BuildProfileFromLLMResponse(_ context.Context, llmResponse map[string]string, profile *config.Profile) error {
 	profile.Fabric = llmResponse["fabric"]
 	profile.Deployment = llmResponse["deploymentType"]
 	profile.Multirail = llmResponse["multirail"] == "true"
@@ -68,7 +138,7 @@ func (p *NetworkOperatorPlugin) BuildProfileFromLLMResponse(llmResponse map[stri
 	return nil
 }
 
-func (p *NetworkOperatorPlugin) GetSystemPromptAddendum() (string, error) {
+func (p *NetworkOperatorPlugin) GetSystemPromptAddendum(_ context.Context) (string, error) {
 	data, err := os.ReadFile("network-operator-system-prompt-addendum")
 	if err != nil {
 		return "", err
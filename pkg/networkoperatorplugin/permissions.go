@@ -0,0 +1,111 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package networkoperatorplugin
+
+import (
+	"slices"
+
+	netop "github.com/Mellanox/network-operator/api/v1alpha1"
+	nicop "github.com/Mellanox/nic-configuration-operator/api/v1alpha1"
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+	"github.com/nvidia/k8s-launch-kit/pkg/plugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Permissions returns the permissions of the requests made beyond the objects of the deployment files and of the Helm chart:
+// the installation keeps the Helm release and looks up the operator and NFD, discovery
+// creates or patches the NicClusterPolicy, reads the nic-configuration-daemon pods and the NicDevices and runs the node
+// collector pods and reads their logs, deployment runs the preflight checks and waits for the NicClusterPolicy, verification runs the workload pods, status reads the
+// nodes and SR-IOV node states and watch refreshes the config from the NicDevices. Best-effort reads are left out.
+func (p *NetworkOperatorPlugin) Permissions(phase string, profile *profiles.Profile, cfg *config.LaunchKubernetesConfig) ([]kubeclient.Permission, error) {
+	namespace := cfg.NetworkOperator.Namespace
+	collectorPods := []kubeclient.Permission{
+		{Resource: "pods", Namespace: namespace, Verbs: []string{"create", "delete", "get"}},
+		{Resource: "pods/log", Namespace: namespace, Verbs: []string{"get"}},
+	}
+	nicDevices := kubeclient.Permission{Group: nicop.GroupVersion.Group, Resource: "nicdevices", Namespace: namespace, Verbs: []string{"list"}}
+
+	switch phase {
+	case plugin.PhaseInstall:
+		// Helm keeps the releases in secrets and creates the namespace, the operator and NFD are looked up first,
+		// and the readiness wait lists the pods of the release
+		return []kubeclient.Permission{
+			{Resource: "secrets", Namespace: namespace, Verbs: []string{"create", "delete", "get", "list", "update"}},
+			{Resource: "namespaces", Verbs: []string{"create", "get"}},
+			{Resource: "pods", Namespace: namespace, Verbs: []string{"list"}},
+			{Group: "apps", Resource: "deployments", Namespace: namespace, Verbs: []string{"list"}},
+			{Group: "apps", Resource: "replicasets", Namespace: namespace, Verbs: []string{"list"}},
+			{Group: "apps", Resource: "daemonsets", Verbs: []string{"list"}},
+		}, nil
+	case plugin.PhaseDiscover:
+		permissions := []kubeclient.Permission{
+			{Group: netop.GroupVersion.Group, Resource: "nicclusterpolicies", Verbs: []string{"create", "delete", "get", "list", "patch"}},
+			{Resource: "pods", Namespace: namespace, Verbs: []string{"list"}},
+			nicDevices,
+		}
+		if cfg.NetworkOperator.NodeCollectorImage != "" {
+			permissions = append(permissions, collectorPods...)
+		}
+		return permissions, nil
+	case plugin.PhaseDeploy:
+		// The preflight checks read the operator deployment, the Multus daemonsets and the nodes
+		return []kubeclient.Permission{
+			{Group: netop.GroupVersion.Group, Resource: "nicclusterpolicies", Verbs: []string{"get"}},
+			{Group: "apps", Resource: "deployments", Namespace: namespace, Verbs: []string{"list"}},
+			{Group: "apps", Resource: "daemonsets", Verbs: []string{"list"}},
+			{Resource: "nodes", Verbs: []string{"list"}},
+		}, nil
+	case plugin.PhaseVerify:
+		if profile == nil || profile.Verification == nil {
+			return nil, nil
+		}
+		workload, err := verificationWorkload(profile.Verification.Workload, cfg)
+		if err != nil {
+			return nil, err
+		}
+		permissions := []kubeclient.Permission{}
+		for _, pod := range workload {
+			permissions = append(permissions, kubeclient.Permission{Resource: "pods", Namespace: pod.Namespace, Verbs: []string{"create", "delete", "get"}})
+		}
+		return permissions, nil
+	case plugin.PhaseStatus:
+		permissions := []kubeclient.Permission{{Resource: "nodes", Verbs: []string{"get", "list"}}}
+		rendered, err := generateDeploymentFiles(profile, cfg)
+		if err != nil {
+			return nil, err
+		}
+		expected, err := decodeRendered(rendered)
+		if err != nil {
+			return nil, err
+		}
+		if slices.ContainsFunc(expected, func(obj *unstructured.Unstructured) bool { return obj.GetKind() == "SriovNetworkNodePolicy" }) {
+			permissions = append(permissions, kubeclient.Permission{Group: sriovNetworkNodeStateGVK.Group, Resource: "sriovnetworknodestates", Namespace: namespace, Verbs: []string{"get"}})
+		}
+		return permissions, nil
+	case plugin.PhaseWatch:
+		permissions := []kubeclient.Permission{nicDevices, {Resource: "nodes", Verbs: []string{"list"}}}
+		if cfg.NetworkOperator.NodeCollectorImage != "" {
+			permissions = append(permissions, collectorPods...)
+		}
+		return permissions, nil
+	}
+	return nil, nil
+}
+
+var _ plugin.PermissionRequirer = &NetworkOperatorPlugin{}
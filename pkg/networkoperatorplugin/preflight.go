@@ -0,0 +1,242 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package networkoperatorplugin
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	netop "github.com/Mellanox/network-operator/api/v1alpha1"
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/plugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// operatorImage is the image name of the network-operator deployed by its Helm chart
+	operatorImage = "network-operator"
+
+	// nfdLabelPrefix is the prefix of the node labels set by NFD
+	nfdLabelPrefix = "feature.node.kubernetes.io/"
+	// kernelVersionLabel is the full kernel version of the node set by NFD, e.g. 5.15.0-91-generic
+	kernelVersionLabel = nfdLabelPrefix + "kernel-version.full"
+	// secureBootLabel is set to true or false on the nodes by the Secure Boot state, e.g. by an NFD local feature file
+	secureBootLabel = nfdLabelPrefix + "secure-boot.enabled"
+	// loadedModuleLabelPrefix followed by a kernel module name is set on the nodes that loaded the module, e.g. by an NFD rule.
+	// The rule labels mlx5_core too, loaded on every NIC node, so that a node without any of these labels is not reported.
+	loadedModuleLabelPrefix = nfdLabelPrefix + "kernel-loadedmodule."
+)
+
+// storageModules are the in-tree storage modules over RDMA that prevent the DOCA driver from reloading the RDMA stack,
+// unless docaDriver.unloadStorageModules is set
+var storageModules = []string{"ib_isert", "ib_srpt", "nvme_rdma", "nvmet_rdma", "rpcrdma", "xprtrdma"}
+
+// PreflightChecks checks the prerequisites of the NicClusterPolicy of the profile: a network-operator of the configured
+// version running in the namespace, NFD labelling the nodes, no other Multus installation, and on the NIC nodes the kernel
+// versions supported by the DOCA driver, Secure Boot and the storage modules loaded, as reported by the node labels
+func (p *NetworkOperatorPlugin) PreflightChecks(ctx context.Context, profile *profiles.Profile, cfg *config.LaunchKubernetesConfig, c client.Client) ([]plugin.PreflightFinding, error) {
+	rendered, err := generateDeploymentFiles(profile, cfg)
+	if err != nil {
+		return nil, err
+	}
+	expected, err := decodeRendered(rendered)
+	if err != nil {
+		return nil, err
+	}
+	policy := &netop.NicClusterPolicy{}
+	for _, obj := range expected {
+		if obj.GetKind() == "NicClusterPolicy" {
+			if err := convertUnstructured(obj, policy); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	findings, err := checkOperatorVersion(ctx, c, cfg.NetworkOperator)
+	if err != nil {
+		return nil, err
+	}
+	if policy.Spec.SecondaryNetwork != nil && policy.Spec.SecondaryNetwork.Multus != nil {
+		multus, err := checkMultus(ctx, c, cfg.NetworkOperator.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, multus...)
+	}
+	nodes, err := checkNodes(ctx, c, cfg, policy.Spec.OFEDDriver != nil)
+	if err != nil {
+		return nil, err
+	}
+	return append(findings, nodes...), nil
+}
+
+// checkOperatorVersion checks that the network-operator runs in its namespace with the image tag of the configured version
+func checkOperatorVersion(ctx context.Context, c client.Client, cfg *config.NetworkOperatorConfig) ([]plugin.PreflightFinding, error) {
+	deployment, image, err := findOperatorDeployment(ctx, c, cfg.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if deployment == nil {
+		return []plugin.PreflightFinding{{Check: "operator-version", Severity: plugin.SeverityBlocker,
+			Message: fmt.Sprintf("no network-operator found in namespace %s, install the network-operator Helm chart %s first", cfg.Namespace, cfg.Version)}}, nil
+	}
+
+	object := "Deployment " + cfg.Namespace + "/" + deployment.Name
+	if deployment.Status.ReadyReplicas == 0 {
+		return []plugin.PreflightFinding{{Check: "operator-version", Severity: plugin.SeverityBlocker, Object: object,
+			Message: "the network-operator is not ready"}}, nil
+	}
+	reference, _, _ := strings.Cut(image, "@")
+	_, tag, _ := strings.Cut(path.Base(reference), ":")
+	if cfg.Version != "" && tag != "" && tag != cfg.Version {
+		return []plugin.PreflightFinding{{Check: "operator-version", Severity: plugin.SeverityWarning, Object: object,
+			Message: fmt.Sprintf("the network-operator runs image %s, the config is for %s, set networkOperator.version or upgrade the operator", image, cfg.Version)}}, nil
+	}
+	return nil, nil
+}
+
+// findOperatorDeployment returns the Deployment of namespace running the network-operator image and the image, nil if none
+func findOperatorDeployment(ctx context.Context, c client.Client, namespace string) (*appsv1.Deployment, string, error) {
+	deployments := &appsv1.DeploymentList{}
+	if err := c.List(ctx, deployments, client.InNamespace(namespace)); err != nil {
+		return nil, "", fmt.Errorf("failed to list the deployments of namespace %s: %w", namespace, err)
+	}
+	for i, deployment := range deployments.Items {
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			image, _, _ := strings.Cut(container.Image, "@")
+			if name, _, _ := strings.Cut(path.Base(image), ":"); name == operatorImage {
+				return &deployments.Items[i], container.Image, nil
+			}
+		}
+	}
+	return nil, "", nil
+}
+
+// checkMultus reports the Multus DaemonSets not deployed by the network-operator, which would compete with the one of the profile
+func checkMultus(ctx context.Context, c client.Client, namespace string) ([]plugin.PreflightFinding, error) {
+	daemonSets := &appsv1.DaemonSetList{}
+	if err := c.List(ctx, daemonSets); err != nil {
+		return nil, fmt.Errorf("failed to list the daemonsets: %w", err)
+	}
+	findings := []plugin.PreflightFinding{}
+	for _, ds := range daemonSets.Items {
+		if !strings.Contains(ds.Name, "multus") || ds.Namespace == namespace {
+			continue
+		}
+		findings = append(findings, plugin.PreflightFinding{Check: "multus", Severity: plugin.SeverityBlocker, Object: "DaemonSet " + ds.Namespace + "/" + ds.Name,
+			Message: "Multus is already installed, remove it or this profile deploys a second Multus"})
+	}
+	return findings, nil
+}
+
+// checkNodes checks that NFD labels the nodes and, on the NIC nodes, the kernel versions, Secure Boot and the loaded
+// storage modules when the DOCA driver is deployed
+func checkNodes(ctx context.Context, c client.Client, cfg *config.LaunchKubernetesConfig, docaDriver bool) ([]plugin.PreflightFinding, error) {
+	nodes := &corev1.NodeList{}
+	if err := c.List(ctx, nodes); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	nfd := slices.ContainsFunc(nodes.Items, func(node corev1.Node) bool {
+		for label := range node.Labels {
+			if strings.HasPrefix(label, nfdLabelPrefix) {
+				return true
+			}
+		}
+		return false
+	})
+	if !nfd {
+		return []plugin.PreflightFinding{{Check: "nfd", Severity: plugin.SeverityBlocker,
+			Message: "no node is labelled by Node Feature Discovery, deploy NFD or enable it in the network-operator Helm chart"}}, nil
+	}
+
+	selector := labels.Everything()
+	if cfg.ClusterConfig != nil && len(cfg.ClusterConfig.NodeSelector) > 0 {
+		selector = labels.SelectorFromSet(cfg.ClusterConfig.NodeSelector)
+	}
+	findings := []plugin.PreflightFinding{}
+	matched := 0
+	for _, node := range nodes.Items {
+		if !selector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		matched++
+		if !docaDriver {
+			continue
+		}
+		object := "Node " + node.Name
+
+		kernel := node.Labels[kernelVersionLabel]
+		supported := cfg.DOCADriver != nil && len(cfg.DOCADriver.SupportedKernels) > 0
+		switch {
+		case !supported:
+		case kernel == "":
+			findings = append(findings, plugin.PreflightFinding{Check: "kernel", Severity: plugin.SeverityWarning, Object: object,
+				Message: fmt.Sprintf("the kernel version is unknown, the node has no %s label", kernelVersionLabel)})
+		case !slices.ContainsFunc(cfg.DOCADriver.SupportedKernels, func(version string) bool { return kernelMatches(kernel, version) }):
+			findings = append(findings, plugin.PreflightFinding{Check: "kernel", Severity: plugin.SeverityBlocker, Object: object,
+				Message: fmt.Sprintf("kernel %s is not supported by DOCA driver %s, supported: %s", kernel, cfg.DOCADriver.Version, strings.Join(cfg.DOCADriver.SupportedKernels, ", "))})
+		}
+
+		switch secureBoot, ok := node.Labels[secureBootLabel]; {
+		case !ok:
+			findings = append(findings, plugin.PreflightFinding{Check: "secure-boot", Severity: plugin.SeverityWarning, Object: object,
+				Message: fmt.Sprintf("the Secure Boot state is unknown, the node has no %s label", secureBootLabel)})
+		case secureBoot == "true":
+			findings = append(findings, plugin.PreflightFinding{Check: "secure-boot", Severity: plugin.SeverityWarning, Object: object,
+				Message: "Secure Boot is enabled, the DOCA driver modules are only loaded if they are signed with an enrolled key"})
+		}
+
+		if cfg.DOCADriver == nil || !cfg.DOCADriver.UnloadStorageModules {
+			reported, loaded := false, []string{}
+			for label := range node.Labels {
+				reported = reported || strings.HasPrefix(label, loadedModuleLabelPrefix)
+			}
+			for _, module := range storageModules {
+				if node.Labels[loadedModuleLabelPrefix+module] == "true" {
+					loaded = append(loaded, module)
+				}
+			}
+			switch {
+			case !reported:
+				findings = append(findings, plugin.PreflightFinding{Check: "in-tree-drivers", Severity: plugin.SeverityWarning, Object: object,
+					Message: fmt.Sprintf("the loaded storage modules are unknown, the node has no %s<module> label", loadedModuleLabelPrefix)})
+			case len(loaded) > 0:
+				findings = append(findings, plugin.PreflightFinding{Check: "in-tree-drivers", Severity: plugin.SeverityBlocker, Object: object,
+					Message: fmt.Sprintf("in-tree storage modules %s are loaded, set docaDriver.unloadStorageModules to let the DOCA driver unload them", strings.Join(loaded, ", "))})
+			}
+		}
+	}
+	if matched == 0 {
+		findings = append(findings, plugin.PreflightFinding{Check: "nfd", Severity: plugin.SeverityWarning,
+			Message: fmt.Sprintf("no node matches the NIC node selector %s, the profile is deployed on no node", selector)})
+	}
+	return findings, nil
+}
+
+// kernelMatches returns whether the kernel version starts with the major.minor version, e.g. 5.15.0-91-generic with 5.15
+func kernelMatches(kernel, version string) bool {
+	return kernel == version || strings.HasPrefix(kernel, version+".") || strings.HasPrefix(kernel, version+"-")
+}
+
+var _ plugin.PreflightChecker = &NetworkOperatorPlugin{}
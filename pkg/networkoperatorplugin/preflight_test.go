@@ -0,0 +1,295 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package networkoperatorplugin
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+	"github.com/nvidia/k8s-launch-kit/pkg/plugin"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// finding is the severity, check and object of a finding, without the message
+type finding struct {
+	severity, check, object string
+}
+
+func findingsOf(findings []plugin.PreflightFinding) []finding {
+	got := []finding{}
+	for _, f := range findings {
+		got = append(got, finding{f.Severity, f.Check, f.Object})
+	}
+	return got
+}
+
+// nicNode returns a NIC node with labels reporting a supported kernel, Secure Boot disabled and no storage module loaded
+func nicNode(name string, labels map[string]string) *corev1.Node {
+	nodeLabels := map[string]string{
+		"feature.node.kubernetes.io/pci-15b3.present": "true",
+		kernelVersionLabel:                    "5.15.0-91-generic",
+		secureBootLabel:                       "false",
+		loadedModuleLabelPrefix + "mlx5_core": "true",
+	}
+	for label, value := range labels {
+		if value == "" {
+			delete(nodeLabels, label)
+		} else {
+			nodeLabels[label] = value
+		}
+	}
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nodeLabels}}
+}
+
+func TestCheckNodes(t *testing.T) {
+	cfg := &config.LaunchKubernetesConfig{
+		DOCADriver:    &config.DOCADriverConfig{Version: "25.04", SupportedKernels: []string{"5.15", "6.8"}},
+		ClusterConfig: &config.ClusterConfig{NodeSelector: map[string]string{"feature.node.kubernetes.io/pci-15b3.present": "true"}},
+	}
+	unloading := *cfg
+	unloading.DOCADriver = &config.DOCADriverConfig{UnloadStorageModules: true}
+
+	tests := []struct {
+		name       string
+		cfg        *config.LaunchKubernetesConfig
+		nodes      []client.Object
+		docaDriver bool
+		want       []finding
+	}{
+		{
+			name:       "no NFD labels",
+			cfg:        cfg,
+			nodes:      []client.Object{&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}}},
+			docaDriver: true,
+			want:       []finding{{plugin.SeverityBlocker, "nfd", ""}},
+		},
+		{
+			name:       "prerequisites met",
+			cfg:        cfg,
+			nodes:      []client.Object{nicNode("node-a", nil), nicNode("node-b", map[string]string{kernelVersionLabel: "6.8.0-40-generic"})},
+			docaDriver: true,
+			want:       []finding{},
+		},
+		{
+			name: "nodes outside the NIC node selector are not checked",
+			cfg:  cfg,
+			nodes: []client.Object{nicNode("node-a", nil), &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "control-plane",
+				Labels: map[string]string{kernelVersionLabel: "4.18.0"}}}},
+			docaDriver: true,
+			want:       []finding{},
+		},
+		{
+			name:       "no node matches the NIC node selector",
+			cfg:        cfg,
+			nodes:      []client.Object{nicNode("node-a", map[string]string{"feature.node.kubernetes.io/pci-15b3.present": ""})},
+			docaDriver: true,
+			want:       []finding{{plugin.SeverityWarning, "nfd", ""}},
+		},
+		{
+			name:       "unsupported kernel",
+			cfg:        cfg,
+			nodes:      []client.Object{nicNode("node-a", map[string]string{kernelVersionLabel: "5.14.0-427.el9.x86_64"})},
+			docaDriver: true,
+			want:       []finding{{plugin.SeverityBlocker, "kernel", "Node node-a"}},
+		},
+		{
+			name:       "unknown kernel",
+			cfg:        cfg,
+			nodes:      []client.Object{nicNode("node-a", map[string]string{kernelVersionLabel: ""})},
+			docaDriver: true,
+			want:       []finding{{plugin.SeverityWarning, "kernel", "Node node-a"}},
+		},
+		{
+			name:       "kernels not checked without supported kernels",
+			cfg:        &unloading,
+			nodes:      []client.Object{nicNode("node-a", map[string]string{kernelVersionLabel: "4.18.0"})},
+			docaDriver: true,
+			want:       []finding{},
+		},
+		{
+			name:       "Secure Boot enabled",
+			cfg:        cfg,
+			nodes:      []client.Object{nicNode("node-a", map[string]string{secureBootLabel: "true"})},
+			docaDriver: true,
+			want:       []finding{{plugin.SeverityWarning, "secure-boot", "Node node-a"}},
+		},
+		{
+			name:       "unknown Secure Boot state",
+			cfg:        cfg,
+			nodes:      []client.Object{nicNode("node-a", map[string]string{secureBootLabel: ""})},
+			docaDriver: true,
+			want:       []finding{{plugin.SeverityWarning, "secure-boot", "Node node-a"}},
+		},
+		{
+			name:       "storage modules loaded",
+			cfg:        cfg,
+			nodes:      []client.Object{nicNode("node-a", map[string]string{loadedModuleLabelPrefix + "nvme_rdma": "true"})},
+			docaDriver: true,
+			want:       []finding{{plugin.SeverityBlocker, "in-tree-drivers", "Node node-a"}},
+		},
+		{
+			name:       "storage modules unloaded by the DOCA driver",
+			cfg:        &unloading,
+			nodes:      []client.Object{nicNode("node-a", map[string]string{loadedModuleLabelPrefix + "nvme_rdma": "true"})},
+			docaDriver: true,
+			want:       []finding{},
+		},
+		{
+			name:       "unknown loaded modules",
+			cfg:        cfg,
+			nodes:      []client.Object{nicNode("node-a", map[string]string{loadedModuleLabelPrefix + "mlx5_core": ""})},
+			docaDriver: true,
+			want:       []finding{{plugin.SeverityWarning, "in-tree-drivers", "Node node-a"}},
+		},
+		{
+			name: "nodes not checked without the DOCA driver",
+			cfg:  cfg,
+			nodes: []client.Object{nicNode("node-a", map[string]string{kernelVersionLabel: "", secureBootLabel: "true",
+				loadedModuleLabelPrefix + "rpcrdma": "true"})},
+			want: []finding{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(kubeclient.NewScheme()).WithObjects(tt.nodes...).Build()
+			findings, err := checkNodes(context.Background(), c, tt.cfg, tt.docaDriver)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := findingsOf(findings); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("checkNodes() = %+v, want %+v", findings, tt.want)
+			}
+		})
+	}
+}
+
+func TestKernelMatches(t *testing.T) {
+	tests := []struct {
+		kernel, version string
+		want            bool
+	}{
+		{kernel: "5.15.0-91-generic", version: "5.15", want: true},
+		{kernel: "5.15", version: "5.15", want: true},
+		{kernel: "5.15-rc1", version: "5.15", want: true},
+		{kernel: "6.8.0-40-generic", version: "6.8.0", want: true},
+		{kernel: "5.150.0", version: "5.15", want: false},
+		{kernel: "5.14.0-427.el9.x86_64", version: "5.15", want: false},
+		{kernel: "15.1.0", version: "5.1", want: false},
+	}
+	for _, tt := range tests {
+		if got := kernelMatches(tt.kernel, tt.version); got != tt.want {
+			t.Errorf("kernelMatches(%s, %s) = %v, want %v", tt.kernel, tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestCheckMultus(t *testing.T) {
+	daemonSet := func(namespace, name string) client.Object {
+		return &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+	c := fake.NewClientBuilder().WithScheme(kubeclient.NewScheme()).WithObjects(
+		daemonSet("kube-system", "kube-multus-ds"),
+		daemonSet("nvidia-network-operator", "kube-multus-ds"),
+		daemonSet("kube-system", "kube-proxy"),
+	).Build()
+
+	findings, err := checkMultus(context.Background(), c, "nvidia-network-operator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []finding{{plugin.SeverityBlocker, "multus", "DaemonSet kube-system/kube-multus-ds"}}
+	if got := findingsOf(findings); !reflect.DeepEqual(got, want) {
+		t.Errorf("checkMultus() = %+v, want %+v", findings, want)
+	}
+}
+
+func TestCheckOperatorVersion(t *testing.T) {
+	deployment := func(name, image string, ready int32) client.Object {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "nvidia-network-operator", Name: name},
+			Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "manager", Image: image}},
+			}}},
+			Status: appsv1.DeploymentStatus{ReadyReplicas: ready},
+		}
+	}
+	object := "Deployment nvidia-network-operator/network-operator"
+
+	tests := []struct {
+		name    string
+		version string
+		objects []client.Object
+		want    []finding
+	}{
+		{
+			name:    "not installed",
+			version: "v25.4.0",
+			objects: []client.Object{deployment("node-feature-discovery", "registry.k8s.io/nfd/node-feature-discovery:v0.17.2", 1)},
+			want:    []finding{{plugin.SeverityBlocker, "operator-version", ""}},
+		},
+		{
+			name:    "not ready",
+			version: "v25.4.0",
+			objects: []client.Object{deployment("network-operator", "nvcr.io/nvidia/cloud-native/network-operator:v25.4.0", 0)},
+			want:    []finding{{plugin.SeverityBlocker, "operator-version", object}},
+		},
+		{
+			name:    "configured version",
+			version: "v25.4.0",
+			objects: []client.Object{deployment("network-operator", "nvcr.io/nvidia/cloud-native/network-operator:v25.4.0", 1)},
+			want:    []finding{},
+		},
+		{
+			name:    "configured version with a digest",
+			version: "v25.4.0",
+			objects: []client.Object{deployment("network-operator", "nvcr.io/nvidia/cloud-native/network-operator:v25.4.0@sha256:0123", 1)},
+			want:    []finding{},
+		},
+		{
+			name:    "other version",
+			version: "v25.4.0",
+			objects: []client.Object{deployment("network-operator", "nvcr.io/nvidia/cloud-native/network-operator:v25.1.0", 1)},
+			want:    []finding{{plugin.SeverityWarning, "operator-version", object}},
+		},
+		{
+			name:    "no configured version",
+			objects: []client.Object{deployment("network-operator", "nvcr.io/nvidia/cloud-native/network-operator:v25.1.0", 1)},
+			want:    []finding{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(kubeclient.NewScheme()).WithObjects(tt.objects...).Build()
+			findings, err := checkOperatorVersion(context.Background(), c, &config.NetworkOperatorConfig{Namespace: "nvidia-network-operator", Version: tt.version})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := findingsOf(findings); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("checkOperatorVersion() = %+v, want %+v", findings, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package networkoperatorplugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	netop "github.com/Mellanox/network-operator/api/v1alpha1"
+	applog "github.com/nvidia/k8s-launch-kit/pkg/log"
+	"golang.org/x/term"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// ofedDriverLabel is set by the Network Operator on the DOCA driver DaemonSet pods
+	ofedDriverLabel = "nvidia.com/ofed-driver"
+	// maxWarningEvents is the number of most recent Warning events shown in the progress
+	maxWarningEvents = 5
+	// progressLogInterval is how often an unchanged progress is logged when not on a TTY
+	progressLogInterval = 30 * time.Second
+)
+
+// rolloutProgress is a snapshot of a NicClusterPolicy rollout
+type rolloutProgress struct {
+	State      netop.State
+	Components []netop.AppliedState
+	DriverPods []driverPodState
+	Warnings   []string
+}
+
+// driverPodState is the state of a single DOCA driver pod
+type driverPodState struct {
+	Node   string
+	Phase  corev1.PodPhase
+	Ready  bool
+	Reason string
+}
+
+func (d driverPodState) String() string {
+	s := string(d.Phase)
+	if d.Ready {
+		s += ", ready"
+	}
+	if d.Reason != "" {
+		s += ", " + d.Reason
+	}
+	return s
+}
+
+// progressReporter renders the progress of a NicClusterPolicy rollout, either as a live view
+// on a terminal or as periodic structured log lines
+type progressReporter struct {
+	client    client.Client
+	namespace string
+	since     time.Time
+
+	out        io.Writer
+	tty        bool
+	written    func() uint64 // number of writes of others to the stream of out, e.g. the log
+	printed    int
+	writes     uint64
+	lastLogged string
+	lastLogAt  time.Time
+}
+
+// newProgressReporter returns a reporter for the rollout of the operator components in namespace.
+// The live view is only used when stderr is a terminal.
+func newProgressReporter(c client.Client, namespace string) *progressReporter {
+	return &progressReporter{
+		client:    c,
+		namespace: namespace,
+		since:     time.Now(),
+		out:       os.Stderr,
+		tty:       term.IsTerminal(int(os.Stderr.Fd())),
+		written:   applog.StderrWrites,
+	}
+}
+
+// Update collects the current rollout progress of policy and renders it
+func (r *progressReporter) Update(ctx context.Context, policy *netop.NicClusterPolicy) {
+	progress := r.collect(ctx, policy)
+	if r.tty {
+		r.render(progress)
+		return
+	}
+	r.log(progress)
+}
+
+// Done finishes the live view, so that subsequent output is not overwritten
+func (r *progressReporter) Done() {
+	r.printed = 0
+}
+
+// collect gathers the applied states, driver pod phases and recent warnings.
+// Listing errors are not fatal, the progress is best effort.
+func (r *progressReporter) collect(ctx context.Context, policy *netop.NicClusterPolicy) rolloutProgress {
+	progress := rolloutProgress{
+		State:      policy.Status.State,
+		Components: policy.Status.AppliedStates,
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.client.List(ctx, pods, client.InNamespace(r.namespace), client.HasLabels{ofedDriverLabel}); err != nil {
+		log.Log.V(1).Info("failed to list DOCA driver pods", "error", err.Error())
+	}
+	for _, pod := range pods.Items {
+		state := driverPodState{
+			Node:  pod.Spec.NodeName,
+			Phase: pod.Status.Phase,
+			Ready: isPodReady(&pod),
+		}
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.State.Waiting != nil && cs.State.Waiting.Reason != "" {
+				state.Reason = cs.State.Waiting.Reason
+				break
+			}
+		}
+		progress.DriverPods = append(progress.DriverPods, state)
+	}
+	slices.SortFunc(progress.DriverPods, func(a, b driverPodState) int {
+		return strings.Compare(a.Node, b.Node)
+	})
+
+	events := &corev1.EventList{}
+	if err := r.client.List(ctx, events, client.InNamespace(r.namespace)); err != nil {
+		log.Log.V(1).Info("failed to list events", "error", err.Error())
+	}
+	warnings := []corev1.Event{}
+	for _, event := range events.Items {
+		if event.Type == corev1.EventTypeWarning && eventTime(&event).After(r.since) {
+			warnings = append(warnings, event)
+		}
+	}
+	slices.SortFunc(warnings, func(a, b corev1.Event) int {
+		return eventTime(&a).Compare(eventTime(&b))
+	})
+	if len(warnings) > maxWarningEvents {
+		warnings = warnings[len(warnings)-maxWarningEvents:]
+	}
+	for _, event := range warnings {
+		progress.Warnings = append(progress.Warnings, fmt.Sprintf("%s/%s: %s: %s",
+			event.InvolvedObject.Kind, event.InvolvedObject.Name, event.Reason, strings.TrimSpace(event.Message)))
+	}
+
+	return progress
+}
+
+// render redraws the live progress view in place
+func (r *progressReporter) render(progress rolloutProgress) {
+	lines := []string{fmt.Sprintf("NicClusterPolicy: %s (elapsed %s)", orUnknown(string(progress.State)), time.Since(r.since).Round(time.Second))}
+	for _, component := range progress.Components {
+		line := fmt.Sprintf("  %-32s %s", component.Name, component.State)
+		if component.Message != "" {
+			line += " - " + component.Message
+		}
+		lines = append(lines, line)
+	}
+	if len(progress.DriverPods) > 0 {
+		lines = append(lines, "DOCA driver pods:")
+		for _, pod := range progress.DriverPods {
+			lines = append(lines, fmt.Sprintf("  %-32s %s", pod.Node, pod))
+		}
+	}
+	if len(progress.Warnings) > 0 {
+		lines = append(lines, "Recent warnings:")
+		for _, warning := range progress.Warnings {
+			lines = append(lines, "  "+warning)
+		}
+	}
+
+	// Move the cursor back to the start of the previous view and clear it, unless something
+	// was written below it since, which would be overwritten. The view is then printed anew.
+	writes := r.written()
+	if r.printed > 0 && writes == r.writes {
+		fmt.Fprintf(r.out, "\033[%dA\033[J", r.printed)
+	}
+	fmt.Fprintln(r.out, strings.Join(lines, "\n"))
+	r.printed = len(lines)
+	r.writes = writes
+}
+
+// log emits the progress as a structured log line when it changed or progressLogInterval passed
+func (r *progressReporter) log(progress rolloutProgress) {
+	components := make([]string, 0, len(progress.Components))
+	for _, component := range progress.Components {
+		components = append(components, fmt.Sprintf("%s=%s", component.Name, component.State))
+	}
+	driverPods := make([]string, 0, len(progress.DriverPods))
+	for _, pod := range progress.DriverPods {
+		driverPods = append(driverPods, fmt.Sprintf("%s=%s", pod.Node, pod))
+	}
+
+	if !r.logDue(fmt.Sprint(progress.State, components, driverPods, progress.Warnings), time.Now()) {
+		return
+	}
+
+	log.Log.Info("Waiting for NicClusterPolicy",
+		"state", orUnknown(string(progress.State)),
+		"elapsed", time.Since(r.since).Round(time.Second).String(),
+		"components", components,
+		"driverPods", driverPods,
+		"warnings", progress.Warnings)
+}
+
+// logDue returns whether the progress summarized by summary is logged at now, which is when it changed
+// or progressLogInterval passed since it was last logged, and records it as logged if so
+func (r *progressReporter) logDue(summary string, now time.Time) bool {
+	if summary == r.lastLogged && now.Sub(r.lastLogAt) < progressLogInterval {
+		return false
+	}
+	r.lastLogged = summary
+	r.lastLogAt = now
+	return true
+}
+
+// eventTime returns the most recent timestamp recorded on the event
+func eventTime(event *corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
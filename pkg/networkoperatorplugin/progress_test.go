@@ -0,0 +1,167 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package networkoperatorplugin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	netop "github.com/Mellanox/network-operator/api/v1alpha1"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestProgressReporterCollect(t *testing.T) {
+	since := time.Now()
+	objects := []client.Object{
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "driver-b", Namespace: "nvidia-network-operator", Labels: map[string]string{ofedDriverLabel: ""}},
+			Spec:       corev1.PodSpec{NodeName: "node-b"},
+			Status: corev1.PodStatus{
+				Phase:             corev1.PodPending,
+				ContainerStatuses: []corev1.ContainerStatus{{State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}}}},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "driver-a", Namespace: "nvidia-network-operator", Labels: map[string]string{ofedDriverLabel: ""}},
+			Spec:       corev1.PodSpec{NodeName: "node-a"},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		},
+		// Not a driver pod
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "operator", Namespace: "nvidia-network-operator"}},
+	}
+	// A warning from before the wait, a normal event and more recent warnings than shown
+	objects = append(objects,
+		progressEvent("old", corev1.EventTypeWarning, since.Add(-time.Minute)),
+		progressEvent("normal", corev1.EventTypeNormal, since.Add(time.Second)))
+	for i := range maxWarningEvents + 1 {
+		objects = append(objects, progressEvent(fmt.Sprintf("warning-%d", i), corev1.EventTypeWarning, since.Add(time.Duration(i+1)*time.Second)))
+	}
+	c := fake.NewClientBuilder().WithScheme(kubeclient.NewScheme()).WithObjects(objects...).Build()
+
+	r := newProgressReporter(c, "nvidia-network-operator")
+	r.since = since
+	policy := &netop.NicClusterPolicy{Status: netop.NicClusterPolicyStatus{
+		State:         netop.StateNotReady,
+		AppliedStates: []netop.AppliedState{{Name: "state-OFED", State: netop.StateNotReady}},
+	}}
+	progress := r.collect(context.Background(), policy)
+
+	want := rolloutProgress{
+		State:      netop.StateNotReady,
+		Components: policy.Status.AppliedStates,
+		DriverPods: []driverPodState{
+			{Node: "node-a", Phase: corev1.PodRunning, Ready: true},
+			{Node: "node-b", Phase: corev1.PodPending, Reason: "ImagePullBackOff"},
+		},
+	}
+	for i := 1; i <= maxWarningEvents; i++ {
+		want.Warnings = append(want.Warnings, fmt.Sprintf("Pod/driver-a: warning-%d: pulling failed", i))
+	}
+	if !reflect.DeepEqual(progress, want) {
+		t.Errorf("collect() =\n%+v\nwant\n%+v", progress, want)
+	}
+}
+
+func progressEvent(reason, eventType string, at time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: reason, Namespace: "nvidia-network-operator"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "driver-a"},
+		Type:           eventType,
+		Reason:         reason,
+		Message:        "pulling failed\n",
+		LastTimestamp:  metav1.NewTime(at),
+	}
+}
+
+func TestProgressReporterRenderKeepsOtherOutput(t *testing.T) {
+	out := &bytes.Buffer{}
+	writes := uint64(0)
+	r := &progressReporter{out: out, tty: true, since: time.Now(), written: func() uint64 { return writes }}
+	progress := rolloutProgress{
+		State:      netop.StateNotReady,
+		Components: []netop.AppliedState{{Name: "state-OFED", State: netop.StateNotReady}},
+	}
+	const cursorUp = "\033[2A\033[J"
+
+	r.render(progress)
+	if strings.Contains(out.String(), "\033[") {
+		t.Fatalf("the first view must not move the cursor: %q", out.String())
+	}
+
+	out.Reset()
+	r.render(progress)
+	if !strings.HasPrefix(out.String(), cursorUp) {
+		t.Errorf("an unchanged stream must be redrawn in place: %q", out.String())
+	}
+
+	// A log line was written below the view, it must not be overwritten
+	writes++
+	out.Reset()
+	r.render(progress)
+	if strings.Contains(out.String(), "\033[") {
+		t.Errorf("the view must be printed anew after other output: %q", out.String())
+	}
+
+	out.Reset()
+	r.render(progress)
+	if !strings.HasPrefix(out.String(), cursorUp) {
+		t.Errorf("the new view must be redrawn in place: %q", out.String())
+	}
+
+	// Output after Done is never overwritten
+	r.Done()
+	out.Reset()
+	r.render(progress)
+	if strings.Contains(out.String(), "\033[") {
+		t.Errorf("the view must be printed anew after Done: %q", out.String())
+	}
+}
+
+func TestProgressReporterLogThrottle(t *testing.T) {
+	r := &progressReporter{}
+	start := time.Now()
+
+	steps := []struct {
+		summary string
+		at      time.Duration
+		want    bool
+	}{
+		{summary: "notReady", at: 0, want: true},
+		{summary: "notReady", at: 10 * time.Second, want: false},
+		{summary: "notReady", at: 29 * time.Second, want: false},
+		{summary: "notReady", at: progressLogInterval, want: true},
+		{summary: "ready", at: progressLogInterval + time.Second, want: true},
+		{summary: "ready", at: progressLogInterval + 2*time.Second, want: false},
+	}
+	for _, step := range steps {
+		if got := r.logDue(step.summary, start.Add(step.at)); got != step.want {
+			t.Errorf("logDue(%q) at %s = %v, want %v", step.summary, step.at, got, step.want)
+		}
+	}
+}
@@ -0,0 +1,397 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package networkoperatorplugin
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"slices"
+	"sort"
+	"strings"
+
+	netop "github.com/Mellanox/network-operator/api/v1alpha1"
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+	"github.com/nvidia/k8s-launch-kit/pkg/plugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// objectMissing is the state of the objects of the profile that are not found in the cluster
+	objectMissing = "Missing"
+	// objectPresent is the state of the found objects without a status
+	objectPresent = "Present"
+	// nodeReady and nodeNotReady are the states of the worker nodes found in the cluster, after their Ready condition
+	nodeReady    = "Ready"
+	nodeNotReady = "NotReady"
+)
+
+// sriovNetworkNodeStateGVK is the per node state of the SR-IOV network operator, named after the node
+var sriovNetworkNodeStateGVK = schema.GroupVersionKind{Group: "sriovnetwork.openshift.io", Version: "v1", Kind: "SriovNetworkNodeState"}
+
+// ProfileStatus reports the state of the deployed objects of the profile in the cluster, the NicClusterPolicy applied states,
+// the SR-IOV node states and allocatable resources of the worker nodes, and the allocation usage of the nv-ipam pools
+func (p *NetworkOperatorPlugin) ProfileStatus(ctx context.Context, profile *profiles.Profile, cfg *config.LaunchKubernetesConfig, deployed map[string]string, c client.Client) (*plugin.ProfileStatus, error) {
+	rendered := deployed
+	if rendered == nil {
+		var err error
+		if rendered, err = generateDeploymentFiles(profile, cfg); err != nil {
+			return nil, err
+		}
+	}
+	expected, err := decodeRendered(rendered)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &plugin.ProfileStatus{Profile: profile.Name, Plugin: p.GetName(), Healthy: true}
+	found := []*unstructured.Unstructured{}
+	for _, obj := range expected {
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(obj.GroupVersionKind())
+		err := c.Get(ctx, client.ObjectKeyFromObject(obj), live)
+		if apierrors.IsNotFound(err) {
+			status.Objects = append(status.Objects, plugin.ObjectStatus{Kind: obj.GetKind(), Namespace: obj.GetNamespace(), Name: obj.GetName(), State: objectMissing, InProfile: true})
+			status.Healthy = false
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s %s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		objectStatus, healthy := liveObjectStatus(live)
+		objectStatus.InProfile = true
+		status.Objects = append(status.Objects, objectStatus)
+		status.Healthy = status.Healthy && healthy
+		found = append(found, live)
+	}
+
+	leftovers, err := managedLeftovers(ctx, c, expected)
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range leftovers {
+		objectStatus, _ := liveObjectStatus(obj)
+		status.Objects = append(status.Objects, objectStatus)
+	}
+
+	for _, obj := range found {
+		switch obj.GetKind() {
+		case "NicClusterPolicy":
+			policy := &netop.NicClusterPolicy{}
+			if err := convertUnstructured(obj, policy); err != nil {
+				return nil, err
+			}
+			for _, state := range policy.Status.AppliedStates {
+				status.Components = append(status.Components, plugin.ComponentStatus{Name: state.Name, State: string(state.State), Message: state.Message})
+				status.Healthy = status.Healthy && (state.State == netop.StateReady || state.State == netop.StateIgnore)
+			}
+		case "IPPool", "CIDRPool":
+			pool, err := poolStatus(obj)
+			if err != nil {
+				return nil, err
+			}
+			status.Pools = append(status.Pools, *pool)
+		}
+	}
+
+	sriov := slices.ContainsFunc(expected, func(obj *unstructured.Unstructured) bool { return obj.GetKind() == "SriovNetworkNodePolicy" })
+	nodes, err := nodesStatus(ctx, c, cfg, workloadResources(expected), sriov)
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes {
+		if node.State != nodeReady {
+			status.Healthy = false
+		}
+		if node.SyncStatus != "" && node.SyncStatus != "Succeeded" {
+			status.Healthy = false
+		}
+		for _, allocatable := range node.Allocatable {
+			if allocatable == "0" {
+				status.Healthy = false
+			}
+		}
+	}
+	status.Nodes = nodes
+
+	return status, nil
+}
+
+// decodeRendered decodes the rendered deployment files, in file name order
+func decodeRendered(rendered map[string]string) ([]*unstructured.Unstructured, error) {
+	names := make([]string, 0, len(rendered))
+	for name := range rendered {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	objects := []*unstructured.Unstructured{}
+	for _, name := range names {
+		decoded, err := kubeclient.DecodeManifests([]byte(rendered[name]))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode deployment file %s: %w", name, err)
+		}
+		objects = append(objects, decoded...)
+	}
+	return objects, nil
+}
+
+// liveObjectStatus returns the status of an object found in the cluster and whether it is healthy
+func liveObjectStatus(obj *unstructured.Unstructured) (plugin.ObjectStatus, bool) {
+	status := plugin.ObjectStatus{
+		Kind:      obj.GetKind(),
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		State:     objectPresent,
+		Managed:   obj.GetLabels()[kubeclient.ManagedByLabel] == kubeclient.FieldOwner,
+	}
+	healthy := true
+
+	switch obj.GetKind() {
+	case "Pod":
+		pod := &corev1.Pod{}
+		if err := convertUnstructured(obj, pod); err == nil {
+			status.State = string(cmp.Or(pod.Status.Phase, corev1.PodPending))
+			status.Message = pod.Status.Message
+			healthy = pod.Status.Phase == corev1.PodRunning || pod.Status.Phase == corev1.PodSucceeded
+		}
+	case "Job":
+		job := &batchv1.Job{}
+		if err := convertUnstructured(obj, job); err == nil {
+			status.State = "Running"
+			for _, condition := range job.Status.Conditions {
+				if condition.Status == corev1.ConditionTrue && (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) {
+					status.State, status.Message = string(condition.Type), condition.Message
+				}
+			}
+			healthy = status.State != string(batchv1.JobFailed)
+		}
+	default:
+		// Network Operator resources report a state, e.g. NicClusterPolicy and the secondary networks
+		if state, ok, _ := unstructured.NestedString(obj.Object, "status", "state"); ok && state != "" {
+			status.State = state
+			status.Message, _, _ = unstructured.NestedString(obj.Object, "status", "reason")
+			healthy = state == string(netop.StateReady)
+		}
+	}
+
+	return status, healthy
+}
+
+// managedLeftovers returns the objects of the kinds of the profile managed by l8k that the profile does not render,
+// e.g. the rail networks of a previous multirail deployment
+func managedLeftovers(ctx context.Context, c client.Client, expected []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	kinds := []schema.GroupVersionKind{}
+	keys := map[string]bool{}
+	for _, obj := range expected {
+		if gvk := obj.GroupVersionKind(); !slices.Contains(kinds, gvk) {
+			kinds = append(kinds, gvk)
+		}
+		keys[objectKey(obj)] = true
+	}
+
+	leftovers := []*unstructured.Unstructured{}
+	for _, gvk := range kinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := c.List(ctx, list, client.MatchingLabels{kubeclient.ManagedByLabel: kubeclient.FieldOwner}); err != nil {
+			return nil, fmt.Errorf("failed to list %s objects managed by l8k: %w", gvk.Kind, err)
+		}
+		for i := range list.Items {
+			if !keys[objectKey(&list.Items[i])] {
+				leftovers = append(leftovers, &list.Items[i])
+			}
+		}
+	}
+	return leftovers, nil
+}
+
+func objectKey(obj *unstructured.Unstructured) string {
+	return strings.Join([]string{obj.GetKind(), obj.GetNamespace(), obj.GetName()}, "/")
+}
+
+// workloadResources returns the device plugin resources requested by the pods and jobs of the profile,
+// i.e. the resources of its networks, except the GPUs
+func workloadResources(objects []*unstructured.Unstructured) []string {
+	resources := []string{}
+	for _, obj := range objects {
+		spec := &corev1.PodSpec{}
+		var err error
+		switch obj.GetKind() {
+		case "Pod":
+			pod := &corev1.Pod{}
+			err = convertUnstructured(obj, pod)
+			spec = &pod.Spec
+		case "Job":
+			job := &batchv1.Job{}
+			err = convertUnstructured(obj, job)
+			spec = &job.Spec.Template.Spec
+		default:
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		for _, container := range spec.Containers {
+			for name := range container.Resources.Limits {
+				if strings.Contains(string(name), "/") && name != "nvidia.com/gpu" && !slices.Contains(resources, string(name)) {
+					resources = append(resources, string(name))
+				}
+			}
+		}
+	}
+	sort.Strings(resources)
+	return resources
+}
+
+// nodesStatus returns the state, the allocatable resources and, for SR-IOV profiles, the SR-IOV node state of the worker nodes.
+// The worker nodes of the cluster config that are no longer in the cluster are reported as missing.
+func nodesStatus(ctx context.Context, c client.Client, cfg *config.LaunchKubernetesConfig, resources []string, sriov bool) ([]plugin.NodeStatus, error) {
+	names := []string{}
+	if cfg.ClusterConfig != nil {
+		names = cfg.ClusterConfig.WorkerNodes
+	}
+	if len(names) == 0 {
+		nodes := &corev1.NodeList{}
+		if err := c.List(ctx, nodes); err != nil {
+			return nil, fmt.Errorf("failed to list nodes: %w", err)
+		}
+		for _, node := range nodes.Items {
+			names = append(names, node.Name)
+		}
+	}
+
+	statuses := []plugin.NodeStatus{}
+	for _, name := range names {
+		status := plugin.NodeStatus{Node: name, State: nodeNotReady, Allocatable: map[string]string{}}
+		node := &corev1.Node{}
+		err := c.Get(ctx, client.ObjectKey{Name: name}, node)
+		if apierrors.IsNotFound(err) {
+			status.State = objectMissing
+			statuses = append(statuses, status)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get node %s: %w", name, err)
+		}
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+				status.State = nodeReady
+			}
+		}
+		for _, resource := range resources {
+			quantity := node.Status.Allocatable[corev1.ResourceName(resource)]
+			status.Allocatable[resource] = quantity.String()
+		}
+
+		if sriov {
+			state := &unstructured.Unstructured{}
+			state.SetGroupVersionKind(sriovNetworkNodeStateGVK)
+			err := c.Get(ctx, client.ObjectKey{Name: name, Namespace: cfg.NetworkOperator.Namespace}, state)
+			switch {
+			case apierrors.IsNotFound(err):
+				status.SyncStatus = objectMissing
+			case err != nil:
+				return nil, fmt.Errorf("failed to get the SR-IOV node state of node %s: %w", name, err)
+			default:
+				status.SyncStatus, _, _ = unstructured.NestedString(state.Object, "status", "syncStatus")
+				status.SyncError, _, _ = unstructured.NestedString(state.Object, "status", "lastSyncError")
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// poolStatus returns the allocation usage of an nv-ipam IPPool or CIDRPool
+func poolStatus(obj *unstructured.Unstructured) (*plugin.PoolStatus, error) {
+	pool := struct {
+		Spec struct {
+			Subnet string `json:"subnet"`
+			CIDR   string `json:"cidr"`
+		} `json:"spec"`
+		Status struct {
+			Allocations []struct {
+				NodeName string `json:"nodeName"`
+				StartIP  string `json:"startIP"`
+				EndIP    string `json:"endIP"`
+				Prefix   string `json:"prefix"`
+			} `json:"allocations"`
+		} `json:"status"`
+	}{}
+	if err := convertUnstructured(obj, &pool); err != nil {
+		return nil, err
+	}
+
+	status := &plugin.PoolStatus{Kind: obj.GetKind(), Name: obj.GetName(), Subnet: pool.Spec.Subnet, Nodes: len(pool.Status.Allocations)}
+	if obj.GetKind() == "CIDRPool" {
+		status.Subnet = pool.Spec.CIDR
+	}
+	if subnet, err := netip.ParsePrefix(status.Subnet); err == nil {
+		status.Capacity = usableAddresses(subnet)
+	}
+	for _, allocation := range pool.Status.Allocations {
+		if allocation.Prefix != "" {
+			if prefix, err := netip.ParsePrefix(allocation.Prefix); err == nil {
+				status.Allocated += usableAddresses(prefix)
+			}
+			continue
+		}
+		start, errStart := netip.ParseAddr(allocation.StartIP)
+		end, errEnd := netip.ParseAddr(allocation.EndIP)
+		if errStart == nil && errEnd == nil {
+			status.Allocated += rangeSize(start, end)
+		}
+	}
+	return status, nil
+}
+
+// rangeSize returns the number of addresses from start to end, capped like usableAddresses
+func rangeSize(start, end netip.Addr) int {
+	a, b := start.As16(), end.As16()
+	size := 0
+	for i := range a {
+		size = size<<8 + int(b[i]) - int(a[i])
+		if size >= 1<<30 {
+			return 1 << 30
+		}
+	}
+	return max(size+1, 0)
+}
+
+// convertUnstructured converts obj to a typed object through JSON
+func convertUnstructured(obj *unstructured.Unstructured, into any) error {
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, into); err != nil {
+		return fmt.Errorf("failed to decode %s %s: %w", obj.GetKind(), obj.GetName(), err)
+	}
+	return nil
+}
+
+var _ plugin.StatusReporter = &NetworkOperatorPlugin{}
@@ -0,0 +1,280 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package networkoperatorplugin
+
+import (
+	"context"
+	"net/netip"
+	"reflect"
+	"testing"
+
+	netop "github.com/Mellanox/network-operator/api/v1alpha1"
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+	"github.com/nvidia/k8s-launch-kit/pkg/plugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	sigsyaml "sigs.k8s.io/yaml"
+)
+
+// decodeObject decodes a single object from YAML
+func decodeObject(t *testing.T, manifest string) *unstructured.Unstructured {
+	t.Helper()
+	obj := &unstructured.Unstructured{}
+	if err := sigsyaml.Unmarshal([]byte(manifest), &obj.Object); err != nil {
+		t.Fatal(err)
+	}
+	return obj
+}
+
+func TestLiveObjectStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		manifest    string
+		want        plugin.ObjectStatus
+		wantHealthy bool
+	}{
+		{
+			name: "running pod",
+			manifest: `{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "test", "namespace": "default",
+				"labels": {"app.kubernetes.io/managed-by": "l8k"}}, "status": {"phase": "Running"}}`,
+			want:        plugin.ObjectStatus{Kind: "Pod", Namespace: "default", Name: "test", State: "Running", Managed: true},
+			wantHealthy: true,
+		},
+		{
+			name:     "pod without phase",
+			manifest: `{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "test", "namespace": "default"}}`,
+			want:     plugin.ObjectStatus{Kind: "Pod", Namespace: "default", Name: "test", State: "Pending"},
+		},
+		{
+			name:     "failed pod",
+			manifest: `{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "test"}, "status": {"phase": "Failed", "message": "evicted"}}`,
+			want:     plugin.ObjectStatus{Kind: "Pod", Name: "test", State: "Failed", Message: "evicted"},
+		},
+		{
+			name:        "running job",
+			manifest:    `{"apiVersion": "batch/v1", "kind": "Job", "metadata": {"name": "nccl-test"}, "status": {"active": 2}}`,
+			want:        plugin.ObjectStatus{Kind: "Job", Name: "nccl-test", State: "Running"},
+			wantHealthy: true,
+		},
+		{
+			name: "complete job",
+			manifest: `{"apiVersion": "batch/v1", "kind": "Job", "metadata": {"name": "nccl-test"},
+				"status": {"conditions": [{"type": "Complete", "status": "True"}]}}`,
+			want:        plugin.ObjectStatus{Kind: "Job", Name: "nccl-test", State: "Complete"},
+			wantHealthy: true,
+		},
+		{
+			name: "failed job",
+			manifest: `{"apiVersion": "batch/v1", "kind": "Job", "metadata": {"name": "nccl-test"},
+				"status": {"conditions": [{"type": "Failed", "status": "True", "message": "BackoffLimitExceeded"}]}}`,
+			want: plugin.ObjectStatus{Kind: "Job", Name: "nccl-test", State: "Failed", Message: "BackoffLimitExceeded"},
+		},
+		{
+			name:        "ready NicClusterPolicy",
+			manifest:    `{"apiVersion": "mellanox.com/v1alpha1", "kind": "NicClusterPolicy", "metadata": {"name": "nic-cluster-policy"}, "status": {"state": "ready"}}`,
+			want:        plugin.ObjectStatus{Kind: "NicClusterPolicy", Name: "nic-cluster-policy", State: "ready"},
+			wantHealthy: true,
+		},
+		{
+			name: "NicClusterPolicy not ready",
+			manifest: `{"apiVersion": "mellanox.com/v1alpha1", "kind": "NicClusterPolicy", "metadata": {"name": "nic-cluster-policy"},
+				"status": {"state": "notReady", "reason": "DOCA driver not ready"}}`,
+			want: plugin.ObjectStatus{Kind: "NicClusterPolicy", Name: "nic-cluster-policy", State: "notReady", Message: "DOCA driver not ready"},
+		},
+		{
+			name:        "object without status",
+			manifest:    `{"apiVersion": "sriovnetwork.openshift.io/v1", "kind": "SriovNetwork", "metadata": {"name": "rail0", "namespace": "nvidia-network-operator"}}`,
+			want:        plugin.ObjectStatus{Kind: "SriovNetwork", Namespace: "nvidia-network-operator", Name: "rail0", State: "Present"},
+			wantHealthy: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, healthy := liveObjectStatus(decodeObject(t, tt.manifest))
+			if !reflect.DeepEqual(got, tt.want) || healthy != tt.wantHealthy {
+				t.Errorf("liveObjectStatus() = %+v, %v, want %+v, %v", got, healthy, tt.want, tt.wantHealthy)
+			}
+		})
+	}
+}
+
+func TestPoolStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		want     plugin.PoolStatus
+	}{
+		{
+			name: "IPPool",
+			manifest: `{"apiVersion": "nv-ipam.nvidia.com/v1alpha1", "kind": "IPPool", "metadata": {"name": "rail0"},
+				"spec": {"subnet": "192.168.0.0/24", "perNodeBlockSize": 16},
+				"status": {"allocations": [
+					{"nodeName": "node-a", "startIP": "192.168.0.1", "endIP": "192.168.0.16"},
+					{"nodeName": "node-b", "startIP": "192.168.0.17", "endIP": "192.168.0.32"}]}}`,
+			want: plugin.PoolStatus{Kind: "IPPool", Name: "rail0", Subnet: "192.168.0.0/24", Nodes: 2, Allocated: 32, Capacity: 254},
+		},
+		{
+			name: "CIDRPool",
+			manifest: `{"apiVersion": "nv-ipam.nvidia.com/v1alpha1", "kind": "CIDRPool", "metadata": {"name": "rail0"},
+				"spec": {"cidr": "10.0.0.0/16", "perNodeNetworkPrefix": 24},
+				"status": {"allocations": [{"nodeName": "node-a", "prefix": "10.0.0.0/24"}, {"nodeName": "node-b", "prefix": "10.0.1.0/24"}]}}`,
+			want: plugin.PoolStatus{Kind: "CIDRPool", Name: "rail0", Subnet: "10.0.0.0/16", Nodes: 2, Allocated: 508, Capacity: 65534},
+		},
+		{
+			name: "Spectrum-X /31 CIDRPool",
+			manifest: `{"apiVersion": "nv-ipam.nvidia.com/v1alpha1", "kind": "CIDRPool", "metadata": {"name": "rail0"},
+				"spec": {"cidr": "172.16.0.0/24", "perNodeNetworkPrefix": 31},
+				"status": {"allocations": [{"nodeName": "node-a", "prefix": "172.16.0.0/31"}]}}`,
+			want: plugin.PoolStatus{Kind: "CIDRPool", Name: "rail0", Subnet: "172.16.0.0/24", Nodes: 1, Allocated: 2, Capacity: 254},
+		},
+		{
+			name: "no allocations yet",
+			manifest: `{"apiVersion": "nv-ipam.nvidia.com/v1alpha1", "kind": "IPPool", "metadata": {"name": "rail0"},
+				"spec": {"subnet": "fd00::/64"}}`,
+			want: plugin.PoolStatus{Kind: "IPPool", Name: "rail0", Subnet: "fd00::/64", Capacity: 1 << 30},
+		},
+		{
+			name: "invalid addresses are not counted",
+			manifest: `{"apiVersion": "nv-ipam.nvidia.com/v1alpha1", "kind": "IPPool", "metadata": {"name": "rail0"},
+				"spec": {"subnet": "invalid"}, "status": {"allocations": [{"nodeName": "node-a", "startIP": "x", "endIP": "y"}]}}`,
+			want: plugin.PoolStatus{Kind: "IPPool", Name: "rail0", Subnet: "invalid", Nodes: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := poolStatus(decodeObject(t, tt.manifest))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("poolStatus() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRangeSize(t *testing.T) {
+	tests := []struct {
+		start, end string
+		want       int
+	}{
+		{start: "192.168.0.1", end: "192.168.0.1", want: 1},
+		{start: "192.168.0.1", end: "192.168.0.16", want: 16},
+		{start: "10.0.0.250", end: "10.0.1.5", want: 12},
+		{start: "10.0.0.0", end: "10.255.255.255", want: 1 << 24},
+		{start: "192.168.0.16", end: "192.168.0.1", want: 0},
+		{start: "fd00::1", end: "fd00::ff", want: 255},
+		{start: "fd00::", end: "fd00::ffff:ffff:ffff:ffff", want: 1 << 30},
+	}
+	for _, tt := range tests {
+		if got := rangeSize(netip.MustParseAddr(tt.start), netip.MustParseAddr(tt.end)); got != tt.want {
+			t.Errorf("rangeSize(%s, %s) = %d, want %d", tt.start, tt.end, got, tt.want)
+		}
+	}
+}
+
+func TestWorkloadResources(t *testing.T) {
+	objects := []*unstructured.Unstructured{
+		decodeObject(t, `{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "test-rail0"}, "spec": {"containers": [
+			{"name": "test", "resources": {"limits": {"nvidia.com/rail0": "1", "cpu": "1", "nvidia.com/gpu": "1"}}}]}}`),
+		decodeObject(t, `{"apiVersion": "batch/v1", "kind": "Job", "metadata": {"name": "nccl-test"}, "spec": {"template": {"spec": {"containers": [
+			{"name": "nccl", "resources": {"limits": {"nvidia.com/rail1": "1", "nvidia.com/rail0": "1", "memory": "1Gi"}}}]}}}}`),
+		decodeObject(t, `{"apiVersion": "mellanox.com/v1alpha1", "kind": "NicClusterPolicy", "metadata": {"name": "nic-cluster-policy"}}`),
+	}
+
+	if got, want := workloadResources(objects), []string{"nvidia.com/rail0", "nvidia.com/rail1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("workloadResources() = %v, want %v", got, want)
+	}
+	if got := workloadResources(objects[2:]); len(got) != 0 {
+		t.Errorf("workloadResources() without workloads = %v, want none", got)
+	}
+}
+
+func TestProfileStatusReportsMissingNodes(t *testing.T) {
+	deployed := map[string]string{
+		"10-nicclusterpolicy.yaml": "apiVersion: mellanox.com/v1alpha1\nkind: NicClusterPolicy\nmetadata:\n  name: nic-cluster-policy\n",
+		"90-test-pod.yaml": "apiVersion: v1\nkind: Pod\nmetadata:\n  name: test-rail0\n  namespace: default\nspec:\n  containers:\n" +
+			"  - name: test\n    resources:\n      limits:\n        nvidia.com/rail0: \"1\"\n",
+	}
+	objects := []client.Object{
+		&netop.NicClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "nic-cluster-policy"},
+			Status: netop.NicClusterPolicyStatus{State: netop.StateReady,
+				AppliedStates: []netop.AppliedState{{Name: "state-OFED", State: netop.StateReady}}},
+		},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-rail0", Namespace: "default"}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-a"},
+			Status: corev1.NodeStatus{
+				Conditions:  []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+				Allocatable: corev1.ResourceList{"nvidia.com/rail0": resource.MustParse("8")},
+			},
+		},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-b"},
+			Status: corev1.NodeStatus{
+				Conditions:  []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionFalse}},
+				Allocatable: corev1.ResourceList{"nvidia.com/rail0": resource.MustParse("8")},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(kubeclient.NewScheme()).WithObjects(objects...).Build()
+	cfg := &config.LaunchKubernetesConfig{
+		NetworkOperator: &config.NetworkOperatorConfig{Namespace: "nvidia-network-operator"},
+		// node-c was removed from the cluster since the discovery
+		ClusterConfig: &config.ClusterConfig{WorkerNodes: []string{"node-a", "node-b", "node-c"}},
+	}
+	profile := &profiles.Profile{Name: "SR-IOV Ethernet RDMA"}
+
+	status, err := (&NetworkOperatorPlugin{}).ProfileStatus(context.Background(), profile, cfg, deployed, c)
+	if err != nil {
+		t.Fatalf("a missing node must be reported, not fail the status: %v", err)
+	}
+
+	wantNodes := []plugin.NodeStatus{
+		{Node: "node-a", State: "Ready", Allocatable: map[string]string{"nvidia.com/rail0": "8"}},
+		{Node: "node-b", State: "NotReady", Allocatable: map[string]string{"nvidia.com/rail0": "8"}},
+		{Node: "node-c", State: "Missing", Allocatable: map[string]string{}},
+	}
+	if !reflect.DeepEqual(status.Nodes, wantNodes) {
+		t.Errorf("Nodes = %+v, want %+v", status.Nodes, wantNodes)
+	}
+	if status.Healthy {
+		t.Errorf("the profile must be unhealthy with a missing node")
+	}
+	if len(status.Objects) != 2 || status.Objects[0].State != "ready" || status.Objects[1].State != "Running" {
+		t.Errorf("the deployed objects must be reported: %+v", status.Objects)
+	}
+
+	// The deployed files are the expected state, not the profile rendered with the current config
+	cfg.ClusterConfig.WorkerNodes = []string{"node-a"}
+	status, err = (&NetworkOperatorPlugin{}).ProfileStatus(context.Background(), profile, cfg, deployed, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Healthy {
+		t.Errorf("the deployed objects and nodes are healthy: %+v", status)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package networkoperatorplugin
+
+import (
+	"fmt"
+	"math/bits"
+	"net/netip"
+	"slices"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// carveSubnets completes nvIpam.subnets from nvIpam.supernet, with a subnet per east-west PF in rail order and its first address
+// as gateway; north-south PFs get no pool. Listed subnets are kept, so that a plan recorded in a saved config stays stable when
+// the cluster changes. The missing subnets are the lowest blocks of the supernet that overlap neither the listed subnets nor
+// the cluster CIDRs, all of the same size: the block planNvIpam needs per node for an address per VF (sriov.numVfs) and
+// the gateway, for nodeHeadroom times the worker nodes.
+func carveSubnets(cfg *config.LaunchKubernetesConfig) error {
+	if cfg.NvIpam == nil || cfg.NvIpam.Supernet == "" || cfg.ClusterConfig == nil {
+		return nil
+	}
+	rails := len(cfg.ClusterConfig.EastWestRails())
+	if len(cfg.NvIpam.Subnets) >= rails {
+		return nil
+	}
+
+	supernet, err := netip.ParsePrefix(cfg.NvIpam.Supernet)
+	if err != nil {
+		return fmt.Errorf("invalid nvIpam supernet %q: %w", cfg.NvIpam.Supernet, err)
+	}
+	if supernet != supernet.Masked() {
+		return fmt.Errorf("nvIpam supernet %s has host bits set, use %s", supernet, supernet.Masked())
+	}
+
+	perNode := 1
+	if cfg.Sriov != nil {
+		perNode = max(cfg.Sriov.NumVfs, 1)
+	}
+	workers := max(len(cfg.ClusterConfig.WorkerNodes), 1)
+	prefix := subnetPrefix(supernet.Addr().BitLen(), workers*nodeHeadroom, perNode, cfg.NvIpam.PoolType)
+	if prefix < supernet.Bits() {
+		return fmt.Errorf("nvIpam supernet %s is smaller than the /%d subnet needed per rail for %d nodes, %d worker nodes and room for more, with %d addresses each",
+			supernet, prefix, workers*nodeHeadroom, workers, perNode)
+	}
+
+	// Blocks of the supernet already in use
+	used := []netip.Prefix{}
+	for _, subnet := range cfg.NvIpam.Subnets {
+		if p, err := netip.ParsePrefix(subnet.Subnet); err == nil {
+			used = append(used, p)
+		}
+	}
+	cidrs, err := parseClusterCIDRs(cfg.ClusterConfig)
+	if err != nil {
+		return err
+	}
+	for _, cidr := range cidrs {
+		used = append(used, cidr.prefix)
+	}
+
+	carved := []string{}
+	for next := supernet.Addr(); len(cfg.NvIpam.Subnets) < rails; {
+		if !next.IsValid() || !supernet.Contains(next) {
+			return fmt.Errorf("nvIpam supernet %s has no free /%d subnet left for %d rails, use a larger supernet", supernet, prefix, rails)
+		}
+		block := netip.PrefixFrom(next, prefix)
+		if !slices.ContainsFunc(used, block.Overlaps) {
+			cfg.NvIpam.Subnets = append(cfg.NvIpam.Subnets, config.NvIpamSubnetConfig{Subnet: block.String(), Gateway: next.Next().String()})
+			used = append(used, block)
+			carved = append(carved, block.String())
+		}
+		next = lastAddr(block).Next()
+	}
+
+	log.Log.Info("Carved nvIpam subnets from the supernet", "supernet", supernet, "subnets", carved)
+	return nil
+}
+
+// subnetPrefix returns the prefix length of a subnet with perNode addresses and a gateway for each of the nodes.
+// IPPool subnets hold a block per node of the size planIPPool requires, plus the network and broadcast addresses.
+// CIDRPool subnets are made of equal per-node subnets, each with its own network, broadcast and gateway addresses.
+func subnetPrefix(bitLen, nodes, perNode int, poolType string) int {
+	if poolType == config.PoolTypeCIDRPool {
+		return bitLen - bits.Len(uint(perNode+3-1)) - bits.Len(uint(nodes-1))
+	}
+	return bitLen - bits.Len(uint(nodes*ipPoolBlockSize(perNode, true)+2-1))
+}
+
+// lastAddr returns the last address of a prefix
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	addr := prefix.Masked().Addr()
+	bytes := addr.As16()
+	offset := 128 - addr.BitLen()
+	for i := prefix.Bits() + offset; i < 128; i++ {
+		bytes[i/8] |= 1 << (7 - i%8)
+	}
+	last := netip.AddrFrom16(bytes)
+	if addr.Is4() {
+		return last.Unmap()
+	}
+	return last
+}
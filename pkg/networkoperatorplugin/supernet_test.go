@@ -0,0 +1,97 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package networkoperatorplugin
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+)
+
+func TestCarveSubnets(t *testing.T) {
+	tests := []struct {
+		name        string
+		supernet    string
+		poolType    string
+		workers     int
+		numVfs      int
+		northSouth  int
+		wantSubnets []string
+		wantErr     string
+	}{
+		{name: "one VF per node", supernet: "10.0.0.0/16", workers: 29, numVfs: 1, wantSubnets: []string{"10.0.0.0/25", "10.0.0.128/25"}},
+		{name: "two VFs per node", supernet: "10.0.0.0/16", workers: 14, numVfs: 2, wantSubnets: []string{"10.0.0.0/25", "10.0.0.128/25"}},
+		{name: "north-south PFs", supernet: "10.0.0.0/16", workers: 2, numVfs: 4, northSouth: 2, wantSubnets: []string{"10.0.0.0/27", "10.0.0.32/27"}},
+		{name: "CIDRPool", supernet: "10.0.0.0/16", poolType: config.PoolTypeCIDRPool, workers: 4, numVfs: 1, wantSubnets: []string{"10.0.0.0/27", "10.0.0.32/27"}},
+		{name: "supernet too small", supernet: "10.0.0.0/26", workers: 29, numVfs: 1, wantErr: "smaller than the /25 subnet"},
+		{name: "supernet full", supernet: "10.0.0.0/25", workers: 29, numVfs: 1, wantErr: "no free /25 subnet left for 2 rails"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := carveTestConfig(tt.supernet, tt.poolType, tt.workers, tt.numVfs, 2, tt.northSouth)
+			err := carveSubnets(cfg)
+			checkPlanError(t, err, tt.wantErr)
+			if tt.wantErr != "" {
+				return
+			}
+			subnets := []string{}
+			for _, subnet := range cfg.NvIpam.Subnets {
+				subnets = append(subnets, subnet.Subnet)
+			}
+			if fmt.Sprint(subnets) != fmt.Sprint(tt.wantSubnets) {
+				t.Errorf("subnets = %v, want %v", subnets, tt.wantSubnets)
+			}
+		})
+	}
+}
+
+// TestCarvedSubnetsCanBePlanned checks that the pools of the carved subnets can be planned at the size boundaries
+func TestCarvedSubnetsCanBePlanned(t *testing.T) {
+	profile := &profiles.Profile{ProfileRequirements: profiles.ProfileRequirements{Deployment: profiles.Values{"sriov"}}}
+	for _, poolType := range []string{config.PoolTypeIPPool, config.PoolTypeCIDRPool} {
+		for workers := 1; workers <= 64; workers++ {
+			for numVfs := 1; numVfs <= 8; numVfs++ {
+				cfg := carveTestConfig("10.0.0.0/8", poolType, workers, numVfs, 1, 1)
+				if _, err := planNvIpam(profile, cfg); err != nil {
+					t.Errorf("%s, %d workers, %d VFs: %v", poolType, workers, numVfs, err)
+				}
+			}
+		}
+	}
+}
+
+func carveTestConfig(supernet, poolType string, workers, numVfs, eastWest, northSouth int) *config.LaunchKubernetesConfig {
+	cfg := &config.LaunchKubernetesConfig{
+		NvIpam:        &config.NvIpamConfig{PoolName: "nv-ipam-pool", Supernet: supernet, PoolType: poolType},
+		Sriov:         &config.SriovConfig{NumVfs: numVfs},
+		Profile:       &config.Profile{},
+		ClusterConfig: &config.ClusterConfig{},
+	}
+	for i := range northSouth + eastWest {
+		traffic := "east-west"
+		if i < northSouth {
+			traffic = "north-south"
+		}
+		cfg.ClusterConfig.PFs = append(cfg.ClusterConfig.PFs, config.PFConfig{PciAddress: fmt.Sprintf("0000:%02x:00.0", i+8), Traffic: traffic})
+	}
+	for i := range workers {
+		cfg.ClusterConfig.WorkerNodes = append(cfg.ClusterConfig.WorkerNodes, fmt.Sprintf("node-%d", i+1))
+	}
+	return cfg
+}
@@ -17,53 +17,43 @@
 package networkoperatorplugin
 
 import (
-	"bytes"
+	"context"
 	"fmt"
-	"os"
 	"path/filepath"
-	"text/template"
+	"slices"
 
 	"github.com/nvidia/k8s-launch-kit/pkg/config"
 	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
 )
 
-// templateFuncs provides helper functions for Go templates
-var templateFuncs = template.FuncMap{
-	"add": func(a, b int) int { return a + b },
-	"sub": func(a, b int) int { return a - b },
-	"gt":  func(a, b int) bool { return a > b },
+// spectrumXDeviceIDs are the PCI device IDs of the Spectrum-X capable NICs: BlueField-3 (as SuperNIC) and ConnectX-8
+var spectrumXDeviceIDs = []string{"a2dc", "1023"}
+
+// ProcessProfileTemplates processes all template files in a profile directory
+func (p *NetworkOperatorPlugin) GenerateProfileDeploymentFiles(_ context.Context, profile *profiles.Profile, config *config.LaunchKubernetesConfig) (map[string]string, error) {
+	return generateDeploymentFiles(profile, config)
 }
 
-// ProcessTemplate processes a Go template file with the given config
-func ProcessTemplate(templatePath string, config *config.LaunchKubernetesConfig) (string, error) {
-	// Read the template file
-	templateContent, err := os.ReadFile(templatePath)
-	if err != nil {
-		return "", fmt.Errorf("failed to read template file %s: %w", templatePath, err)
+// generateDeploymentFiles validates the profile against the discovered hardware and renders its templates
+func generateDeploymentFiles(profile *profiles.Profile, config *config.LaunchKubernetesConfig) (map[string]string, error) {
+	if err := validatePFs(profile, config); err != nil {
+		return nil, fmt.Errorf("profile %s cannot be deployed on the discovered hardware: %w", profile.Name, err)
 	}
-
-	// Parse the template with helper functions
-	tmpl, err := template.New(filepath.Base(templatePath)).Funcs(templateFuncs).Parse(string(templateContent))
-	if err != nil {
-		return "", fmt.Errorf("failed to parse template %s: %w", templatePath, err)
+	if err := validateSpectrumX(profile, config); err != nil {
+		return nil, fmt.Errorf("profile %s cannot be deployed on the discovered hardware: %w", profile.Name, err)
 	}
-
-	// Execute the template
-	var buf bytes.Buffer
-	err = tmpl.Execute(&buf, config)
+	if err := validateAI(profile, config); err != nil {
+		return nil, fmt.Errorf("profile %s cannot be deployed on the discovered hardware: %w", profile.Name, err)
+	}
+	planned, err := planNvIpam(profile, config)
 	if err != nil {
-		return "", fmt.Errorf("failed to execute template %s: %w", templatePath, err)
+		return nil, fmt.Errorf("profile %s cannot be deployed with the nvIpam settings: %w", profile.Name, err)
 	}
 
-	return buf.String(), nil
-}
-
-// ProcessProfileTemplates processes all template files in a profile directory
-func (p *NetworkOperatorPlugin) GenerateProfileDeploymentFiles(profile *profiles.Profile, config *config.LaunchKubernetesConfig) (map[string]string, error) {
 	results := make(map[string]string)
 
 	for _, templatePath := range profile.Templates {
-		processed, err := ProcessTemplate(templatePath, config)
+		processed, err := profiles.ProcessTemplate(templatePath, planned)
 		if err != nil {
 			return nil, fmt.Errorf("failed to process template %s: %w", templatePath, err)
 		}
@@ -73,3 +63,71 @@ func (p *NetworkOperatorPlugin) GenerateProfileDeploymentFiles(profile *profiles
 
 	return results, nil
 }
+
+// validatePFs checks the profile settings against the discovered limits of the east-west PFs.
+// Attributes that were not discovered are not validated.
+func validatePFs(profile *profiles.Profile, config *config.LaunchKubernetesConfig) error {
+	if !profile.ProfileRequirements.Deployment.Requires("sriov") || config.Sriov == nil || config.ClusterConfig == nil {
+		return nil
+	}
+
+	for _, pf := range config.ClusterConfig.PFs {
+		if pf.Traffic != "east-west" {
+			continue
+		}
+		if pf.SriovEnabled != nil && !*pf.SriovEnabled {
+			return fmt.Errorf("SR-IOV is disabled in the firmware of PF %s (%s)", pf.PciAddress, pf.NetworkInterface)
+		}
+		if pf.TotalVfs > 0 && config.Sriov.NumVfs > pf.TotalVfs {
+			return fmt.Errorf("sriov.numVfs (%d) exceeds the maximum number of VFs supported by PF %s (%d)", config.Sriov.NumVfs, pf.PciAddress, pf.TotalVfs)
+		}
+	}
+
+	return nil
+}
+
+// validateSpectrumX checks that the east-west PFs of a Spectrum-X profile are Spectrum-X capable.
+// Unknown device IDs are not validated, the /31 rail subnets are validated by planNvIpam.
+func validateSpectrumX(profile *profiles.Profile, config *config.LaunchKubernetesConfig) error {
+	if !profile.ProfileRequirements.SpectrumX.Requires("true") || config.ClusterConfig == nil {
+		return nil
+	}
+
+	for _, pf := range config.ClusterConfig.PFs {
+		if pf.Traffic != "east-west" {
+			continue
+		}
+		if pf.DeviceID != "" && !slices.Contains(spectrumXDeviceIDs, pf.DeviceID) {
+			return fmt.Errorf("PF %s (device ID %s %s) is not Spectrum-X capable, a BlueField-3 SuperNIC or a ConnectX-8 is required", pf.PciAddress, pf.DeviceID, pf.DeviceType)
+		}
+	}
+
+	return nil
+}
+
+// validateAI checks that an AI profile can align its rails with the GPUs: one east-west PF per GPU of a node
+func validateAI(profile *profiles.Profile, config *config.LaunchKubernetesConfig) error {
+	if !profile.ProfileRequirements.Ai.Requires("true") || config.ClusterConfig == nil {
+		return nil
+	}
+	if config.AI == nil {
+		return fmt.Errorf("ai section is missing from the config")
+	}
+	if config.AI.NcclConfigMap == "" || config.AI.TestImage == "" || config.AI.TestNodes < 1 {
+		return fmt.Errorf("ai.ncclConfigMap, ai.testImage and ai.testNodes (at least 1) are required")
+	}
+
+	gpusPerNode := 0
+	if config.ClusterConfig.Capabilities != nil && config.ClusterConfig.Capabilities.Nodes != nil {
+		gpusPerNode = config.ClusterConfig.Capabilities.Nodes.GpusPerNode
+	}
+	if gpusPerNode == 0 {
+		return fmt.Errorf("the number of GPUs per node is unknown, discover the cluster config or set clusterConfig.capabilities.nodes.gpusPerNode")
+	}
+
+	rails := len(config.ClusterConfig.EastWestRailNames())
+	if rails != gpusPerNode {
+		return fmt.Errorf("AI profiles need one east-west PF per GPU, found %d east-west PFs for %d GPUs per node", rails, gpusPerNode)
+	}
+	return nil
+}
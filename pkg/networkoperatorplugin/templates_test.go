@@ -0,0 +1,111 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package networkoperatorplugin
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	sigsyaml "sigs.k8s.io/yaml"
+)
+
+// loadTestConfig returns the default config of the repository with the given cluster config
+func loadTestConfig(t *testing.T, profile *config.Profile, cluster *config.ClusterConfig) *config.LaunchKubernetesConfig {
+	t.Helper()
+	cfg, err := config.LoadFullConfig("../../l8k-config.yaml", log.Log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Profile = profile
+	cfg.ClusterConfig = cluster
+	return cfg
+}
+
+func TestNcclConfigMapListsRailDevices(t *testing.T) {
+	cluster := &config.ClusterConfig{
+		Capabilities: &config.ClusterCapabilities{Nodes: &config.NodesCapabilities{Sriov: true, Rdma: true, Gpu: true}},
+		PFs: []config.PFConfig{
+			{PciAddress: "0000:08:00.0", RdmaDevice: "mlx5_0", Traffic: "east-west"},
+			{PciAddress: "0000:0c:00.0", RdmaDevice: "mlx5_1", Traffic: "north-south"},
+			{PciAddress: "0000:3b:00.0", RdmaDevice: "mlx5_2", Traffic: "east-west"},
+		},
+	}
+
+	for _, dir := range []string{"sriov-ethernet-ai", "sriov-ib-ai"} {
+		cfg := loadTestConfig(t, &config.Profile{Fabric: "ethernet", Deployment: "sriov", Multirail: true, Ai: true}, cluster)
+		rendered, err := profiles.ProcessTemplate("../../profiles/"+dir+"/50-nccl-configmap.yaml", cfg)
+		if err != nil {
+			t.Fatalf("%s: %v", dir, err)
+		}
+		configMap := &corev1.ConfigMap{}
+		if err := sigsyaml.Unmarshal([]byte(rendered), configMap); err != nil {
+			t.Fatalf("%s: %v", dir, err)
+		}
+
+		if got := configMap.Data["NCCL_IB_HCA"]; got != "=mlx5_0:1,mlx5_1:1" {
+			t.Errorf("%s: NCCL_IB_HCA = %q, want one device per rail", dir, got)
+		}
+		if _, found := configMap.Data["rails"]; found {
+			t.Errorf("%s: the rail map must not be injected as environment variable", dir)
+		}
+		if configMap.Annotations["l8k.nvidia.com/rails"] == "" {
+			t.Errorf("%s: rail map annotation missing", dir)
+		}
+	}
+}
+
+func TestRailPoolsUseTheSubnetOfTheirRail(t *testing.T) {
+	cluster := &config.ClusterConfig{
+		PFs: []config.PFConfig{
+			{PciAddress: "0000:08:00.0", Traffic: "east-west"},
+			{PciAddress: "0000:3b:00.0", Traffic: "east-west"},
+		},
+		// GPU order differs from the PF order
+		Rails: []config.RailConfig{{Name: "rail0", PciAddress: "0000:3b:00.0"}, {Name: "rail1", PciAddress: "0000:08:00.0"}},
+	}
+
+	for _, poolType := range []string{config.PoolTypeIPPool, config.PoolTypeCIDRPool} {
+		cfg := loadTestConfig(t, &config.Profile{Fabric: "ethernet", Deployment: "sriov", Multirail: true}, cluster)
+		cfg.NvIpam.PoolType = poolType
+		rendered, err := profiles.ProcessTemplate("../../profiles/sriov-ethernet-rdma/20-ippool.yaml", cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		objects, err := decodeRendered(map[string]string{"20-ippool.yaml": rendered})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		subnets := map[string]string{}
+		for _, obj := range objects {
+			subnet, _, _ := unstructured.NestedString(obj.Object, "spec", "subnet")
+			if cidr, found, _ := unstructured.NestedString(obj.Object, "spec", "cidr"); found {
+				subnet = cidr
+			}
+			subnets[obj.GetName()] = subnet
+		}
+		want := map[string]string{"nv-ipam-pool-rail0": cfg.NvIpam.Subnets[0].Subnet, "nv-ipam-pool-rail1": cfg.NvIpam.Subnets[1].Subnet}
+		if !reflect.DeepEqual(subnets, want) {
+			t.Errorf("%s: pools = %v, want %v", poolType, subnets, want)
+		}
+	}
+}
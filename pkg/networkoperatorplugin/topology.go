@@ -0,0 +1,220 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package networkoperatorplugin
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// gpuCountLabel is set on GPU nodes by the GPU Operator feature discovery
+	gpuCountLabel = "nvidia.com/gpu.count"
+	// gpuPresentLabel is set by NFD on nodes with NVIDIA PCI devices
+	gpuPresentLabel = "feature.node.kubernetes.io/pci-10de.present"
+)
+
+// PCIe distances between two devices, from closest to farthest, in nvidia-smi topo notation
+var pcieDistances = []string{
+	"PIX",  // at most a single PCIe switch between the devices
+	"PXB",  // multiple PCIe switches, without traversing the host bridge
+	"PHB",  // traverses the PCIe host bridge
+	"NODE", // traverses the interconnect between host bridges of the same NUMA node
+	"SYS",  // traverses the interconnect between NUMA nodes
+}
+
+// pcieDistance returns the index in pcieDistances for two devices given their sysfs paths
+// (e.g. pci0000:00/0000:00:01.0/0000:01:00.0/0000:02:08.0/0000:08:00.0) and NUMA nodes
+func pcieDistance(pathA, pathB string, numaA, numaB *int) int {
+	a := strings.Split(pathA, "/")
+	b := strings.Split(pathB, "/")
+
+	common := 0
+	for common < len(a)-1 && common < len(b)-1 && a[common] == b[common] {
+		common++
+	}
+
+	switch {
+	case common == 0 && numaA != nil && numaB != nil && *numaA == *numaB:
+		return 3
+	case common == 0:
+		return 4
+	case common == 1:
+		return 2
+	case len(a)-common <= 2 && len(b)-common <= 2:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// buildRailMap pairs every GPU of a node with its closest east-west PF, balancing GPUs between equally close PFs.
+// Rails are ordered by the first GPU they serve, so that rail N is the rail of GPU N; PFs without GPUs come last.
+func buildRailMap(pfs []config.PFConfig, node *nodeAttributes) []config.RailConfig {
+	gpus := slices.Clone(node.GPUs)
+	slices.SortFunc(gpus, func(a, b gpuAttributes) int { return strings.Compare(a.PciAddress, b.PciAddress) })
+
+	candidates := []config.PFConfig{}
+	for _, pf := range pfs {
+		if _, ok := node.PFs[pf.PciAddress]; ok && pf.Traffic == "east-west" {
+			candidates = append(candidates, pf)
+		}
+	}
+	if len(candidates) == 0 || len(gpus) == 0 {
+		return nil
+	}
+
+	rails := map[string]*config.RailConfig{}
+	distances := map[string]int{}
+	order := []string{}
+	for _, gpu := range gpus {
+		best, bestDistance := "", len(pcieDistances)
+		for _, pf := range candidates {
+			attrs := node.PFs[pf.PciAddress]
+			d := pcieDistance(gpu.Path, attrs.Path, gpu.NumaNode, attrs.NumaNode)
+			if d < bestDistance || (d == bestDistance && railLoad(rails, pf.PciAddress) < railLoad(rails, best)) {
+				best, bestDistance = pf.PciAddress, d
+			}
+		}
+
+		rail, ok := rails[best]
+		if !ok {
+			rail = &config.RailConfig{PciAddress: best}
+			rails[best] = rail
+			order = append(order, best)
+		}
+		rail.GPUs = append(rail.GPUs, gpu.PciAddress)
+		distances[best] = max(distances[best], bestDistance)
+	}
+	for _, pf := range candidates {
+		if _, ok := rails[pf.PciAddress]; !ok {
+			rails[pf.PciAddress] = &config.RailConfig{PciAddress: pf.PciAddress}
+			order = append(order, pf.PciAddress)
+		}
+	}
+
+	result := make([]config.RailConfig, 0, len(order))
+	for i, pci := range order {
+		rail := rails[pci]
+		rail.Name = "rail" + strconv.Itoa(i)
+		if len(rail.GPUs) > 0 {
+			rail.Distance = pcieDistances[distances[pci]]
+		}
+		for _, pf := range candidates {
+			if pf.PciAddress == pci {
+				rail.NetworkInterface = pf.NetworkInterface
+			}
+		}
+		result = append(result, *rail)
+	}
+	return result
+}
+
+func railLoad(rails map[string]*config.RailConfig, pciAddress string) int {
+	if rail, ok := rails[pciAddress]; ok {
+		return len(rail.GPUs)
+	}
+	return 0
+}
+
+// discoverGPUTopology fills the GPU capabilities, GPUs and rail map of the cluster config.
+// The topology collected from the nodes is used when available, otherwise the GPU Operator and NFD node labels.
+// Nodes are expected to be homogeneous: the first node with GPUs defines the rail map, differences are logged.
+func discoverGPUTopology(ctx context.Context, c client.Client, attributes map[string]*nodeAttributes, cluster *config.ClusterConfig) {
+	cluster.Capabilities.Nodes.Gpu = false
+	cluster.Capabilities.Nodes.GpusPerNode = 0
+	cluster.GPUs = nil
+	cluster.Rails = nil
+
+	gpusPerNode := map[string]int{}
+	for _, node := range cluster.WorkerNodes {
+		if attrs, ok := attributes[node]; ok {
+			gpusPerNode[node] = len(attrs.GPUs)
+		}
+	}
+
+	// Fall back to node labels for nodes without collected attributes
+	if len(gpusPerNode) < len(cluster.WorkerNodes) {
+		for _, name := range cluster.WorkerNodes {
+			if _, ok := gpusPerNode[name]; ok {
+				continue
+			}
+			node := &corev1.Node{}
+			if err := c.Get(ctx, client.ObjectKey{Name: name}, node); err != nil {
+				log.Log.V(1).Info("failed to get node for GPU discovery", "node", name, "error", err.Error())
+				continue
+			}
+			if count, err := strconv.Atoi(node.Labels[gpuCountLabel]); err == nil {
+				gpusPerNode[name] = count
+			} else if node.Labels[gpuPresentLabel] == "true" {
+				// GPUs present, but their number is unknown
+				cluster.Capabilities.Nodes.Gpu = true
+			}
+		}
+	}
+
+	for _, count := range gpusPerNode {
+		if count == 0 {
+			continue
+		}
+		cluster.Capabilities.Nodes.Gpu = true
+		if cluster.Capabilities.Nodes.GpusPerNode == 0 || count < cluster.Capabilities.Nodes.GpusPerNode {
+			cluster.Capabilities.Nodes.GpusPerNode = count
+		}
+	}
+
+	var reference string
+	for _, node := range cluster.WorkerNodes {
+		attrs, ok := attributes[node]
+		if !ok || len(attrs.GPUs) == 0 {
+			continue
+		}
+
+		rails := buildRailMap(cluster.PFs, attrs)
+		if reference == "" {
+			reference = node
+			cluster.Rails = rails
+			for _, gpu := range attrs.GPUs {
+				cluster.GPUs = append(cluster.GPUs, config.GPUConfig{PciAddress: gpu.PciAddress, NumaNode: gpu.NumaNode})
+			}
+			slices.SortFunc(cluster.GPUs, func(a, b config.GPUConfig) int { return strings.Compare(a.PciAddress, b.PciAddress) })
+			continue
+		}
+		if !slices.EqualFunc(rails, cluster.Rails, func(a, b config.RailConfig) bool {
+			return a.Name == b.Name && a.PciAddress == b.PciAddress && slices.Equal(a.GPUs, b.GPUs)
+		}) {
+			log.Log.Info("GPU to NIC topology differs between nodes, using the rail map of the first node",
+				"node", node, "referenceNode", reference)
+		}
+	}
+
+	if len(cluster.Rails) > 0 {
+		summary := make([]string, 0, len(cluster.Rails))
+		for _, rail := range cluster.Rails {
+			summary = append(summary, fmt.Sprintf("%s=%s(%s)[%s]", rail.Name, rail.NetworkInterface, rail.Distance, strings.Join(rail.GPUs, ",")))
+		}
+		log.Log.Info("Discovered GPU to NIC rail map", "node", reference, "rails", summary)
+	}
+}
@@ -0,0 +1,363 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package networkoperatorplugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+	"github.com/nvidia/k8s-launch-kit/pkg/plugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// verifyLabel marks the pods created by l8k to verify a deployment
+	verifyLabel = "nvidia.com/l8k-verify"
+	// networkStatusAnnotation lists the networks attached to a pod by multus, with their addresses
+	networkStatusAnnotation = "k8s.v1.cni.cncf.io/network-status"
+)
+
+// Verification checks, run in this order between a server and a client pod
+const (
+	checkRping      = "rping"
+	checkWriteBw    = "ib_write_bw"
+	checkWriteLat   = "ib_write_lat"
+	serverTimeout   = 120 // seconds a server waits for its client, per check
+	clientAttempts  = 60  // connection attempts of a client, 2 seconds apart, covering the server timeout of the previous check
+	checkOutputMark = "==="
+)
+
+// verifyServerScript runs the servers of the checks given as arguments, one after the other
+var verifyServerScript = fmt.Sprintf(`
+for check in "$@"; do
+  case $check in
+    %[1]s) cmd="rping -s -C 10 -v" ;;
+    %[2]s) cmd="ib_write_bw -R -F --report_gbits" ;;
+    %[3]s) cmd="ib_write_lat -R -F" ;;
+  esac
+  timeout %[4]d $cmd > /dev/null 2>&1
+done
+`, checkRping, checkWriteBw, checkWriteLat, serverTimeout)
+
+// verifyClientScript runs the clients of the checks given as arguments against the server address given first.
+// For every check it writes "=== <check> <exit code>" to the termination log, followed by the perftest result lines
+// on success or the last lines of the output on failure. The termination message is limited to 4096 bytes.
+var verifyClientScript = fmt.Sprintf(`
+server=$1; shift
+for check in "$@"; do
+  case $check in
+    %[1]s) cmd="rping -c -a $server -C 10 -v" ;;
+    %[2]s) cmd="ib_write_bw -R -F --report_gbits $server" ;;
+    %[3]s) cmd="ib_write_lat -R -F $server" ;;
+  esac
+  rc=1
+  for attempt in $(seq %[4]d); do
+    $cmd > /tmp/out 2>&1 && { rc=0; break; }
+    sleep 2
+  done
+  echo "%[5]s $check $rc"
+  if [ $rc = 0 ]; then grep -A1 '#bytes' /tmp/out; else tail -n 3 /tmp/out; fi
+done > /dev/termination-log
+`, checkRping, checkWriteBw, checkWriteLat, clientAttempts, checkOutputMark)
+
+// VerifyProfile runs the checks of the profile verification between a server and a client pod on two nodes,
+// for every test pod of the verification workload, i.e. for every network of the profile
+func (p *NetworkOperatorPlugin) VerifyProfile(ctx context.Context, profile *profiles.Profile, cfg *config.LaunchKubernetesConfig, c client.Client) ([]plugin.VerificationResult, error) {
+	if profile.Verification == nil {
+		return nil, nil
+	}
+	for _, check := range profile.Verification.Tests {
+		if !slices.Contains([]string{checkRping, checkWriteBw, checkWriteLat}, check) {
+			return nil, fmt.Errorf("unknown verification test %q in profile %s, must be %s, %s or %s", check, profile.Name, checkRping, checkWriteBw, checkWriteLat)
+		}
+	}
+
+	serverNode, clientNode, err := verificationNodes(cfg)
+	if err != nil {
+		return nil, err
+	}
+	workload, err := verificationWorkload(profile.Verification.Workload, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	thresholds := &config.VerificationConfig{}
+	if cfg.Verification != nil {
+		thresholds = cfg.Verification
+	}
+
+	results := []plugin.VerificationResult{}
+	for _, pod := range workload {
+		log.Log.Info("Verifying network", "pod", pod.Name, "server", serverNode, "client", clientNode, "tests", profile.Verification.Tests)
+		output, err := runVerificationPair(ctx, c, pod, serverNode, clientNode, profile.Verification.Tests, cfg.Timeouts)
+		if err != nil {
+			for _, check := range profile.Verification.Tests {
+				results = append(results, plugin.VerificationResult{Check: check, Network: pod.Name, Server: serverNode, Client: clientNode, Message: err.Error()})
+			}
+			continue
+		}
+		for _, check := range profile.Verification.Tests {
+			result := evaluateCheck(check, output[check], thresholds)
+			result.Network, result.Server, result.Client = pod.Name, serverNode, clientNode
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
+
+// verificationNodes returns the server and client nodes: verification.nodes, or the first two worker nodes
+func verificationNodes(cfg *config.LaunchKubernetesConfig) (string, string, error) {
+	nodes := []string{}
+	if cfg.Verification != nil && len(cfg.Verification.Nodes) > 0 {
+		nodes = cfg.Verification.Nodes
+	} else if cfg.ClusterConfig != nil {
+		nodes = cfg.ClusterConfig.WorkerNodes
+	}
+	if len(nodes) < 2 || nodes[0] == nodes[1] {
+		return "", "", fmt.Errorf("verification needs two different nodes, set verification.nodes or discover at least two worker nodes")
+	}
+	return nodes[0], nodes[1], nil
+}
+
+// verificationWorkload renders the workload template and returns its pods
+func verificationWorkload(template string, cfg *config.LaunchKubernetesConfig) ([]*corev1.Pod, error) {
+	rendered, err := profiles.ProcessTemplate(template, cfg)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := kubeclient.DecodeManifests([]byte(rendered))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode verification workload %s: %w", template, err)
+	}
+
+	pods := []*corev1.Pod{}
+	for _, obj := range objects {
+		if obj.GetKind() != "Pod" {
+			continue
+		}
+		pod := &corev1.Pod{}
+		if err := convertUnstructured(obj, pod); err != nil {
+			return nil, fmt.Errorf("invalid verification workload %s: %w", template, err)
+		}
+		if len(pod.Spec.Containers) == 0 {
+			return nil, fmt.Errorf("pod %s in verification workload %s has no container", pod.Name, template)
+		}
+		pods = append(pods, pod)
+	}
+	if len(pods) == 0 {
+		return nil, fmt.Errorf("verification workload %s has no pod", template)
+	}
+	return pods, nil
+}
+
+// runVerificationPair runs the server pod on one node, then the client pod on the other node against the address of the server
+// on the workload network, and returns the client output by check. Both pods are removed before returning.
+func runVerificationPair(ctx context.Context, c client.Client, workload *corev1.Pod, serverNode, clientNode string, checks []string, timeouts *config.TimeoutsConfig) (map[string][]string, error) {
+	pods := []*corev1.Pod{}
+	defer func() {
+		cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cleanupTimeout)
+		defer cancel()
+		for _, pod := range pods {
+			if err := c.Delete(cleanupCtx, pod, client.Preconditions{UID: &pod.UID}); err != nil && !apierrors.IsNotFound(err) {
+				log.Log.Error(err, "failed to delete verification pod", "pod", pod.Name)
+			}
+		}
+	}()
+
+	serverPod := newVerificationPod(workload, "server", serverNode, append([]string{verifyServerScript, "server"}, checks...))
+	if err := c.Create(ctx, serverPod); err != nil {
+		return nil, fmt.Errorf("failed to create verification server pod: %w", err)
+	}
+	pods = append(pods, serverPod)
+
+	address := ""
+	err := waitFor(ctx, timeouts.Verification, timeouts, fmt.Sprintf("verification server pod %s to run on node %s", serverPod.Name, serverNode), func(ctx context.Context) (bool, error) {
+		if err := c.Get(ctx, client.ObjectKeyFromObject(serverPod), serverPod); err != nil {
+			return false, nil
+		}
+		if serverPod.Status.Phase == corev1.PodFailed || serverPod.Status.Phase == corev1.PodSucceeded {
+			return false, fmt.Errorf("verification server pod %s terminated before the client started", serverPod.Name)
+		}
+		address = networkAddress(serverPod)
+		return serverPod.Status.Phase == corev1.PodRunning && address != "", nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	clientPod := newVerificationPod(workload, "client", clientNode, append([]string{verifyClientScript, "client", address}, checks...))
+	if err := c.Create(ctx, clientPod); err != nil {
+		return nil, fmt.Errorf("failed to create verification client pod: %w", err)
+	}
+	pods = append(pods, clientPod)
+
+	err = waitFor(ctx, timeouts.Verification, timeouts, fmt.Sprintf("verification client pod %s to complete on node %s", clientPod.Name, clientNode), func(ctx context.Context) (bool, error) {
+		if err := c.Get(ctx, client.ObjectKeyFromObject(clientPod), clientPod); err != nil {
+			return false, nil
+		}
+		return clientPod.Status.Phase == corev1.PodSucceeded || clientPod.Status.Phase == corev1.PodFailed, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cs := range clientPod.Status.ContainerStatuses {
+		if cs.State.Terminated != nil {
+			return parseVerificationOutput(cs.State.Terminated.Message), nil
+		}
+	}
+	return nil, fmt.Errorf("verification client pod %s reported no result", clientPod.Name)
+}
+
+// newVerificationPod returns a copy of the workload pod pinned to node, running bash with args
+func newVerificationPod(workload *corev1.Pod, role, node string, args []string) *corev1.Pod {
+	pod := &corev1.Pod{}
+	pod.GenerateName = fmt.Sprintf("%s-%s-", workload.Name, role)
+	pod.Namespace = workload.Namespace
+	if pod.Namespace == "" {
+		pod.Namespace = "default"
+	}
+	pod.Labels = map[string]string{verifyLabel: "true"}
+	pod.Annotations = maps.Clone(workload.Annotations)
+	pod.Spec = *workload.Spec.DeepCopy()
+	pod.Spec.NodeName = node
+	pod.Spec.Affinity = nil
+	pod.Spec.RestartPolicy = corev1.RestartPolicyNever
+
+	container := &pod.Spec.Containers[0]
+	container.Command = append([]string{"/bin/bash", "-c"}, args...)
+	container.Args = nil
+	container.TerminationMessagePolicy = corev1.TerminationMessageReadFile
+	pod.Spec.Containers = pod.Spec.Containers[:1]
+
+	return pod
+}
+
+// networkAddress returns the first address of the pod on a network attached by multus, other than the default network
+func networkAddress(pod *corev1.Pod) string {
+	statuses := []struct {
+		IPs     []string `json:"ips"`
+		Default bool     `json:"default"`
+	}{}
+	if err := json.Unmarshal([]byte(pod.Annotations[networkStatusAnnotation]), &statuses); err != nil {
+		return ""
+	}
+	for _, status := range statuses {
+		if !status.Default && len(status.IPs) > 0 {
+			return status.IPs[0]
+		}
+	}
+	return ""
+}
+
+// parseVerificationOutput splits the client output by check
+func parseVerificationOutput(message string) map[string][]string {
+	output := map[string][]string{}
+	check := ""
+	for _, line := range strings.Split(message, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 3 && fields[0] == checkOutputMark {
+			check = fields[1]
+			output[check] = []string{fields[2]}
+			continue
+		}
+		if check != "" && len(fields) > 0 {
+			output[check] = append(output[check], strings.TrimSpace(line))
+		}
+	}
+	return output
+}
+
+// evaluateCheck returns the result of a check from its output: the exit code of the client, then the perftest
+// header and result lines on success. Thresholds of 0 are not checked.
+func evaluateCheck(check string, output []string, thresholds *config.VerificationConfig) plugin.VerificationResult {
+	result := plugin.VerificationResult{Check: check}
+	if len(output) == 0 {
+		result.Message = "no output"
+		return result
+	}
+	if output[0] != "0" {
+		result.Message = strings.Join(output[1:], " ")
+		if result.Message == "" {
+			result.Message = "client exited with code " + output[0]
+		}
+		return result
+	}
+
+	switch check {
+	case checkRping:
+		result.Passed = true
+		result.Value = "connected"
+	case checkWriteBw:
+		// #bytes #iterations BW peak[Gb/sec] BW average[Gb/sec] MsgRate[Mpps]
+		bandwidth, err := perftestValue(output, 3)
+		if err != nil {
+			result.Message = err.Error()
+			return result
+		}
+		result.Value = fmt.Sprintf("%.2f Gb/s", bandwidth)
+		result.Passed = thresholds.MinBandwidthGbps == 0 || bandwidth >= thresholds.MinBandwidthGbps
+		if thresholds.MinBandwidthGbps > 0 {
+			result.Threshold = fmt.Sprintf(">= %.2f Gb/s", thresholds.MinBandwidthGbps)
+		}
+	case checkWriteLat:
+		// #bytes #iterations t_min[usec] t_max[usec] t_typical[usec] t_avg[usec] ...
+		latency, err := perftestValue(output, 5)
+		if err != nil {
+			result.Message = err.Error()
+			return result
+		}
+		result.Value = fmt.Sprintf("%.2f usec", latency)
+		result.Passed = thresholds.MaxLatencyUsec == 0 || latency <= thresholds.MaxLatencyUsec
+		if thresholds.MaxLatencyUsec > 0 {
+			result.Threshold = fmt.Sprintf("<= %.2f usec", thresholds.MaxLatencyUsec)
+		}
+	}
+	if !result.Passed && result.Message == "" {
+		result.Message = "threshold not met"
+	}
+	return result
+}
+
+// perftestValue returns the column of the perftest result line, the line following the #bytes header
+func perftestValue(output []string, column int) (float64, error) {
+	for i, line := range output {
+		if !strings.HasPrefix(line, "#bytes") || i+1 >= len(output) {
+			continue
+		}
+		fields := strings.Fields(output[i+1])
+		if column >= len(fields) {
+			break
+		}
+		return strconv.ParseFloat(fields[column], 64)
+	}
+	return 0, fmt.Errorf("no result in the output: %s", strings.Join(output[1:], " "))
+}
+
+var _ plugin.Verifier = &NetworkOperatorPlugin{}
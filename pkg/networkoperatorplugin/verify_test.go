@@ -0,0 +1,210 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package networkoperatorplugin
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/plugin"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// verificationOutput is the termination message of a client pod of verifyClientScript, with the output of
+// rping, ib_write_bw --report_gbits and ib_write_lat of perftest 24.07 between two ConnectX-7 on a 100 Gb/s link
+const verificationOutput = `=== rping 0
+=== ib_write_bw 0
+ #bytes     #iterations    BW peak[Gb/sec]    BW average[Gb/sec]   MsgRate[Mpps]
+ 65536      5000             97.52              96.95              0.184918
+=== ib_write_lat 0
+ #bytes #iterations    t_min[usec]    t_max[usec]  t_typical[usec]    t_avg[usec]    t_stdev[usec]   99% percentile[usec]   99.9% percentile[usec] 
+ 2       1000          1.53           4.67         1.58                1.61             0.05            1.75                    4.67   
+`
+
+// failedOutput is the termination message of a client pod whose server did not answer
+const failedOutput = `=== rping 1
+cma event RDMA_CM_EVENT_REJECTED, error 8
+wait for CONNECTED state 10
+connect error -1
+=== ib_write_bw 1
+ Couldn't connect to 192.168.2.10:18515
+Unable to perform rdma_client function
+ Unable to init the socket connection
+=== ib_write_lat 1
+`
+
+func TestParseVerificationOutput(t *testing.T) {
+	got := parseVerificationOutput(verificationOutput)
+	want := map[string][]string{
+		checkRping: {"0"},
+		checkWriteBw: {"0",
+			"#bytes     #iterations    BW peak[Gb/sec]    BW average[Gb/sec]   MsgRate[Mpps]",
+			"65536      5000             97.52              96.95              0.184918"},
+		checkWriteLat: {"0",
+			"#bytes #iterations    t_min[usec]    t_max[usec]  t_typical[usec]    t_avg[usec]    t_stdev[usec]   99% percentile[usec]   99.9% percentile[usec]",
+			"2       1000          1.53           4.67         1.58                1.61             0.05            1.75                    4.67"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseVerificationOutput() =\n%q\nwant\n%q", got, want)
+	}
+
+	if got := parseVerificationOutput("OCI runtime exec failed\n"); len(got) != 0 {
+		t.Errorf("output without check marks must be ignored, got %q", got)
+	}
+}
+
+func TestEvaluateCheck(t *testing.T) {
+	passed := parseVerificationOutput(verificationOutput)
+	failed := parseVerificationOutput(failedOutput)
+
+	tests := []struct {
+		name       string
+		check      string
+		output     []string
+		thresholds *config.VerificationConfig
+		want       plugin.VerificationResult
+	}{
+		{
+			name:       "rping connected",
+			check:      checkRping,
+			output:     passed[checkRping],
+			thresholds: &config.VerificationConfig{},
+			want:       plugin.VerificationResult{Check: checkRping, Passed: true, Value: "connected"},
+		},
+		{
+			name:       "bandwidth without threshold",
+			check:      checkWriteBw,
+			output:     passed[checkWriteBw],
+			thresholds: &config.VerificationConfig{},
+			want:       plugin.VerificationResult{Check: checkWriteBw, Passed: true, Value: "96.95 Gb/s"},
+		},
+		{
+			name:       "bandwidth above the threshold",
+			check:      checkWriteBw,
+			output:     passed[checkWriteBw],
+			thresholds: &config.VerificationConfig{MinBandwidthGbps: 90},
+			want:       plugin.VerificationResult{Check: checkWriteBw, Passed: true, Value: "96.95 Gb/s", Threshold: ">= 90.00 Gb/s"},
+		},
+		{
+			name:       "bandwidth below the threshold",
+			check:      checkWriteBw,
+			output:     passed[checkWriteBw],
+			thresholds: &config.VerificationConfig{MinBandwidthGbps: 97.5},
+			want: plugin.VerificationResult{Check: checkWriteBw, Value: "96.95 Gb/s", Threshold: ">= 97.50 Gb/s",
+				Message: "threshold not met"},
+		},
+		{
+			name:       "latency below the threshold",
+			check:      checkWriteLat,
+			output:     passed[checkWriteLat],
+			thresholds: &config.VerificationConfig{MaxLatencyUsec: 2},
+			want:       plugin.VerificationResult{Check: checkWriteLat, Passed: true, Value: "1.61 usec", Threshold: "<= 2.00 usec"},
+		},
+		{
+			name:       "latency above the threshold",
+			check:      checkWriteLat,
+			output:     passed[checkWriteLat],
+			thresholds: &config.VerificationConfig{MaxLatencyUsec: 1.55},
+			want: plugin.VerificationResult{Check: checkWriteLat, Value: "1.61 usec", Threshold: "<= 1.55 usec",
+				Message: "threshold not met"},
+		},
+		{
+			name:       "failed rping",
+			check:      checkRping,
+			output:     failed[checkRping],
+			thresholds: &config.VerificationConfig{},
+			want: plugin.VerificationResult{Check: checkRping,
+				Message: "cma event RDMA_CM_EVENT_REJECTED, error 8 wait for CONNECTED state 10 connect error -1"},
+		},
+		{
+			name:       "failed client",
+			check:      checkWriteBw,
+			output:     failed[checkWriteBw],
+			thresholds: &config.VerificationConfig{MinBandwidthGbps: 90},
+			want: plugin.VerificationResult{Check: checkWriteBw,
+				Message: "Couldn't connect to 192.168.2.10:18515 Unable to perform rdma_client function Unable to init the socket connection"},
+		},
+		{
+			name:       "failed client without output",
+			check:      checkWriteLat,
+			output:     failed[checkWriteLat],
+			thresholds: &config.VerificationConfig{},
+			want:       plugin.VerificationResult{Check: checkWriteLat, Message: "client exited with code 1"},
+		},
+		{
+			name:       "no output",
+			check:      checkWriteLat,
+			thresholds: &config.VerificationConfig{},
+			want:       plugin.VerificationResult{Check: checkWriteLat, Message: "no output"},
+		},
+		{
+			name:       "success without result line",
+			check:      checkWriteBw,
+			output:     []string{"0", "#bytes     #iterations    BW peak[Gb/sec]    BW average[Gb/sec]   MsgRate[Mpps]"},
+			thresholds: &config.VerificationConfig{},
+			want: plugin.VerificationResult{Check: checkWriteBw,
+				Message: "no result in the output: #bytes     #iterations    BW peak[Gb/sec]    BW average[Gb/sec]   MsgRate[Mpps]"},
+		},
+		{
+			name:       "truncated result line",
+			check:      checkWriteLat,
+			output:     []string{"0", "#bytes #iterations    t_min[usec]    t_max[usec]", "2       1000          1.53"},
+			thresholds: &config.VerificationConfig{},
+			want:       plugin.VerificationResult{Check: checkWriteLat, Message: "no result in the output: #bytes #iterations    t_min[usec]    t_max[usec] 2       1000          1.53"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := evaluateCheck(tt.check, tt.output, tt.thresholds); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("evaluateCheck() =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewVerificationPodCopiesTheWorkload(t *testing.T) {
+	workload := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "sriov-test-pod-rail0",
+			Annotations: map[string]string{"k8s.v1.cni.cncf.io/networks": "sriov-rail0"},
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test", Image: "mellanox/rping-test"}, {Name: "sidecar"}}},
+	}
+
+	server := newVerificationPod(workload, "server", "node-a", []string{"script", "server"})
+	server.Annotations["extra"] = "set by the server"
+	client := newVerificationPod(workload, "client", "node-b", []string{"script", "client"})
+
+	if _, shared := workload.Annotations["extra"]; shared {
+		t.Errorf("the annotations of the workload are shared with the verification pods")
+	}
+	if _, shared := client.Annotations["extra"]; shared {
+		t.Errorf("the annotations are shared between the verification pods")
+	}
+	if client.Namespace != "default" || client.GenerateName != "sriov-test-pod-rail0-client-" || client.Spec.NodeName != "node-b" {
+		t.Errorf("unexpected client pod: %s/%s on %s", client.Namespace, client.GenerateName, client.Spec.NodeName)
+	}
+	if len(client.Spec.Containers) != 1 || !reflect.DeepEqual(client.Spec.Containers[0].Command, []string{"/bin/bash", "-c", "script", "client"}) {
+		t.Errorf("unexpected client containers: %+v", client.Spec.Containers)
+	}
+	if len(workload.Spec.Containers) != 2 || workload.Spec.Containers[0].Command != nil {
+		t.Errorf("the workload spec was modified: %+v", workload.Spec.Containers)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package networkoperatorplugin
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	nicop "github.com/Mellanox/nic-configuration-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+)
+
+// WatchedObjects returns the Nodes and the NicDevices, new workers and NICs change the discovered cluster config
+func (p *NetworkOperatorPlugin) WatchedObjects() []client.Object {
+	return []client.Object{&corev1.Node{}, &nicop.NicDevice{}}
+}
+
+// RefreshClusterConfig rebuilds the PFs, worker nodes and capabilities of the cluster config from the current NicDevices,
+// which requires the nic-configuration-operator to keep running after discovery. The PF attributes are collected only
+// from the nodes not seen before. The capabilities of the nodes matching the NIC node selector without NicDevices are empty.
+func (p *NetworkOperatorPlugin) RefreshClusterConfig(ctx context.Context, cfg *config.LaunchKubernetesConfig, c client.Client) (map[string]config.NodesCapabilities, error) {
+	cluster := cfg.ClusterConfig
+	if cluster == nil || cluster.Capabilities == nil || cluster.Capabilities.Nodes == nil {
+		return nil, fmt.Errorf("the config has no discovered cluster config to refresh")
+	}
+
+	devices := &nicop.NicDeviceList{}
+	if err := c.List(ctx, devices, client.InNamespace(cfg.NetworkOperator.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list NicDevices: %w", err)
+	}
+	if len(devices.Items) == 0 {
+		return nil, fmt.Errorf("no NicDevice found in namespace %s, the nic-configuration-operator must be running", cfg.NetworkOperator.Namespace)
+	}
+
+	devicesByNode := map[string][]nicop.NicDevice{}
+	for _, d := range devices.Items {
+		if d.Status.Node != "" {
+			devicesByNode[d.Status.Node] = append(devicesByNode[d.Status.Node], d)
+		}
+	}
+
+	if p.attributes == nil {
+		p.attributes = map[string]*nodeAttributes{}
+		p.collected = map[string]bool{}
+	}
+	for node := range p.collected {
+		if _, ok := devicesByNode[node]; !ok {
+			delete(p.attributes, node)
+			delete(p.collected, node)
+		}
+	}
+	if image := cfg.NetworkOperator.NodeCollectorImage; image != "" {
+		pciByNode := map[string][]string{}
+		for node, nodeDevices := range devicesByNode {
+			if p.collected[node] {
+				continue
+			}
+			for _, d := range nodeDevices {
+				for _, port := range d.Status.Ports {
+					if port.PCI != "" {
+						pciByNode[node] = append(pciByNode[node], port.PCI)
+					}
+				}
+			}
+		}
+		if len(pciByNode) > 0 {
+			log.Log.Info("Collecting device attributes from new nodes", "nodes", len(pciByNode))
+			for node, attrs := range collectNodeAttributes(ctx, c, cfg.NetworkOperator.Namespace, image, pciByNode, cfg.Timeouts) {
+				p.attributes[node] = attrs
+			}
+			// Nodes the collection failed on are not retried, their attributes stay unknown
+			for node := range pciByNode {
+				p.collected[node] = true
+			}
+		}
+	}
+
+	cluster.WorkerNodes = []string{}
+	buildClusterConfigFromNicDevices(devices.Items, p.attributes, cluster)
+	discoverGPUTopology(ctx, c, p.attributes, cluster)
+	if err := carveSubnets(cfg); err != nil {
+		return nil, err
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := c.List(ctx, nodes, client.MatchingLabels(cluster.NodeSelector)); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	capabilities := map[string]config.NodesCapabilities{}
+	for _, node := range nodes.Items {
+		nodeCluster := &config.ClusterConfig{Capabilities: &config.ClusterCapabilities{Nodes: &config.NodesCapabilities{}}}
+		if nodeDevices, ok := devicesByNode[node.Name]; ok {
+			buildClusterConfigFromNicDevices(nodeDevices, p.attributes, nodeCluster)
+		}
+		nodeCapabilities := *nodeCluster.Capabilities.Nodes
+		nodeCapabilities.Gpu = hasGPUs(&node, p.attributes[node.Name])
+		capabilities[node.Name] = nodeCapabilities
+	}
+	return capabilities, nil
+}
+
+// hasGPUs returns whether the node has NVIDIA GPUs, from the collected attributes or else from the node labels
+func hasGPUs(node *corev1.Node, attrs *nodeAttributes) bool {
+	if attrs != nil && len(attrs.GPUs) > 0 {
+		return true
+	}
+	if count, err := strconv.Atoi(node.Labels[gpuCountLabel]); err == nil {
+		return count > 0
+	}
+	return node.Labels[gpuPresentLabel] == "true"
+}
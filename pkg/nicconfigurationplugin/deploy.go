@@ -0,0 +1,134 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nicconfigurationplugin
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	nicop "github.com/Mellanox/nic-configuration-operator/api/v1alpha1"
+	nicopconsts "github.com/Mellanox/nic-configuration-operator/pkg/consts"
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+	"github.com/nvidia/k8s-launch-kit/pkg/plugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// failedReasons are the reasons of the ConfigUpdateInProgress condition of a NicDevice that end the configuration
+var failedReasons = []string{
+	nicopconsts.IncorrectSpecReason,
+	nicopconsts.NonVolatileConfigUpdateFailedReason,
+	nicopconsts.RuntimeConfigUpdateFailedReason,
+}
+
+// DeployProfile applies the NicConfigurationTemplates in manifestsDir and waits until the nic-configuration-operator
+// has applied them to all matching NicDevices. Non-volatile changes reboot the nodes, which may take a while.
+func (p *NicConfigurationPlugin) DeployProfile(ctx context.Context, profile *profiles.Profile, config *config.LaunchKubernetesConfig, kubeClient client.Client, manifestsDir string) error {
+	objects, err := kubeclient.ReadManifests(manifestsDir)
+	if err != nil {
+		return err
+	}
+
+	templateGVK := nicop.GroupVersion.WithKind("NicConfigurationTemplate")
+	templates := []client.ObjectKey{}
+	for _, obj := range objects {
+		log.Log.Info("Applying object", "kind", obj.GetKind(), "name", obj.GetName(), "version", obj.GetAPIVersion())
+		if err := kubeclient.Apply(ctx, kubeClient, obj, config.ForceConflicts()); err != nil {
+			return fmt.Errorf("failed to apply %s %s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		if obj.GroupVersionKind() == templateGVK {
+			templates = append(templates, client.ObjectKeyFromObject(obj))
+		}
+	}
+
+	log.Log.Info("Waiting for the NIC configuration to be applied", "templates", len(templates))
+	return waitNicConfigurationApplied(ctx, kubeClient, templates, config.Timeouts)
+}
+
+// waitNicConfigurationApplied polls the NicDevices matched by the templates until the configuration update of
+// each of them succeeded for its current spec, logging the progress of every device
+func waitNicConfigurationApplied(ctx context.Context, c client.Client, templates []client.ObjectKey, timeouts *config.TimeoutsConfig) error {
+	waitCtx, cancel := context.WithTimeout(ctx, timeouts.NicConfiguration)
+	defer cancel()
+
+	lastReasons := map[string]string{}
+	err := timeouts.PollBackoff().DelayFunc().Until(waitCtx, true, false, func(ctx context.Context) (bool, error) {
+		done := true
+		for _, key := range templates {
+			template := &nicop.NicConfigurationTemplate{}
+			if err := c.Get(ctx, key, template); err != nil {
+				log.Log.V(1).Info("failed to get NicConfigurationTemplate", "name", key.Name, "error", err.Error())
+				return false, nil
+			}
+			if len(template.Status.NicDevices) == 0 {
+				log.Log.V(1).Info("No NicDevices matched by NicConfigurationTemplate yet", "name", key.Name)
+				done = false
+				continue
+			}
+
+			for _, name := range template.Status.NicDevices {
+				device := &nicop.NicDevice{}
+				if err := c.Get(ctx, client.ObjectKey{Namespace: key.Namespace, Name: name}, device); err != nil {
+					log.Log.V(1).Info("failed to get NicDevice", "name", name, "error", err.Error())
+					done = false
+					continue
+				}
+
+				reason := "Pending"
+				condition := meta.FindStatusCondition(device.Status.Conditions, nicopconsts.ConfigUpdateInProgressCondition)
+				if condition != nil && condition.ObservedGeneration == device.Generation {
+					reason = condition.Reason
+				}
+				if reason != lastReasons[name] {
+					log.Log.Info("NicDevice configuration", "device", name, "node", device.Status.Node, "reason", reason)
+					lastReasons[name] = reason
+				}
+
+				if slices.Contains(failedReasons, reason) {
+					return false, fmt.Errorf("failed to configure NicDevice %s on node %s: %s: %s", name, device.Status.Node, reason, condition.Message)
+				}
+				if reason != nicopconsts.UpdateSuccessfulReason {
+					done = false
+				}
+			}
+		}
+		return done, nil
+	})
+	if err != nil && wait.Interrupted(err) {
+		if ctx.Err() != nil {
+			return fmt.Errorf("interrupted while waiting for the NIC configuration: %w", ctx.Err())
+		}
+		return fmt.Errorf("timeout waiting for the NIC configuration to be applied after %s, last device states: %v", timeouts.NicConfiguration, lastReasons)
+	}
+	return err
+}
+
+// Permissions returns the permission to read the NicDevices matched by the NicConfigurationTemplates while waiting
+// for the configuration, the templates are part of the deployment files
+func (p *NicConfigurationPlugin) Permissions(phase string, profile *profiles.Profile, cfg *config.LaunchKubernetesConfig) ([]kubeclient.Permission, error) {
+	if phase == plugin.PhaseDeploy {
+		return []kubeclient.Permission{{Group: nicop.GroupVersion.Group, Resource: "nicdevices", Namespace: cfg.NetworkOperator.Namespace, Verbs: []string{"get"}}}, nil
+	}
+	return nil, nil
+}
+
+var _ plugin.PermissionRequirer = &NicConfigurationPlugin{}
@@ -0,0 +1,136 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nicconfigurationplugin
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	nicop "github.com/Mellanox/nic-configuration-operator/api/v1alpha1"
+	nicopconsts "github.com/Mellanox/nic-configuration-operator/pkg/consts"
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// nicDevice returns a NicDevice at generation 2 whose configuration update condition has reason and observedGeneration,
+// no condition if reason is empty
+func nicDevice(name, reason string, observedGeneration int64) *nicop.NicDevice {
+	device := &nicop.NicDevice{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "nvidia-network-operator", Generation: 2},
+		Status:     nicop.NicDeviceStatus{Node: "node-" + name},
+	}
+	if reason != "" {
+		device.Status.Conditions = []metav1.Condition{{
+			Type:               nicopconsts.ConfigUpdateInProgressCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             reason,
+			Message:            "firmware says no",
+			ObservedGeneration: observedGeneration,
+		}}
+	}
+	return device
+}
+
+func TestWaitNicConfigurationApplied(t *testing.T) {
+	tests := []struct {
+		name    string
+		matched []string
+		devices []*nicop.NicDevice
+		wantErr string
+	}{
+		{
+			name:    "all devices updated",
+			matched: []string{"a", "b"},
+			devices: []*nicop.NicDevice{nicDevice("a", nicopconsts.UpdateSuccessfulReason, 2), nicDevice("b", nicopconsts.UpdateSuccessfulReason, 2)},
+		},
+		{
+			name:    "no devices matched yet",
+			wantErr: "timeout waiting for the NIC configuration",
+		},
+		{
+			name:    "one device pending reboot",
+			matched: []string{"a", "b"},
+			devices: []*nicop.NicDevice{nicDevice("a", nicopconsts.UpdateSuccessfulReason, 2), nicDevice("b", nicopconsts.PendingRebootReason, 2)},
+			wantErr: "b:PendingReboot",
+		},
+		{
+			name:    "success of the previous spec",
+			matched: []string{"a"},
+			devices: []*nicop.NicDevice{nicDevice("a", nicopconsts.UpdateSuccessfulReason, 1)},
+			wantErr: "a:Pending",
+		},
+		{
+			name:    "no condition yet",
+			matched: []string{"a"},
+			devices: []*nicop.NicDevice{nicDevice("a", "", 0)},
+			wantErr: "a:Pending",
+		},
+		{
+			name:    "matched device not found",
+			matched: []string{"a", "gone"},
+			devices: []*nicop.NicDevice{nicDevice("a", nicopconsts.UpdateSuccessfulReason, 2)},
+			wantErr: "timeout waiting for the NIC configuration",
+		},
+		{
+			name:    "non-volatile update failed",
+			matched: []string{"a", "b"},
+			devices: []*nicop.NicDevice{nicDevice("a", nicopconsts.UpdateStartedReason, 2), nicDevice("b", nicopconsts.NonVolatileConfigUpdateFailedReason, 2)},
+			wantErr: "failed to configure NicDevice b on node node-b: NonVolatileConfigUpdateFailed: firmware says no",
+		},
+		{
+			name:    "incorrect spec",
+			matched: []string{"a"},
+			devices: []*nicop.NicDevice{nicDevice("a", nicopconsts.IncorrectSpecReason, 2)},
+			wantErr: "failed to configure NicDevice a on node node-a: IncorrectSpec",
+		},
+		{
+			name:    "failure of the previous spec is ignored",
+			matched: []string{"a"},
+			devices: []*nicop.NicDevice{nicDevice("a", nicopconsts.RuntimeConfigUpdateFailedReason, 1)},
+			wantErr: "timeout waiting for the NIC configuration",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := &nicop.NicConfigurationTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "nic-configuration-a2dc", Namespace: "nvidia-network-operator"},
+				Status:     nicop.NicTemplateStatus{NicDevices: tt.matched},
+			}
+			objects := []client.Object{template}
+			for _, device := range tt.devices {
+				objects = append(objects, device)
+			}
+			c := fake.NewClientBuilder().WithScheme(kubeclient.NewScheme()).WithObjects(objects...).Build()
+			timeouts := &config.TimeoutsConfig{NicConfiguration: 100 * time.Millisecond, PollInterval: 10 * time.Millisecond}
+			timeouts.SetDefaults()
+
+			err := waitNicConfigurationApplied(context.Background(), c, []client.ObjectKey{client.ObjectKeyFromObject(template)}, timeouts)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("error = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
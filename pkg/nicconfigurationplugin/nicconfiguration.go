@@ -0,0 +1,100 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nicconfigurationplugin
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+	"github.com/nvidia/k8s-launch-kit/pkg/plugin"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	PluginName    = "nic-configuration"
+	PluginVersion = "1.0.0"
+)
+
+// networkOperatorPlugin deploys the nic-configuration-operator with the NicClusterPolicy and discovers the device IDs
+const networkOperatorPlugin = "network-operator"
+
+func init() {
+	plugin.Register(plugin.Registration{
+		Metadata: plugin.Metadata{
+			Name:                PluginName,
+			Version:             PluginVersion,
+			ProfileRequirements: []string{"fabric", "deployment", "spectrumX", "gpuDirect"},
+			// The nic-configuration-operator is deployed by the NicClusterPolicy
+			DependsOn: []string{networkOperatorPlugin},
+		},
+		New:      func() plugin.Plugin { return &NicConfigurationPlugin{} },
+		Validate: validateOptions,
+	})
+}
+
+// NicConfigurationPlugin tunes the NIC firmware parameters for the selected profile with NicConfigurationTemplates
+// of the nic-configuration-operator: link type, number of VFs, PCIe max read request, RoCE QoS and GPUDirect (ATS).
+// The profile is built by the network-operator plugin, this plugin only reads it.
+type NicConfigurationPlugin struct {
+}
+
+// validateOptions checks that the network-operator plugin, which selects the profile, is enabled too
+func validateOptions(options options.Options) error {
+	if !slices.Contains(options.EnabledPlugins, networkOperatorPlugin) {
+		return fmt.Errorf("plugin %s requires the %s plugin to be enabled", PluginName, networkOperatorPlugin)
+	}
+
+	return nil
+}
+
+func (p *NicConfigurationPlugin) GetName() string {
+	return PluginName
+}
+
+func (p *NicConfigurationPlugin) GetVersion() string {
+	return PluginVersion
+}
+
+func (p *NicConfigurationPlugin) ProfileConfiguredInCmd(_ context.Context, options options.Options) bool {
+	return options.Fabric != ""
+}
+
+func (p *NicConfigurationPlugin) BuildProfileFromOptions(_ context.Context, options options.Options, profile *config.Profile) error {
+	log.Log.V(1).Info("Profile is built by the network-operator plugin", "plugin", p.GetName(), "profile", profile)
+	return nil
+}
+
+func (p *NicConfigurationPlugin) BuildProfileFromLLMResponse(_ context.Context, llmResponse map[string]string, profile *config.Profile) error {
+	log.Log.V(1).Info("Profile is built by the network-operator plugin", "plugin", p.GetName(), "profile", profile)
+	return nil
+}
+
+func (p *NicConfigurationPlugin) GetSystemPromptAddendum(_ context.Context) (string, error) {
+	return "", nil
+}
+
+// DiscoverClusterConfig discovers nothing: the device IDs and PCI addresses of the PFs are discovered by the network-operator plugin
+func (p *NicConfigurationPlugin) DiscoverClusterConfig(ctx context.Context, c client.Client, defaultConfig *config.LaunchKubernetesConfig) error {
+	log.Log.V(1).Info("Using the PFs discovered by the network-operator plugin", "plugin", p.GetName())
+	return nil
+}
+
+var _ plugin.Plugin = &NicConfigurationPlugin{}
@@ -0,0 +1,96 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nicconfigurationplugin
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+)
+
+// maxReadRequestSizes are the PCIe max read request sizes supported by the nic-configuration-operator
+var maxReadRequestSizes = []int{128, 256, 512, 1024, 2048, 4096}
+
+// pfcPattern is the format of the priority flow control setting, one 0 or 1 per priority
+var pfcPattern = regexp.MustCompile(`^([01],){7}[01]$`)
+
+// GenerateProfileDeploymentFiles renders a NicConfigurationTemplate per device ID of the east-west PFs
+// after checking the nicConfiguration section of the config
+func (p *NicConfigurationPlugin) GenerateProfileDeploymentFiles(_ context.Context, profile *profiles.Profile, config *config.LaunchKubernetesConfig) (map[string]string, error) {
+	if err := validateNicConfiguration(profile, config); err != nil {
+		return nil, fmt.Errorf("profile %s cannot be deployed with the given config: %w", profile.Name, err)
+	}
+
+	results := make(map[string]string)
+
+	for _, templatePath := range profile.Templates {
+		processed, err := profiles.ProcessTemplate(templatePath, config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to process template %s: %w", templatePath, err)
+		}
+
+		results[filepath.Base(templatePath)] = processed
+	}
+
+	return results, nil
+}
+
+// validateNicConfiguration checks that the firmware settings are supported and that the device IDs of the
+// east-west PFs, which select the NICs of the templates, are known
+func validateNicConfiguration(profile *profiles.Profile, config *config.LaunchKubernetesConfig) error {
+	nicConfiguration := config.NicConfiguration
+	if nicConfiguration == nil {
+		return fmt.Errorf("nicConfiguration section is missing from the config")
+	}
+	if config.NetworkOperator == nil {
+		return fmt.Errorf("networkOperator section is missing from the config")
+	}
+
+	if nicConfiguration.MaxReadRequest != 0 && !slices.Contains(maxReadRequestSizes, nicConfiguration.MaxReadRequest) {
+		return fmt.Errorf("nicConfiguration.maxReadRequest must be one of: %v", maxReadRequestSizes)
+	}
+
+	if profile.ProfileRequirements.SpectrumX.Requires("true") {
+		if nicConfiguration.Trust == "" || nicConfiguration.Pfc == "" {
+			return fmt.Errorf("nicConfiguration.trust and nicConfiguration.pfc are required for Spectrum-X")
+		}
+		if !pfcPattern.MatchString(nicConfiguration.Pfc) {
+			return fmt.Errorf("nicConfiguration.pfc must be 8 comma-separated 0 or 1 values, e.g. 0,0,0,1,0,0,0,0")
+		}
+	}
+
+	eastWest := 0
+	for _, pf := range config.ClusterConfig.PFs {
+		if pf.Traffic != "east-west" {
+			continue
+		}
+		eastWest++
+		if pf.DeviceID == "" {
+			return fmt.Errorf("device ID of PF %s is unknown, discover the cluster config or set clusterConfig.pfs[].deviceID", pf.PciAddress)
+		}
+	}
+	if eastWest == 0 {
+		return fmt.Errorf("no east-west PFs to configure in the cluster config")
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nicconfigurationplugin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	sigsyaml "sigs.k8s.io/yaml"
+)
+
+var spectrumXProfile = &profiles.Profile{
+	Name:                "NIC Configuration Spectrum-X",
+	ProfileRequirements: profiles.ProfileRequirements{Fabric: profiles.Values{"ethernet"}, SpectrumX: profiles.Values{"true"}},
+	Templates:           []string{"../../profiles/nic-configuration-spectrum-x/10-nicconfigurationtemplate.yaml"},
+}
+
+var ethernetProfile = &profiles.Profile{
+	Name:                "NIC Configuration Ethernet",
+	ProfileRequirements: profiles.ProfileRequirements{Fabric: profiles.Values{"ethernet"}, SpectrumX: profiles.Values{"false"}},
+	Templates:           []string{"../../profiles/nic-configuration-ethernet/10-nicconfigurationtemplate.yaml"},
+}
+
+// loadTestConfig returns the default config of the repository with the given NIC configuration and
+// two BlueField-3 east-west PFs and a ConnectX-7 north-south PF
+func loadTestConfig(t *testing.T, nicConfiguration *config.NicConfigurationConfig) *config.LaunchKubernetesConfig {
+	t.Helper()
+	cfg, err := config.LoadFullConfig("../../l8k-config.yaml", log.Log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Profile = &config.Profile{Fabric: "ethernet", Deployment: "sriov", Multirail: true, SpectrumX: true, GPUDirect: "rdma"}
+	cfg.NicConfiguration = nicConfiguration
+	cfg.ClusterConfig = &config.ClusterConfig{
+		PFs: []config.PFConfig{
+			{PciAddress: "0000:08:00.0", DeviceID: "a2dc", Traffic: "east-west"},
+			{PciAddress: "0000:0c:00.0", DeviceID: "1021", Traffic: "north-south"},
+			{PciAddress: "0000:3b:00.0", DeviceID: "a2dc", Traffic: "east-west"},
+		},
+	}
+	return cfg
+}
+
+func TestValidateNicConfiguration(t *testing.T) {
+	spectrumX := &config.NicConfigurationConfig{Trust: "dscp", Pfc: "0,0,0,1,0,0,0,0", MaxReadRequest: 4096}
+
+	tests := []struct {
+		name             string
+		profile          *profiles.Profile
+		nicConfiguration *config.NicConfigurationConfig
+		pfs              []config.PFConfig
+		wantErr          string
+	}{
+		{name: "Spectrum-X", profile: spectrumXProfile, nicConfiguration: spectrumX},
+		{name: "missing section", profile: spectrumXProfile, wantErr: "nicConfiguration section is missing"},
+		{name: "Spectrum-X without trust", profile: spectrumXProfile, nicConfiguration: &config.NicConfigurationConfig{Pfc: "0,0,0,1,0,0,0,0"},
+			wantErr: "trust and nicConfiguration.pfc are required for Spectrum-X"},
+		{name: "Spectrum-X without pfc", profile: spectrumXProfile, nicConfiguration: &config.NicConfigurationConfig{Trust: "dscp"},
+			wantErr: "trust and nicConfiguration.pfc are required for Spectrum-X"},
+		{name: "pfc with 7 priorities", profile: spectrumXProfile, nicConfiguration: &config.NicConfigurationConfig{Trust: "dscp", Pfc: "0,0,0,1,0,0,0"},
+			wantErr: "8 comma-separated 0 or 1 values"},
+		{name: "pfc with other values", profile: spectrumXProfile, nicConfiguration: &config.NicConfigurationConfig{Trust: "dscp", Pfc: "0,0,0,3,0,0,0,0"},
+			wantErr: "8 comma-separated 0 or 1 values"},
+		{name: "pfc with spaces", profile: spectrumXProfile, nicConfiguration: &config.NicConfigurationConfig{Trust: "dscp", Pfc: "0, 0, 0, 1, 0, 0, 0, 0"},
+			wantErr: "8 comma-separated 0 or 1 values"},
+		{name: "QoS is not required without Spectrum-X", profile: ethernetProfile, nicConfiguration: &config.NicConfigurationConfig{}},
+		{name: "unsupported max read request", profile: ethernetProfile, nicConfiguration: &config.NicConfigurationConfig{MaxReadRequest: 8192},
+			wantErr: "maxReadRequest must be one of"},
+		{name: "unknown device ID", profile: spectrumXProfile, nicConfiguration: spectrumX,
+			pfs:     []config.PFConfig{{PciAddress: "0000:08:00.0", Traffic: "east-west"}},
+			wantErr: "device ID of PF 0000:08:00.0 is unknown"},
+		{name: "unknown device ID of a north-south PF", profile: spectrumXProfile, nicConfiguration: spectrumX,
+			pfs: []config.PFConfig{{PciAddress: "0000:08:00.0", DeviceID: "a2dc", Traffic: "east-west"}, {PciAddress: "0000:0c:00.0", Traffic: "north-south"}}},
+		{name: "no east-west PF", profile: spectrumXProfile, nicConfiguration: spectrumX,
+			pfs:     []config.PFConfig{{PciAddress: "0000:0c:00.0", DeviceID: "1021", Traffic: "north-south"}},
+			wantErr: "no east-west PFs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := loadTestConfig(t, tt.nicConfiguration)
+			if tt.pfs != nil {
+				cfg.ClusterConfig.PFs = tt.pfs
+			}
+
+			err := validateNicConfiguration(tt.profile, cfg)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("error = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSpectrumXTemplate(t *testing.T) {
+	cfg := loadTestConfig(t, &config.NicConfigurationConfig{Trust: "dscp", Pfc: "0,0,0,1,0,0,0,0", MaxReadRequest: 4096})
+	cfg.ClusterConfig.PFs = append(cfg.ClusterConfig.PFs, config.PFConfig{PciAddress: "0000:5e:00.0", DeviceID: "1023", Traffic: "east-west"})
+
+	files, err := (&NicConfigurationPlugin{}).GenerateProfileDeploymentFiles(context.Background(), spectrumXProfile, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	documents := strings.Split(files["10-nicconfigurationtemplate.yaml"], "\n---\n")
+	if len(documents) != 2 {
+		t.Fatalf("want one template per east-west device ID, got %d documents:\n%s", len(documents), files["10-nicconfigurationtemplate.yaml"])
+	}
+
+	templates := map[string]*unstructured.Unstructured{}
+	for _, document := range documents {
+		obj := &unstructured.Unstructured{}
+		if err := sigsyaml.Unmarshal([]byte(document), &obj.Object); err != nil {
+			t.Fatalf("%v:\n%s", err, document)
+		}
+		templates[obj.GetName()] = obj
+	}
+
+	bf3 := templates["nic-configuration-a2dc"]
+	if bf3 == nil {
+		t.Fatalf("no template for the BlueField-3 PFs: %v", templates)
+	}
+	addresses, _, _ := unstructured.NestedStringSlice(bf3.Object, "spec", "nicSelector", "pciAddresses")
+	if strings.Join(addresses, ",") != "0000:08:00.0,0000:3b:00.0" {
+		t.Errorf("pciAddresses = %v, want the east-west BlueField-3 PFs only", addresses)
+	}
+	for path, want := range map[string]any{
+		"numVfs":                                 cfg.Sriov.NumVfs,
+		"linkType":                               "Ethernet",
+		"pciPerformanceOptimized.enabled":        true,
+		"pciPerformanceOptimized.maxReadRequest": 4096,
+		"roceOptimized.enabled":                  true,
+		"roceOptimized.qos.trust":                "dscp",
+		"roceOptimized.qos.pfc":                  "0,0,0,1,0,0,0,0",
+		"gpuDirectOptimized.enabled":             true,
+	} {
+		got, found, err := unstructured.NestedFieldNoCopy(bf3.Object, append([]string{"spec", "template"}, strings.Split(path, ".")...)...)
+		if err != nil || !found {
+			t.Errorf("%s not rendered: %v", path, err)
+			continue
+		}
+		// Numbers are decoded as float64
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("%s = %v, want %v", path, got, want)
+		}
+	}
+	if templates["nic-configuration-1023"] == nil {
+		t.Errorf("no template for the ConnectX-8 PF: %v", templates)
+	}
+}
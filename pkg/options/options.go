@@ -16,32 +16,72 @@
 
 package options
 
+import "time"
+
 // Options holds all the configuration parameters for the application
 type Options struct {
 	// Logging
-	LogLevel string
+	LogLevel string `yaml:"logLevel"`
+
+	// Timeouts, zero values fall back to the config file or the defaults
+	Timeout                      time.Duration `yaml:"timeout"`                      // Overall timeout for the whole workflow
+	DiscoveryTimeout             time.Duration `yaml:"discoveryTimeout"`             // Timeout for the cluster discovery phase
+	DeploymentTimeout            time.Duration `yaml:"deploymentTimeout"`            // Timeout for the cluster deployment phase
+	NicClusterPolicyReadyTimeout time.Duration `yaml:"nicClusterPolicyReadyTimeout"` // Timeout for the NicClusterPolicy to become ready
+	PollInterval                 time.Duration `yaml:"pollInterval"`                 // Initial interval between status polls
 
 	// Phase 1: Cluster Discovery
-	UserConfig            string // Path to user-provided config (skips discovery)
-	DiscoverClusterConfig bool   // Whether to discover cluster config
-	SaveClusterConfig     string // Path to save discovered config
+	UserConfig            string `yaml:"userConfig"`            // Path to user-provided config (skips discovery)
+	DiscoverClusterConfig bool   `yaml:"discoverClusterConfig"` // Whether to discover cluster config
+	SaveClusterConfig     string `yaml:"saveClusterConfig"`     // Path to save discovered config
+
+	ExistingNicClusterPolicy string `yaml:"existingNicClusterPolicy"` // How to treat an existing NicClusterPolicy during discovery (refuse, reuse)
+	InstallOperators         bool   `yaml:"installOperators"`         // Whether to install or upgrade the operators of the plugins with Helm before discovery
 
 	// Phase 2: Deployment Generation
-	Fabric              string // Fabric type to deploy
-	DeploymentType      string // Deployment type to deploy
-	Multirail           bool   // Whether to deploy with multirail
-	SpectrumX           bool   // Whether to deploy with Spectrum X
-	Ai                  bool   // Whether to deploy with AI
-	Prompt              string // Path to file with a prompt to use for LLM-assisted profile generation
-	SaveDeploymentFiles string // Directory to save generated files
+	Fabric              string `yaml:"fabric"`              // Fabric type to deploy
+	DeploymentType      string `yaml:"deploymentType"`      // Deployment type to deploy
+	Multirail           bool   `yaml:"multirail"`           // Whether to deploy with multirail
+	SpectrumX           bool   `yaml:"spectrumX"`           // Whether to deploy with Spectrum X
+	Ai                  bool   `yaml:"ai"`                  // Whether to deploy with AI
+	Prompt              string `yaml:"prompt"`              // Path to file with a prompt to use for LLM-assisted profile generation
+	SaveDeploymentFiles string `yaml:"saveDeploymentFiles"` // Directory to save generated files
 
-	LLMApiKey string // API key for the LLM API
-	LLMApiUrl string // API URL for the LLM API
-	LLMVendor string // Vendor of the LLM API
+	GPUDirect                 string        `yaml:"gpuDirect"`                 // GPUDirect mode of the GPU Operator profile (none, rdma, storage)
+	ClusterPolicyReadyTimeout time.Duration `yaml:"clusterPolicyReadyTimeout"` // Timeout for the GPU Operator ClusterPolicy to become ready
 
-	EnabledPlugins []string // Enabled plugins
+	LLMApiKey string `yaml:"-"`         // API key for the LLM API, never passed to external plugins
+	LLMApiUrl string `yaml:"llmApiUrl"` // API URL for the LLM API
+	LLMVendor string `yaml:"llmVendor"` // Vendor of the LLM API
+
+	EnabledPlugins []string `yaml:"enabledPlugins"` // Enabled plugins
+	PluginsDir     string   `yaml:"pluginsDir"`     // Directory with out-of-tree l8k-plugin-* executables, searched before $PATH
 
 	// Phase 3: Cluster Deployment
-	Deploy     bool   // Whether to deploy to cluster
-	Kubeconfig string // Path to kubeconfig for discovery and deployment
+	Deploy     bool   `yaml:"deploy"`     // Whether to deploy to cluster
+	Kubeconfig string `yaml:"kubeconfig"` // Path to kubeconfig for discovery and deployment, KUBECONFIG, ~/.kube/config or the in-cluster config if empty
+
+	// Cluster access, see kubeclient.Options
+	Context      string   `yaml:"context"`      // Context of the kubeconfig, the current context if empty
+	As           string   `yaml:"as"`           // User to impersonate
+	AsGroups     []string `yaml:"asGroups"`     // Groups to impersonate
+	KubeAPIQPS   float32  `yaml:"kubeAPIQPS"`   // Maximum queries per second to the API server
+	KubeAPIBurst int      `yaml:"kubeAPIBurst"` // Maximum burst of queries to the API server
+
+	ForceConflicts bool `yaml:"forceConflicts"` // Whether to take over the fields owned by other field managers when deploying
+	SkipPreflight  bool `yaml:"skipPreflight"`  // Whether to skip the permission and cluster checks run before discovery and deployment
+	Preflight      bool `yaml:"preflight"`      // Whether to only run the checks of the deployment instead of deploying
+
+	// Phase 4: Deployment Verification
+	Verify bool `yaml:"verify"` // Whether to verify the deployed networks with test pods
+
+	// Status reporting
+	Status bool   `yaml:"status"` // Whether to report the status of the deployed profiles instead of generating and deploying them
+	Output string `yaml:"output"` // Output format of the status report (table, json)
+
+	// Watch mode
+	Watch bool `yaml:"watch"` // Whether to keep watching the cluster after the workflow, refreshing the cluster config as nodes join
+
+	// Rollback
+	RollbackTo int `yaml:"rollbackTo"` // Revision of the deployment history to roll back to, the previous one if 0
 }
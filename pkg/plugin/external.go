@@ -0,0 +1,340 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+	applog "github.com/nvidia/k8s-launch-kit/pkg/log"
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+	yamlv2 "gopkg.in/yaml.v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ExternalPluginPrefix is the executable name prefix of out-of-tree plugins, e.g. l8k-plugin-storage
+	ExternalPluginPrefix = "l8k-plugin-"
+	// ProtocolVersion is the version of the JSON-over-stdio protocol spoken with external plugins
+	ProtocolVersion = "v1"
+	// callTimeout bounds the requests that do not access the cluster
+	callTimeout = time.Minute
+)
+
+// Request is sent to an external plugin on stdin. The plugin is executed once per request.
+type Request struct {
+	ProtocolVersion string                     `json:"protocolVersion"`
+	Method          string                     `json:"method"`
+	Params          map[string]json.RawMessage `json:"params,omitempty"`
+}
+
+// Response is read from the stdout of an external plugin. Error is set if the method failed.
+type Response struct {
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// ExternalPlugin drives an out-of-tree plugin executable over the JSON-over-stdio protocol.
+// Every method of the Plugin interface is mapped to a request with the same method name, GetDependsOn returns the
+// plugins it depends on. Configs are encoded with the same keys as in the YAML config files, options and profiles as WireOptions and WireProfile.
+// Instead of a Kubernetes client, plugins receive the cluster access options in the "kubeconfig", "context", "as"
+// and "asGroups" params. An empty kubeconfig selects the standard loading rules, KUBECONFIG, ~/.kube/config or the in-cluster config.
+// Every request is bounded by callTimeout, except discovery and deployment which are bounded by their phase timeouts.
+type ExternalPlugin struct {
+	path      string
+	cluster   kubeclient.Options
+	name      string
+	version   string
+	dependsOn []string
+}
+
+// FindExternal looks up the executable of the named out-of-tree plugin (l8k-plugin-<name>),
+// first in pluginsDir if set, then in $PATH.
+func FindExternal(name, pluginsDir string) (string, error) {
+	if Reserved(name) {
+		return "", fmt.Errorf("plugin name %s is reserved", name)
+	}
+	executable := ExternalPluginPrefix + name
+	if pluginsDir != "" {
+		path := filepath.Join(pluginsDir, executable)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() && info.Mode()&0111 != 0 {
+			return path, nil
+		}
+	}
+
+	path, err := exec.LookPath(executable)
+	if err != nil {
+		return "", fmt.Errorf("plugin %s not found in plugins directory %q or $PATH: %w", name, pluginsDir, err)
+	}
+	return path, nil
+}
+
+// NewExternal starts the plugin executable at path to query its name, version and the plugins it depends on,
+// see Metadata.DependsOn, which it returns for the GetDependsOn method.
+// The cluster access options are passed to the methods accessing the cluster. Cancelling ctx aborts the pending requests.
+func NewExternal(ctx context.Context, path string, cluster kubeclient.Options) (*ExternalPlugin, error) {
+	p := &ExternalPlugin{path: path, cluster: cluster}
+
+	if err := p.call(ctx, callTimeout, "GetName", nil, &p.name); err != nil {
+		return nil, err
+	}
+	if err := p.call(ctx, callTimeout, "GetVersion", nil, &p.version); err != nil {
+		return nil, err
+	}
+	if err := p.call(ctx, callTimeout, "GetDependsOn", nil, &p.dependsOn); err != nil {
+		return nil, err
+	}
+
+	log.Log.V(1).Info("Loaded external plugin", "name", p.name, "version", p.version, "dependsOn", p.dependsOn, "path", path)
+	return p, nil
+}
+
+func (p *ExternalPlugin) GetName() string {
+	return p.name
+}
+
+func (p *ExternalPlugin) GetVersion() string {
+	return p.version
+}
+
+func (p *ExternalPlugin) ProfileConfiguredInCmd(ctx context.Context, options options.Options) bool {
+	var configured bool
+	if err := p.call(ctx, callTimeout, "ProfileConfiguredInCmd", map[string]any{"options": newWireOptions(options)}, &configured); err != nil {
+		log.Log.Error(err, "external plugin call failed", "plugin", p.name)
+		return false
+	}
+	return configured
+}
+
+func (p *ExternalPlugin) BuildProfileFromOptions(ctx context.Context, options options.Options, profile *config.Profile) error {
+	sent, err := toWire(profile)
+	if err != nil {
+		return fmt.Errorf("failed to encode profile for plugin %s: %w", p.path, err)
+	}
+	return p.call(ctx, callTimeout, "BuildProfileFromOptions", map[string]any{"options": newWireOptions(options), "profile": sent}, profile)
+}
+
+func (p *ExternalPlugin) BuildProfileFromLLMResponse(ctx context.Context, llmResponse map[string]string, profile *config.Profile) error {
+	sent, err := toWire(profile)
+	if err != nil {
+		return fmt.Errorf("failed to encode profile for plugin %s: %w", p.path, err)
+	}
+	return p.call(ctx, callTimeout, "BuildProfileFromLLMResponse", map[string]any{"llmResponse": llmResponse, "profile": sent}, profile)
+}
+
+func (p *ExternalPlugin) GetSystemPromptAddendum(ctx context.Context) (string, error) {
+	var addendum string
+	err := p.call(ctx, callTimeout, "GetSystemPromptAddendum", nil, &addendum)
+	return addendum, err
+}
+
+// DiscoverClusterConfig sends the default config and expects the config with the plugin-specific part discovered.
+// Only the fields changed by the plugin are merged into defaultConfig, the sections it drops or does not know are kept.
+func (p *ExternalPlugin) DiscoverClusterConfig(ctx context.Context, _ client.Client, defaultConfig *config.LaunchKubernetesConfig) error {
+	sent, err := toWire(defaultConfig)
+	if err != nil {
+		return fmt.Errorf("failed to encode config for plugin %s: %w", p.path, err)
+	}
+	var discovered json.RawMessage
+	if err := p.call(ctx, discoveryTimeout(defaultConfig), "DiscoverClusterConfig", p.withCluster(map[string]any{"config": sent}), &discovered); err != nil {
+		return err
+	}
+	return mergeDiscovered(defaultConfig, sent, discovered)
+}
+
+// mergeDiscovered decodes into cfg the fields of discovered that differ from sent, the config the plugin was given.
+// Decoding into cfg keeps its pointers, so the ClusterConfig stays shared with the other plugins, see the Plugin interface.
+func mergeDiscovered(cfg *config.LaunchKubernetesConfig, sent, discovered json.RawMessage) error {
+	var before, after map[string]any
+	if err := json.Unmarshal(sent, &before); err != nil {
+		return err
+	}
+	if len(discovered) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(discovered, &after); err != nil {
+		return fmt.Errorf("invalid discovered config: %w", err)
+	}
+	patch, err := json.Marshal(changedFields(before, after))
+	if err != nil {
+		return err
+	}
+	if err := fromWire(patch, cfg); err != nil {
+		return fmt.Errorf("invalid discovered config: %w", err)
+	}
+	return nil
+}
+
+// changedFields returns the fields of after that are missing from or differ from before, recursing into objects.
+// Lists and scalars are returned whole.
+func changedFields(before, after map[string]any) map[string]any {
+	changed := map[string]any{}
+	for key, value := range after {
+		previous, found := before[key]
+		if found && reflect.DeepEqual(previous, value) {
+			continue
+		}
+		previousObject, wasObject := previous.(map[string]any)
+		object, isObject := value.(map[string]any)
+		if wasObject && isObject {
+			changed[key] = changedFields(previousObject, object)
+			continue
+		}
+		changed[key] = value
+	}
+	return changed
+}
+
+// discoveryTimeout returns the timeout of the discovery phase, unlimited if the config has no timeouts
+func discoveryTimeout(cfg *config.LaunchKubernetesConfig) time.Duration {
+	if cfg == nil || cfg.Timeouts == nil {
+		return 0
+	}
+	return cfg.Timeouts.Discovery
+}
+
+// deploymentTimeout returns the timeout of the deployment phase, unlimited if the config has no timeouts
+func deploymentTimeout(cfg *config.LaunchKubernetesConfig) time.Duration {
+	if cfg == nil || cfg.Timeouts == nil {
+		return 0
+	}
+	return cfg.Timeouts.Deployment
+}
+
+func (p *ExternalPlugin) GenerateProfileDeploymentFiles(ctx context.Context, profile *profiles.Profile, config *config.LaunchKubernetesConfig) (map[string]string, error) {
+	sent, err := toWire(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config for plugin %s: %w", p.path, err)
+	}
+	files := map[string]string{}
+	err = p.call(ctx, callTimeout, "GenerateProfileDeploymentFiles", map[string]any{"profile": newWireProfile(profile), "config": sent}, &files)
+	return files, err
+}
+
+func (p *ExternalPlugin) DeployProfile(ctx context.Context, profile *profiles.Profile, config *config.LaunchKubernetesConfig, _ client.Client, manifestsDir string) error {
+	sent, err := toWire(config)
+	if err != nil {
+		return fmt.Errorf("failed to encode config for plugin %s: %w", p.path, err)
+	}
+	return p.call(ctx, deploymentTimeout(config), "DeployProfile", p.withCluster(map[string]any{
+		"profile":      newWireProfile(profile),
+		"config":       sent,
+		"manifestsDir": manifestsDir,
+	}), nil)
+}
+
+// withCluster adds the cluster access options to the params, the unset ones are omitted except kubeconfig
+func (p *ExternalPlugin) withCluster(params map[string]any) map[string]any {
+	params["kubeconfig"] = p.cluster.Kubeconfig
+	if p.cluster.Context != "" {
+		params["context"] = p.cluster.Context
+	}
+	if p.cluster.As != "" {
+		params["as"] = p.cluster.As
+	}
+	if len(p.cluster.AsGroups) > 0 {
+		params["asGroups"] = p.cluster.AsGroups
+	}
+	return params
+}
+
+// call executes the plugin with a request for method on stdin and decodes the result into result, if not nil.
+// The params are encoded as JSON, configs must be encoded with toWire beforehand. Results are decoded with fromWire.
+// The plugin is killed when ctx is done or after timeout, unless zero. The plugin's stderr is passed through, so that it can log.
+func (p *ExternalPlugin) call(ctx context.Context, timeout time.Duration, method string, params map[string]any, result any) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	request := Request{ProtocolVersion: ProtocolVersion, Method: method, Params: map[string]json.RawMessage{}}
+	for key, value := range params {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s for plugin %s: %w", key, p.path, err)
+		}
+		request.Params[key] = raw
+	}
+	input, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.path)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = applog.Stderr()
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("plugin %s did not complete %s: %w", p.path, method, ctx.Err())
+		}
+		return fmt.Errorf("plugin %s failed on %s: %w", p.path, method, err)
+	}
+
+	response := Response{}
+	if err := json.Unmarshal(stdout.Bytes(), &response); err != nil {
+		return fmt.Errorf("plugin %s returned an invalid response to %s: %w", p.path, method, err)
+	}
+	if response.Error != "" {
+		return errors.New(response.Error)
+	}
+	if raw, ok := result.(*json.RawMessage); ok {
+		*raw = response.Result
+		return nil
+	}
+	if result != nil && len(response.Result) > 0 {
+		if err := fromWire(response.Result, result); err != nil {
+			return fmt.Errorf("plugin %s returned an invalid result to %s: %w", p.path, method, err)
+		}
+	}
+	return nil
+}
+
+// toWire encodes v as JSON with the keys of its YAML representation
+func toWire(v any) (json.RawMessage, error) {
+	data, err := yamlv2.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.YAMLToJSON(data)
+}
+
+// fromWire decodes JSON produced by toWire (or a plugin) into v
+func fromWire(raw json.RawMessage, v any) error {
+	data, err := yaml.JSONToYAML(raw)
+	if err != nil {
+		return err
+	}
+	return yamlv2.Unmarshal(data, v)
+}
+
+var _ Plugin = &ExternalPlugin{}
@@ -0,0 +1,172 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+)
+
+func TestMergeDiscoveredKeepsUnchangedSections(t *testing.T) {
+	cluster := &config.ClusterConfig{WorkerNodes: []string{"node-1"}}
+	cfg := &config.LaunchKubernetesConfig{
+		NetworkOperator: &config.NetworkOperatorConfig{Namespace: "nvidia-network-operator", Version: "v25.7.0"},
+		NvIpam:          &config.NvIpamConfig{PoolName: "nv-ipam-pool"},
+		ClusterConfig:   cluster,
+	}
+	sent, err := toWire(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The plugin drops the sections it does not know and discovers the worker nodes
+	discovered := json.RawMessage(`{"networkOperator": {"namespace": "nvidia-network-operator", "version": "v25.7.0"}, "clusterConfig": {"workerNodes": ["node-1", "node-2"]}}`)
+
+	if err := mergeDiscovered(cfg, sent, discovered); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.NvIpam == nil || cfg.NvIpam.PoolName != "nv-ipam-pool" {
+		t.Errorf("nvIpam section not kept: %+v", cfg.NvIpam)
+	}
+	if cfg.ClusterConfig != cluster {
+		t.Errorf("ClusterConfig pointer not kept")
+	}
+	if len(cluster.WorkerNodes) != 2 {
+		t.Errorf("discovered worker nodes not merged: %v", cluster.WorkerNodes)
+	}
+}
+
+func TestChangedFields(t *testing.T) {
+	before := map[string]any{"a": map[string]any{"x": 1.0, "y": "same"}, "b": []any{"1"}, "c": "kept"}
+	after := map[string]any{"a": map[string]any{"x": 2.0, "y": "same"}, "b": []any{"1"}, "d": true}
+
+	changed := changedFields(before, after)
+	want := map[string]any{"a": map[string]any{"x": 2.0}, "d": true}
+	if got, _ := json.Marshal(changed); string(got) != mustMarshal(t, want) {
+		t.Errorf("changedFields() = %s, want %s", got, mustMarshal(t, want))
+	}
+}
+
+func TestCallTimeout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ExternalPluginPrefix+"slow")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\nexec sleep 10\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	p := &ExternalPlugin{path: path, cluster: kubeclient.Options{}}
+
+	start := time.Now()
+	err := p.call(context.Background(), 100*time.Millisecond, "GetName", nil, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("call() error = %v, want a deadline exceeded error", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("call() returned after %s, want the timeout to kill the plugin", elapsed)
+	}
+}
+
+func TestWireEncoding(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ExternalPluginPrefix+"echo")
+	// The plugin saves the request and returns the files of GenerateProfileDeploymentFiles
+	script := "#!/bin/sh\ncat > " + filepath.Join(dir, "request.json") + "\necho '{\"result\": {\"10-storage.yaml\": \"kind: Storage\"}}'\n"
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	p := &ExternalPlugin{path: path}
+	request := func() map[string]json.RawMessage {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(dir, "request.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req := Request{}
+		if err := json.Unmarshal(data, &req); err != nil {
+			t.Fatal(err)
+		}
+		return req.Params
+	}
+
+	opts := options.Options{
+		Fabric:         "ethernet",
+		DeploymentType: "sriov",
+		Multirail:      true,
+		EnabledPlugins: []string{"network-operator", "echo"},
+		LLMApiKey:      "secret",
+		Kubeconfig:     "/root/.kube/config",
+	}
+	if err := p.BuildProfileFromOptions(context.Background(), opts, &config.Profile{}); err != nil {
+		t.Fatal(err)
+	}
+	params := request()
+	wantOptions := `{"enabledPlugins":["network-operator","echo"],"fabric":"ethernet","deploymentType":"sriov","multirail":true,"spectrumX":false,"ai":false,` +
+		`"discoverClusterConfig":false,"installOperators":false,"deploy":false,"verify":false}`
+	if got := string(params["options"]); got != wantOptions {
+		t.Errorf("options = %s, want %s", got, wantOptions)
+	}
+	// Configs keep the keys of the config files
+	wantProfile := `{"ai":false,"deployment":"","fabric":"","multirail":false,"spectrumX":false}`
+	if got := string(params["profile"]); got != wantProfile {
+		t.Errorf("profile = %s, want %s", got, wantProfile)
+	}
+
+	profile := &profiles.Profile{
+		Name:                "Storage",
+		Plugin:              "echo",
+		ProfileRequirements: profiles.ProfileRequirements{Fabric: profiles.Values{"ethernet", "infiniband"}},
+		DeploymentGuide:     "profiles/storage/README.md",
+		Templates:           []string{"profiles/storage/10-storage.yaml"},
+	}
+	cfg := &config.LaunchKubernetesConfig{NetworkOperator: &config.NetworkOperatorConfig{Namespace: "nvidia-network-operator"}}
+	files, err := p.GenerateProfileDeploymentFiles(context.Background(), profile, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if files["10-storage.yaml"] != "kind: Storage" {
+		t.Errorf("unexpected files: %v", files)
+	}
+	params = request()
+	wantProfile = `{"name":"Storage","plugin":"echo","profileRequirements":{"fabric":["ethernet","infiniband"]},"nodeCapabilities":{},` +
+		`"deploymentGuide":"profiles/storage/README.md","templates":["profiles/storage/10-storage.yaml"]}`
+	if got := string(params["profile"]); got != wantProfile {
+		t.Errorf("profile = %s, want %s", got, wantProfile)
+	}
+	var sentConfig map[string]any
+	if err := json.Unmarshal(params["config"], &sentConfig); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := sentConfig["networkOperator"]; !ok {
+		t.Errorf("config not encoded with the keys of the config files: %s", params["config"])
+	}
+}
+
+func mustMarshal(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DependsOn returns the plugins p must run after: the DependsOn of the registration of a built-in plugin,
+// or the dependencies an external plugin returned for GetDependsOn
+func DependsOn(p Plugin) []string {
+	if external, ok := p.(*ExternalPlugin); ok {
+		return external.dependsOn
+	}
+	if r, ok := Lookup(p.GetName()); ok {
+		return r.DependsOn
+	}
+	return nil
+}
+
+// Order sorts the enabled plugins so that every plugin comes after the enabled plugins it depends on.
+// Dependencies on plugins that are not enabled are ignored. Independent plugins keep their order in names,
+// so that the result is deterministic. An error is returned if the dependencies form a cycle.
+func Order(names []string, dependsOn func(name string) []string) ([]string, error) {
+	// number of unsorted dependencies per plugin, and the plugins depending on each plugin
+	pending := map[string]int{}
+	dependents := map[string][]string{}
+	unique := []string{}
+	for _, name := range names {
+		if _, ok := pending[name]; !ok {
+			pending[name] = 0
+			unique = append(unique, name)
+		}
+	}
+	for _, name := range unique {
+		for _, dependency := range dependsOn(name) {
+			if _, enabled := pending[dependency]; !enabled {
+				continue
+			}
+			pending[name]++
+			dependents[dependency] = append(dependents[dependency], name)
+		}
+	}
+
+	sorted := make([]string, 0, len(unique))
+	for len(sorted) < len(unique) {
+		next := ""
+		for _, name := range unique {
+			if count, ok := pending[name]; ok && count == 0 {
+				next = name
+				break
+			}
+		}
+		if next == "" {
+			cycle := []string{}
+			for _, name := range unique {
+				if _, ok := pending[name]; ok {
+					cycle = append(cycle, name)
+				}
+			}
+			return nil, fmt.Errorf("plugin dependencies form a cycle between: %s", strings.Join(cycle, ", "))
+		}
+
+		delete(pending, next)
+		sorted = append(sorted, next)
+		for _, dependent := range dependents[next] {
+			pending[dependent]--
+		}
+	}
+
+	return sorted, nil
+}
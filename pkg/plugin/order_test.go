@@ -0,0 +1,133 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+)
+
+func TestOrder(t *testing.T) {
+	tests := []struct {
+		name      string
+		names     []string
+		dependsOn map[string][]string
+		want      []string
+		wantErr   string
+	}{
+		{
+			name:  "independent plugins keep their order",
+			names: []string{"nic-configuration", "network-operator", "storage"},
+			want:  []string{"nic-configuration", "network-operator", "storage"},
+		},
+		{
+			name:      "dependencies come first",
+			names:     []string{"gpu-operator", "nic-configuration", "network-operator"},
+			dependsOn: map[string][]string{"gpu-operator": {"network-operator"}, "nic-configuration": {"network-operator"}},
+			want:      []string{"network-operator", "gpu-operator", "nic-configuration"},
+		},
+		{
+			name:      "transitive dependencies",
+			names:     []string{"a", "b", "c"},
+			dependsOn: map[string][]string{"a": {"b"}, "b": {"c"}},
+			want:      []string{"c", "b", "a"},
+		},
+		{
+			name:      "dependencies on disabled plugins are ignored",
+			names:     []string{"gpu-operator", "storage"},
+			dependsOn: map[string][]string{"gpu-operator": {"network-operator"}},
+			want:      []string{"gpu-operator", "storage"},
+		},
+		{
+			name:      "duplicates are removed",
+			names:     []string{"gpu-operator", "network-operator", "gpu-operator"},
+			dependsOn: map[string][]string{"gpu-operator": {"network-operator"}},
+			want:      []string{"network-operator", "gpu-operator"},
+		},
+		{
+			name:      "cycle",
+			names:     []string{"storage", "a", "b"},
+			dependsOn: map[string][]string{"a": {"b"}, "b": {"a"}},
+			wantErr:   "plugin dependencies form a cycle between: a, b",
+		},
+		{
+			name:      "self dependency",
+			names:     []string{"a"},
+			dependsOn: map[string][]string{"a": {"a"}},
+			wantErr:   "plugin dependencies form a cycle between: a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Order(tt.names, func(name string) []string { return tt.dependsOn[name] })
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("Order() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Order() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDependsOnOfExternalPlugin(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ExternalPluginPrefix+"storage")
+	script := `#!/bin/sh
+case "$(cat)" in
+*'"GetName"'*) echo '{"result": "storage"}' ;;
+*'"GetVersion"'*) echo '{"result": "1.0.0"}' ;;
+*'"GetDependsOn"'*) echo '{"result": ["network-operator"]}' ;;
+*) echo '{"error": "unknown method"}' ;;
+esac
+`
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := NewExternal(context.Background(), path, kubeclient.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := DependsOn(p); !reflect.DeepEqual(got, []string{"network-operator"}) {
+		t.Errorf("DependsOn() = %v, want [network-operator]", got)
+	}
+	order, err := Order([]string{"storage", "network-operator"}, func(name string) []string {
+		if name == "storage" {
+			return DependsOn(p)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(order, ",") != "network-operator,storage" {
+		t.Errorf("Order() = %v, want the external plugin after its dependency", order)
+	}
+}
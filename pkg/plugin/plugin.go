@@ -18,16 +18,25 @@ package plugin
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
 	"github.com/nvidia/k8s-launch-kit/pkg/options"
 	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// APIVersion is the version of the Plugin interface implemented by this version of the tool.
+// A plugin is compatible if the major version returned by GetVersion matches.
+const APIVersion = "1.0.0"
+
 // Plugin defines the interface to implement for tool support for the Launch Kit.
-// To integrate a new tool, implement this interface and add the plugin to the plugins map in the main package.
-// CLI flags and config type should be defined in the tool, not in the plugin.
+// To integrate a new tool, implement this interface and register the plugin with Register from an init function of its package,
+// or ship it as an out-of-tree l8k-plugin-<name> executable speaking the protocol of ExternalPlugin.
+// Plugin-specific CLI flags and their validation are contributed with the Registration, the config type is defined in the tool.
 // Configuration templates should be stored in the tool's directory. Additional make targets can be used for template provisioning.
 type Plugin interface {
 	// GetName returns the name of the plugin. The name will be used to enable / disable plugins and match profiles.
@@ -35,18 +44,175 @@ type Plugin interface {
 	// GetVersion returns the version of the plugin. The version will be used to check if the plugin is compatible with the current version of the tool.
 	GetVersion() string
 	// ProfileConfiguredInCmd returns true if the profile is configured for the plugin based on the options.
-	ProfileConfiguredInCmd(options options.Options) bool
+	ProfileConfiguredInCmd(ctx context.Context, options options.Options) bool
 	// BuildProfileFromOptions builds the profile for the plugin based on the options.
-	BuildProfileFromOptions(options options.Options, profile *config.Profile) error
+	BuildProfileFromOptions(ctx context.Context, options options.Options, profile *config.Profile) error
 	// BuildProfileFromLLMResponse builds the profile for the plugin based on the LLM response.
-	BuildProfileFromLLMResponse(llmResponse map[string]string, profile *config.Profile) error
+	BuildProfileFromLLMResponse(ctx context.Context, llmResponse map[string]string, profile *config.Profile) error
 	// GetSystemPromptAddendum returns the addendum to the system prompt, specific to the plugin. The addendum will be used to add additional context to the system prompt.
-	GetSystemPromptAddendum() (string, error)
+	GetSystemPromptAddendum(ctx context.Context) (string, error)
 	// DiscoverClusterConfig discovers the plugin-specific part of the cluster configuration and adds it to the given LaunchKubernetesConfig.
 	// Should not reassign defaultConfig.ClusterConfig, only edit it.
 	DiscoverClusterConfig(ctx context.Context, kubeClient client.Client, defaultConfig *config.LaunchKubernetesConfig) error
 	// GenerateProfileDeploymentFiles generates the deployment files for the profile.
-	GenerateProfileDeploymentFiles(profile *profiles.Profile, config *config.LaunchKubernetesConfig) (map[string]string, error)
-	// DeployProfile deploys the profile to the cluster.
-	DeployProfile(ctx context.Context, profile *profiles.Profile, kubeClient client.Client, manifestsDir string) error
+	GenerateProfileDeploymentFiles(ctx context.Context, profile *profiles.Profile, config *config.LaunchKubernetesConfig) (map[string]string, error)
+	// DeployProfile deploys the profile to the cluster. Waits should honor config.Timeouts and the ctx cancellation.
+	// It returns once the deployed components are ready, which gates the deployment of the plugins depending on it.
+	DeployProfile(ctx context.Context, profile *profiles.Profile, config *config.LaunchKubernetesConfig, kubeClient client.Client, manifestsDir string) error
+}
+
+// Verifier is implemented by the plugins able to verify their deployed profiles, by running the verification workload
+// declared in the profile (see profiles.Verification). It is optional, the profiles of other plugins are not verified.
+type Verifier interface {
+	// VerifyProfile runs the verification workload of the deployed profile and returns the result of every check.
+	// An error means the verification could not run. The objects created for the verification are removed before returning.
+	VerifyProfile(ctx context.Context, profile *profiles.Profile, config *config.LaunchKubernetesConfig, kubeClient client.Client) ([]VerificationResult, error)
+}
+
+// VerificationResult is the outcome of a verification check between two nodes
+type VerificationResult struct {
+	Check     string // e.g. ib_write_bw
+	Network   string // network or workload the check ran on, e.g. sriov-test-pod-rail0
+	Server    string // node of the server pod
+	Client    string // node of the client pod
+	Passed    bool
+	Value     string // measured value, e.g. 96.95 Gb/s
+	Threshold string // threshold the value was compared to, empty if not checked
+	Message   string // reason of a failure
+}
+
+// StatusReporter is implemented by the plugins able to report the health of their deployed profiles, for l8k status.
+// It is optional, the profiles of other plugins are skipped.
+type StatusReporter interface {
+	// ProfileStatus returns the state of the objects of the profile found in the cluster and of the components they deploy.
+	// The expected objects are those of deployed, the deployment files of the plugin in the latest revision of the history
+	// by file name, or of the profile rendered with config if deployed is nil, i.e. no revision deployed the profile.
+	// An error means the status could not be collected, unhealthy components and missing nodes are reported in the status.
+	ProfileStatus(ctx context.Context, profile *profiles.Profile, config *config.LaunchKubernetesConfig, deployed map[string]string, kubeClient client.Client) (*ProfileStatus, error)
+}
+
+// Watcher is implemented by the plugins able to refresh their part of the discovered cluster config as the cluster changes,
+// for l8k watch. It is optional, the cluster config of other plugins is not refreshed.
+type Watcher interface {
+	// WatchedObjects returns the kinds of objects whose changes invalidate the discovered cluster config
+	WatchedObjects() []client.Object
+	// RefreshClusterConfig updates the cluster config of config from the current state of the cluster without deploying anything,
+	// and returns the capabilities of every worker node to check the deployed profiles against.
+	RefreshClusterConfig(ctx context.Context, config *config.LaunchKubernetesConfig, kubeClient client.Client) (map[string]config.NodesCapabilities, error)
+}
+
+// Installer is implemented by the plugins able to install or upgrade their operator in the cluster, e.g. with its Helm chart,
+// before discovery with --install-operators. It is optional, the operators of other plugins must be installed beforehand.
+// The permissions of the installation are checked before it runs, see PhaseInstall.
+type Installer interface {
+	// InstallOperator installs or upgrades the operator with settings derived from the profile requirements, nil if they are
+	// not known before discovery, e.g. with --prompt, and from the cluster. It returns once the operator is ready, within
+	// config.Timeouts.OperatorInstall.
+	InstallOperator(ctx context.Context, requirements *config.Profile, config *config.LaunchKubernetesConfig, kubeClient client.Client, cluster kubeclient.Options) error
+	// OperatorObjects returns the objects InstallOperator creates or updates, e.g. the rendered Helm chart, for the
+	// preflight permission checks. The permissions of its other requests are declared with PermissionRequirer.
+	OperatorObjects(ctx context.Context, requirements *config.Profile, config *config.LaunchKubernetesConfig, kubeClient client.Client, cluster kubeclient.Options) ([]*unstructured.Unstructured, error)
+}
+
+// Phases of the workflow whose permissions are checked before they run, see PermissionRequirer
+const (
+	PhaseInstall  = "install" // --install-operators, the profile is nil and config.Profile holds the requirements
+	PhaseDiscover = "discover"
+	PhaseDeploy   = "deploy"
+	PhaseVerify   = "verify"
+	PhaseStatus   = "status"
+	PhaseWatch    = "watch"
+)
+
+// PermissionRequirer is implemented by the plugins declaring the Kubernetes permissions their phases need, for the preflight
+// checks run before discovery and deployment. The permissions on the objects of the deployment files, and on the kinds of
+// WatchedObjects, are added by l8k and need not be declared. It is optional, other plugins are checked for those only.
+type PermissionRequirer interface {
+	// Permissions returns the permissions of the requests made to run phase, one of the Phase constants, with config.
+	// profile is nil for PhaseInstall and PhaseDiscover. Requests whose failure is tolerated should be left out.
+	Permissions(phase string, profile *profiles.Profile, config *config.LaunchKubernetesConfig) ([]kubeclient.Permission, error)
+}
+
+// PreflightChecker is implemented by the plugins checking the prerequisites of their profiles in the cluster, e.g. operators,
+// CRDs or node features, before the deployment. It is optional, the served kinds of the deployment files are checked by l8k.
+type PreflightChecker interface {
+	// PreflightChecks returns the findings of the checks of the prerequisites of the profile, nothing if they are met.
+	// An error means the checks could not run.
+	PreflightChecks(ctx context.Context, profile *profiles.Profile, config *config.LaunchKubernetesConfig, kubeClient client.Client) ([]PreflightFinding, error)
+}
+
+// Severities of the preflight findings
+const (
+	SeverityBlocker = "blocker" // the deployment would fail or break the cluster, it is not run
+	SeverityWarning = "warning" // the deployment may not work as expected
+)
+
+// PreflightFinding is an unmet prerequisite of a profile found by the preflight checks
+type PreflightFinding struct {
+	Check    string `json:"check"` // e.g. crd, operator-version, nfd, multus, kernel, secure-boot
+	Severity string `json:"severity"`
+	Object   string `json:"object,omitempty"` // node or object the finding is about
+	Message  string `json:"message"`
+}
+
+// ProfileStatus is the health of a deployed profile
+type ProfileStatus struct {
+	Profile    string            `json:"profile"`
+	Plugin     string            `json:"plugin"`
+	Revision   int               `json:"revision,omitempty"` // revision of the history the objects are compared to, 0 if rendered
+	Healthy    bool              `json:"healthy"`
+	Objects    []ObjectStatus    `json:"objects"`
+	Components []ComponentStatus `json:"components,omitempty"`
+	Nodes      []NodeStatus      `json:"nodes,omitempty"`
+	Pools      []PoolStatus      `json:"pools,omitempty"`
+}
+
+// ObjectStatus is the state of an object of the profile, or of another object of its kinds managed by l8k
+type ObjectStatus struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	State     string `json:"state"`             // e.g. ready, Running, Missing
+	Managed   bool   `json:"managed"`           // labelled as managed by l8k
+	InProfile bool   `json:"inProfile"`         // false for managed objects the profile no longer renders
+	Message   string `json:"message,omitempty"` // reason of an unhealthy state
+}
+
+// ComponentStatus is the state of a component deployed by an object of the profile, e.g. a NicClusterPolicy applied state
+type ComponentStatus struct {
+	Name    string `json:"name"`
+	State   string `json:"state"`
+	Message string `json:"message,omitempty"`
+}
+
+// NodeStatus is the state of the profile on a node
+type NodeStatus struct {
+	Node        string            `json:"node"`
+	State       string            `json:"state"`                // Ready, NotReady, or Missing if the node is not in the cluster
+	SyncStatus  string            `json:"syncStatus,omitempty"` // SR-IOV node state, e.g. Succeeded, InProgress, Failed
+	SyncError   string            `json:"syncError,omitempty"`
+	Allocatable map[string]string `json:"allocatable"` // allocatable device plugin resources of the profile
+}
+
+// PoolStatus is the allocation usage of an IP pool of the profile
+type PoolStatus struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Subnet    string `json:"subnet"`
+	Nodes     int    `json:"nodes"`     // nodes with an allocated block
+	Allocated int    `json:"allocated"` // addresses of the allocated blocks
+	Capacity  int    `json:"capacity"`  // usable addresses of the subnet
+}
+
+// CheckCompatibility returns an error if the plugin version is not compatible with APIVersion
+func CheckCompatibility(p Plugin) error {
+	if majorVersion(p.GetVersion()) != majorVersion(APIVersion) {
+		return fmt.Errorf("plugin %s version %s is not compatible with plugin API version %s", p.GetName(), p.GetVersion(), APIVersion)
+	}
+	return nil
+}
+
+func majorVersion(version string) string {
+	major, _, _ := strings.Cut(strings.TrimPrefix(version, "v"), ".")
+	return major
 }
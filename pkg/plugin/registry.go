@@ -0,0 +1,108 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/history"
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+	"github.com/spf13/pflag"
+)
+
+// Metadata describes the capabilities of a built-in plugin
+type Metadata struct {
+	// Name of the plugin, as used in --enabled-plugins and in the plugin field of profiles
+	Name string
+	// Version of the plugin, checked with CheckCompatibility
+	Version string
+	// ProfileRequirements are the keys of the profile section of the config (e.g. fabric, deployment) the plugin profiles are matched on
+	ProfileRequirements []string
+	// RequiredFlags are the CLI flags, without dashes, that must be set when the plugin is enabled
+	RequiredFlags []string
+	// NeedsKubeconfig is true if the plugin needs cluster access even when neither discovery nor deployment is requested
+	NeedsKubeconfig bool
+	// DependsOn are the plugins that, when enabled too, are discovered, generated and deployed before this one.
+	// Deployment of this plugin starts only after DeployProfile of its dependencies returned, i.e. they are ready.
+	DependsOn []string
+}
+
+// Registration is what a built-in plugin registers with Register, usually from an init function of its package
+type Registration struct {
+	Metadata
+
+	// New returns a new instance of the plugin
+	New func() Plugin
+	// AddFlags adds the plugin-specific CLI flags to flags, bound to fields of options. Optional.
+	AddFlags func(flags *pflag.FlagSet, options *options.Options)
+	// Validate checks the options of the plugin flags, it is called only when the plugin is enabled. Optional.
+	Validate func(options options.Options) error
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Registration{}
+)
+
+// Reserved returns whether name can not be used by a plugin. The deployment files of every plugin are saved
+// in a directory named after it, next to the history of the deployments.
+func Reserved(name string) bool {
+	return name == history.DirName
+}
+
+// Register makes a built-in plugin available by its name.
+// It panics if the registration is incomplete, the name is reserved or a plugin with the same name is already registered.
+func Register(r Registration) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if r.Name == "" || r.New == nil {
+		panic("plugin: Register requires a name and a constructor")
+	}
+	if Reserved(r.Name) {
+		panic(fmt.Sprintf("plugin: Register called with the reserved name %s", r.Name))
+	}
+	if _, ok := registry[r.Name]; ok {
+		panic(fmt.Sprintf("plugin: Register called twice for plugin %s", r.Name))
+	}
+	registry[r.Name] = r
+}
+
+// Lookup returns the registration of the built-in plugin with the given name
+func Lookup(name string) (Registration, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	r, ok := registry[name]
+	return r, ok
+}
+
+// Registered returns the registrations of all built-in plugins, sorted by name
+func Registered() []Registration {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	registrations := make([]Registration, 0, len(registry))
+	for _, r := range registry {
+		registrations = append(registrations, r)
+	}
+	slices.SortFunc(registrations, func(a, b Registration) int { return strings.Compare(a.Name, b.Name) })
+	return registrations
+}
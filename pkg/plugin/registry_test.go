@@ -0,0 +1,40 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/history"
+)
+
+func TestReservedNames(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Register must panic with the reserved name %s", history.DirName)
+		}
+		if _, ok := Lookup(history.DirName); ok {
+			t.Errorf("a plugin with a reserved name must not be registered")
+		}
+	}()
+
+	if _, err := FindExternal(history.DirName, t.TempDir()); err == nil || !strings.Contains(err.Error(), "reserved") {
+		t.Errorf("FindExternal() error = %v, want a reserved name", err)
+	}
+	Register(Registration{Metadata: Metadata{Name: history.DirName}, New: func() Plugin { return nil }})
+}
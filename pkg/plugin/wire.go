@@ -0,0 +1,93 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+)
+
+// The params and results of the external plugin protocol that are not configs. Configs, i.e. config.LaunchKubernetesConfig
+// and config.Profile, are encoded with the same keys as in the YAML config files, see toWire.
+
+// WireOptions are the command line options sent to external plugins as the "options" param of ProfileConfiguredInCmd
+// and BuildProfileFromOptions. Secrets, such as the LLM API key, and the cluster access options are not sent.
+type WireOptions struct {
+	LogLevel       string   `json:"logLevel,omitempty"`
+	EnabledPlugins []string `json:"enabledPlugins,omitempty"`
+
+	// Profile selection, see config.Profile
+	Fabric         string `json:"fabric,omitempty"`
+	DeploymentType string `json:"deploymentType,omitempty"`
+	Multirail      bool   `json:"multirail"`
+	SpectrumX      bool   `json:"spectrumX"`
+	Ai             bool   `json:"ai"`
+	GPUDirect      string `json:"gpuDirect,omitempty"`
+
+	// Phases of the workflow run after the profile selection
+	DiscoverClusterConfig bool   `json:"discoverClusterConfig"`
+	InstallOperators      bool   `json:"installOperators"`
+	SaveDeploymentFiles   string `json:"saveDeploymentFiles,omitempty"`
+	Deploy                bool   `json:"deploy"`
+	Verify                bool   `json:"verify"`
+}
+
+// newWireOptions returns the wire encoding of o
+func newWireOptions(o options.Options) WireOptions {
+	return WireOptions{
+		LogLevel:              o.LogLevel,
+		EnabledPlugins:        o.EnabledPlugins,
+		Fabric:                o.Fabric,
+		DeploymentType:        o.DeploymentType,
+		Multirail:             o.Multirail,
+		SpectrumX:             o.SpectrumX,
+		Ai:                    o.Ai,
+		GPUDirect:             o.GPUDirect,
+		DiscoverClusterConfig: o.DiscoverClusterConfig,
+		InstallOperators:      o.InstallOperators,
+		SaveDeploymentFiles:   o.SaveDeploymentFiles,
+		Deploy:                o.Deploy,
+		Verify:                o.Verify,
+	}
+}
+
+// WireProfile is a profile of the plugin, loaded from its profile.yaml, sent to external plugins as the "profile" param
+// of GenerateProfileDeploymentFiles and DeployProfile. Templates are the paths of the template files of the profile.
+type WireProfile struct {
+	Name                string                       `json:"name"`
+	Plugin              string                       `json:"plugin"`
+	Description         string                       `json:"description,omitempty"`
+	ProfileRequirements profiles.ProfileRequirements `json:"profileRequirements"`
+	NodeCapabilities    profiles.NodeCapabilities    `json:"nodeCapabilities"`
+	DeploymentGuide     string                       `json:"deploymentGuide,omitempty"`
+	Templates           []string                     `json:"templates"`
+	Verification        *profiles.Verification       `json:"verification,omitempty"`
+}
+
+// newWireProfile returns the wire encoding of profile
+func newWireProfile(profile *profiles.Profile) WireProfile {
+	return WireProfile{
+		Name:                profile.Name,
+		Plugin:              profile.Plugin,
+		Description:         profile.Description,
+		ProfileRequirements: profile.ProfileRequirements,
+		NodeCapabilities:    profile.NodeCapabilities,
+		DeploymentGuide:     profile.DeploymentGuide,
+		Templates:           profile.Templates,
+		Verification:        profile.Verification,
+	}
+}
@@ -27,18 +27,22 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+// ProfileRequirements are matched against the profile section of the config, see Values
 type ProfileRequirements struct {
-	Fabric     string `yaml:"fabric"`
-	Deployment string `yaml:"deployment"`
-	Multirail  *bool  `yaml:"multirail"`
-	SpectrumX  *bool  `yaml:"spectrumX"`
-	Ai         *bool  `yaml:"ai"`
+	Fabric     Values `yaml:"fabric" json:"fabric,omitempty"`
+	Deployment Values `yaml:"deployment" json:"deployment,omitempty"`
+	Multirail  Values `yaml:"multirail" json:"multirail,omitempty"`
+	SpectrumX  Values `yaml:"spectrumX" json:"spectrumX,omitempty"`
+	Ai         Values `yaml:"ai" json:"ai,omitempty"`
+	GPUDirect  Values `yaml:"gpuDirect" json:"gpuDirect,omitempty"`
 }
 
+// NodeCapabilities are matched against the discovered capabilities of the nodes, see Values
 type NodeCapabilities struct {
-	Sriov *bool `yaml:"sriov"`
-	Rdma  *bool `yaml:"rdma"`
-	Ib    *bool `yaml:"ib"`
+	Sriov Values `yaml:"sriov" json:"sriov,omitempty"`
+	Rdma  Values `yaml:"rdma" json:"rdma,omitempty"`
+	Ib    Values `yaml:"ib" json:"ib,omitempty"`
+	Gpu   Values `yaml:"gpu" json:"gpu,omitempty"`
 }
 
 type Profile struct {
@@ -49,6 +53,15 @@ type Profile struct {
 	NodeCapabilities    NodeCapabilities    `yaml:"nodeCapabilities"`
 	DeploymentGuide     string
 	Templates           []string
+	Verification        *Verification `yaml:"verification,omitempty"`
+}
+
+// Verification declares how a deployed profile is verified by the plugins implementing plugin.Verifier
+type Verification struct {
+	// Workload is the template of the test pods, one per network, run as server and client pairs on two nodes
+	Workload string `yaml:"workload" json:"workload"`
+	// Tests run between every pair, in order: rping, ib_write_bw, ib_write_lat
+	Tests []string `yaml:"tests" json:"tests"`
 }
 
 const ProfilesDir = "profiles"
@@ -100,57 +113,40 @@ func FindApplicableProfile(requirements *config.Profile, capabilities *config.Cl
 	return nil, errors.New("no applicable profile found")
 }
 
+// Validate returns true if every requirement and node capability of the profile matches the selected profile settings
+// and the cluster capabilities, otherwise the reason of the first mismatch. A profile requiring node capabilities is
+// not applicable when the capabilities are unknown.
 func (p *Profile) Validate(requirements *config.Profile, capabilities *config.ClusterCapabilities) (bool, string) {
 	log.Log.V(1).Info("Validating profile", "profile", p)
 
-	if p.ProfileRequirements.Fabric != "" && p.ProfileRequirements.Fabric != requirements.Fabric {
-		return false, fmt.Sprintf("selected fabric type does not match profile requirements: %s", p.ProfileRequirements.Fabric)
+	var nodes *config.NodesCapabilities
+	if capabilities != nil {
+		nodes = capabilities.Nodes
 	}
-
-	if p.ProfileRequirements.Deployment != "" && p.ProfileRequirements.Deployment != requirements.Deployment {
-		return false, fmt.Sprintf("selected deployment type does not match profile requirements: %s", p.ProfileRequirements.Deployment)
-	}
-
-	if p.ProfileRequirements.Multirail != nil && *p.ProfileRequirements.Multirail != requirements.Multirail {
-		return false, fmt.Sprintf("selected multirail setting does not match profile requirements: %t", *p.ProfileRequirements.Multirail)
-	}
-
-	if p.ProfileRequirements.SpectrumX != nil {
-		if !(*p.ProfileRequirements.SpectrumX) && !requirements.SpectrumX {
-			return false, fmt.Sprintf("profile is not applicable to Spectrum-X clusters: %t", *p.ProfileRequirements.SpectrumX)
-		}
-		if *p.ProfileRequirements.SpectrumX && requirements.SpectrumX {
-			return false, fmt.Sprintf("profile can obly be deployed on Spectrum-X clusters: %t", *p.ProfileRequirements.SpectrumX)
-		}
-	}
-
-	if p.ProfileRequirements.Ai != nil {
-		if !(*p.ProfileRequirements.Ai) && requirements.Ai {
-			return false, fmt.Sprintf("profile is not applicable to AI clusters: %t", *p.ProfileRequirements.Ai)
+	for _, matcher := range requirementMatchers {
+		values := matcher.values(p)
+		if matcher.nodeCapability && nodes == nil {
+			if len(values) > 0 {
+				return false, fmt.Sprintf("%s is unknown, the cluster config has no node capabilities: %s", matcher.description, values)
+			}
+			continue
 		}
-		if *p.ProfileRequirements.Ai && !requirements.Ai {
-			return false, fmt.Sprintf("profile can only be deployed on AI clusters: %t", *p.ProfileRequirements.Ai)
+		if !values.Matches(matcher.actual(requirements, nodes)) {
+			return false, fmt.Sprintf("%s does not match profile requirements: %s", matcher.description, values)
 		}
 	}
 
-	if p.NodeCapabilities.Sriov != nil && *p.NodeCapabilities.Sriov != capabilities.Nodes.Sriov {
-		return false, fmt.Sprintf("cluster sriov capability does not match profile requirements: %t", *p.NodeCapabilities.Sriov)
-	}
-	if p.NodeCapabilities.Rdma != nil && *p.NodeCapabilities.Rdma != capabilities.Nodes.Rdma {
-		return false, fmt.Sprintf("cluster rdma capability does not match profile requirements: %t", *p.NodeCapabilities.Rdma)
-	}
-	if p.NodeCapabilities.Ib != nil && *p.NodeCapabilities.Ib != capabilities.Nodes.Ib {
-		return false, fmt.Sprintf("cluster ib capability does not match profile requirements: %t", *p.NodeCapabilities.Ib)
-	}
-
 	return true, ""
 }
 
-// UpdateManifestsPaths appends the directory path to the templates and deployment guide
+// UpdateManifestsPaths appends the directory path to the templates, deployment guide and verification workload
 func (p *Profile) UpdateManifestsPaths(dirPath string) {
 	for i := range p.Templates {
 		p.Templates[i] = filepath.Join(dirPath, p.Templates[i])
 	}
 
 	p.DeploymentGuide = filepath.Join(dirPath, p.DeploymentGuide)
+	if p.Verification != nil {
+		p.Verification.Workload = filepath.Join(dirPath, p.Verification.Workload)
+	}
 }
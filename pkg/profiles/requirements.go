@@ -0,0 +1,149 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package profiles
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+)
+
+// Values is a profile requirement on a setting of the cluster. It lists the values the profile can be deployed with,
+// values prefixed with "!" are forbidden, and an empty list matches any value. It is unmarshaled from a single value
+// or a list, e.g. `fabric: [ethernet, infiniband]`, `spectrumX: true` (required), `spectrumX: false` or
+// `deployment: "!host_device"` (forbidden).
+type Values []string
+
+// UnmarshalYAML accepts a single value or a list of values of any scalar type
+func (v *Values) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var list []interface{}
+	if err := unmarshal(&list); err != nil {
+		var single interface{}
+		if err := unmarshal(&single); err != nil {
+			return err
+		}
+		list = []interface{}{single}
+	}
+
+	*v = nil
+	for _, value := range list {
+		if value != nil {
+			*v = append(*v, fmt.Sprint(value))
+		}
+	}
+	return nil
+}
+
+// Matches returns true if value is one of the allowed values, when there are any, and none of the forbidden values
+func (v Values) Matches(value string) bool {
+	hasAllowed, allowed := false, false
+	for _, requirement := range v {
+		if forbidden, ok := strings.CutPrefix(requirement, "!"); ok {
+			if forbidden == value {
+				return false
+			}
+			continue
+		}
+		hasAllowed = true
+		allowed = allowed || requirement == value
+	}
+	return allowed || !hasAllowed
+}
+
+// Requires returns true if value is the only allowed value
+func (v Values) Requires(value string) bool {
+	allowed := []string{}
+	for _, requirement := range v {
+		if !strings.HasPrefix(requirement, "!") {
+			allowed = append(allowed, requirement)
+		}
+	}
+	return len(allowed) == 1 && allowed[0] == value
+}
+
+func (v Values) String() string {
+	return strings.Join(v, ", ")
+}
+
+// requirementMatcher matches a requirement of a profile against the selected profile settings or the cluster capabilities
+type requirementMatcher struct {
+	description string
+	values      func(p *Profile) Values
+	actual      func(requirements *config.Profile, nodes *config.NodesCapabilities) string
+	// nodeCapability is set if actual reads the node capabilities, which are unknown without a discovered cluster config
+	nodeCapability bool
+}
+
+// requirementMatchers are checked in order by Profile.Validate, the first mismatch is reported
+var requirementMatchers = []requirementMatcher{
+	{
+		description: "selected fabric type",
+		values:      func(p *Profile) Values { return p.ProfileRequirements.Fabric },
+		actual:      func(r *config.Profile, _ *config.NodesCapabilities) string { return r.Fabric },
+	},
+	{
+		description: "selected deployment type",
+		values:      func(p *Profile) Values { return p.ProfileRequirements.Deployment },
+		actual:      func(r *config.Profile, _ *config.NodesCapabilities) string { return r.Deployment },
+	},
+	{
+		description: "selected multirail setting",
+		values:      func(p *Profile) Values { return p.ProfileRequirements.Multirail },
+		actual:      func(r *config.Profile, _ *config.NodesCapabilities) string { return strconv.FormatBool(r.Multirail) },
+	},
+	{
+		description: "selected Spectrum-X setting",
+		values:      func(p *Profile) Values { return p.ProfileRequirements.SpectrumX },
+		actual:      func(r *config.Profile, _ *config.NodesCapabilities) string { return strconv.FormatBool(r.SpectrumX) },
+	},
+	{
+		description: "selected AI setting",
+		values:      func(p *Profile) Values { return p.ProfileRequirements.Ai },
+		actual:      func(r *config.Profile, _ *config.NodesCapabilities) string { return strconv.FormatBool(r.Ai) },
+	},
+	{
+		description: "selected GPUDirect mode",
+		values:      func(p *Profile) Values { return p.ProfileRequirements.GPUDirect },
+		actual:      func(r *config.Profile, _ *config.NodesCapabilities) string { return r.GPUDirect },
+	},
+	{
+		description:    "cluster sriov capability",
+		values:         func(p *Profile) Values { return p.NodeCapabilities.Sriov },
+		actual:         func(_ *config.Profile, n *config.NodesCapabilities) string { return strconv.FormatBool(n.Sriov) },
+		nodeCapability: true,
+	},
+	{
+		description:    "cluster rdma capability",
+		values:         func(p *Profile) Values { return p.NodeCapabilities.Rdma },
+		actual:         func(_ *config.Profile, n *config.NodesCapabilities) string { return strconv.FormatBool(n.Rdma) },
+		nodeCapability: true,
+	},
+	{
+		description:    "cluster ib capability",
+		values:         func(p *Profile) Values { return p.NodeCapabilities.Ib },
+		actual:         func(_ *config.Profile, n *config.NodesCapabilities) string { return strconv.FormatBool(n.Ib) },
+		nodeCapability: true,
+	},
+	{
+		description:    "cluster gpu capability",
+		values:         func(p *Profile) Values { return p.NodeCapabilities.Gpu },
+		actual:         func(_ *config.Profile, n *config.NodesCapabilities) string { return strconv.FormatBool(n.Gpu) },
+		nodeCapability: true,
+	},
+}
@@ -0,0 +1,217 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package profiles
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"gopkg.in/yaml.v2"
+)
+
+func TestValuesUnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want Values
+	}{
+		{name: "string", yaml: `fabric: ethernet`, want: Values{"ethernet"}},
+		{name: "bool", yaml: `fabric: true`, want: Values{"true"}},
+		{name: "forbidden", yaml: `fabric: "!host_device"`, want: Values{"!host_device"}},
+		{name: "list", yaml: `fabric: [ethernet, infiniband]`, want: Values{"ethernet", "infiniband"}},
+		{name: "list of bools", yaml: `fabric: [true, false]`, want: Values{"true", "false"}},
+		{name: "empty list", yaml: `fabric: []`, want: nil},
+		{name: "null", yaml: `fabric: null`, want: nil},
+		{name: "missing", yaml: `other: x`, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requirements struct {
+				Fabric Values `yaml:"fabric"`
+			}
+			if err := yaml.Unmarshal([]byte(tt.yaml), &requirements); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(requirements.Fabric, tt.want) {
+				t.Errorf("Values = %#v, want %#v", requirements.Fabric, tt.want)
+			}
+		})
+	}
+}
+
+func TestValuesMatches(t *testing.T) {
+	tests := []struct {
+		name   string
+		values Values
+		value  string
+		want   bool
+	}{
+		{name: "empty matches anything", values: nil, value: "ethernet", want: true},
+		{name: "empty matches empty", values: nil, value: "", want: true},
+		{name: "scalar match", values: Values{"ethernet"}, value: "ethernet", want: true},
+		{name: "scalar mismatch", values: Values{"ethernet"}, value: "infiniband", want: false},
+		{name: "list match", values: Values{"sriov", "rdma_shared"}, value: "rdma_shared", want: true},
+		{name: "list mismatch", values: Values{"sriov", "rdma_shared"}, value: "host_device", want: false},
+		{name: "forbidden", values: Values{"!host_device"}, value: "host_device", want: false},
+		{name: "not forbidden", values: Values{"!host_device"}, value: "sriov", want: true},
+		{name: "allowed and forbidden", values: Values{"sriov", "!rdma_shared"}, value: "rdma_shared", want: false},
+		{name: "bool required", values: Values{"true"}, value: "false", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.values.Matches(tt.value); got != tt.want {
+				t.Errorf("%v.Matches(%q) = %v, want %v", tt.values, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValuesRequires(t *testing.T) {
+	tests := []struct {
+		name   string
+		values Values
+		value  string
+		want   bool
+	}{
+		{name: "empty", values: nil, value: "true", want: false},
+		{name: "single value", values: Values{"true"}, value: "true", want: true},
+		{name: "other value", values: Values{"false"}, value: "true", want: false},
+		{name: "several values", values: Values{"true", "false"}, value: "true", want: false},
+		{name: "forbidden values are ignored", values: Values{"true", "!false"}, value: "true", want: true},
+		{name: "forbidden only", values: Values{"!false"}, value: "true", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.values.Requires(tt.value); got != tt.want {
+				t.Errorf("%v.Requires(%q) = %v, want %v", tt.values, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProfileValidate(t *testing.T) {
+	spectrumX := &Profile{ProfileRequirements: ProfileRequirements{Fabric: Values{"ethernet"}, SpectrumX: Values{"true"}}}
+	notSpectrumX := &Profile{ProfileRequirements: ProfileRequirements{Fabric: Values{"ethernet"}, SpectrumX: Values{"false"}}}
+	rdma := &Profile{NodeCapabilities: NodeCapabilities{Rdma: Values{"true"}}}
+	capabilities := &config.ClusterCapabilities{Nodes: &config.NodesCapabilities{Rdma: true}}
+
+	tests := []struct {
+		name         string
+		profile      *Profile
+		requirements *config.Profile
+		capabilities *config.ClusterCapabilities
+		want         bool
+		wantReason   string
+	}{
+		{name: "Spectrum-X profile on Spectrum-X", profile: spectrumX, requirements: &config.Profile{Fabric: "ethernet", SpectrumX: true}, capabilities: capabilities, want: true},
+		{name: "Spectrum-X profile without Spectrum-X", profile: spectrumX, requirements: &config.Profile{Fabric: "ethernet"}, capabilities: capabilities, wantReason: "Spectrum-X"},
+		{name: "other profile on Spectrum-X", profile: notSpectrumX, requirements: &config.Profile{Fabric: "ethernet", SpectrumX: true}, capabilities: capabilities, wantReason: "Spectrum-X"},
+		{name: "other profile without Spectrum-X", profile: notSpectrumX, requirements: &config.Profile{Fabric: "ethernet"}, capabilities: capabilities, want: true},
+		{name: "capability present", profile: rdma, requirements: &config.Profile{}, capabilities: capabilities, want: true},
+		{name: "capability missing", profile: rdma, requirements: &config.Profile{}, capabilities: &config.ClusterCapabilities{Nodes: &config.NodesCapabilities{}}, wantReason: "rdma"},
+		{name: "nil capabilities", profile: rdma, requirements: &config.Profile{}, capabilities: nil, wantReason: "no node capabilities"},
+		{name: "nil nodes", profile: rdma, requirements: &config.Profile{}, capabilities: &config.ClusterCapabilities{}, wantReason: "no node capabilities"},
+		{name: "nil capabilities without capability requirements", profile: spectrumX, requirements: &config.Profile{Fabric: "ethernet", SpectrumX: true}, capabilities: nil, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason := tt.profile.Validate(tt.requirements, tt.capabilities)
+			if got != tt.want {
+				t.Errorf("Validate() = %v (%s), want %v", got, reason, tt.want)
+			}
+			if !strings.Contains(reason, tt.wantReason) {
+				t.Errorf("Validate() reason = %q, want it to contain %q", reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestProfileValidateEveryRequirement(t *testing.T) {
+	// The profile settings and node capabilities the cases are matched against
+	requirements := &config.Profile{Fabric: "ethernet", Deployment: "sriov", Multirail: true, SpectrumX: false, Ai: true, GPUDirect: "rdma"}
+	nodes := &config.NodesCapabilities{Sriov: true, Rdma: true, Ib: false, Gpu: true}
+
+	tests := []struct {
+		description string
+		set         func(p *Profile, values Values)
+		actual      string
+		other       string
+	}{
+		{description: "selected fabric type", set: func(p *Profile, v Values) { p.ProfileRequirements.Fabric = v }, actual: "ethernet", other: "infiniband"},
+		{description: "selected deployment type", set: func(p *Profile, v Values) { p.ProfileRequirements.Deployment = v }, actual: "sriov", other: "host_device"},
+		{description: "selected multirail setting", set: func(p *Profile, v Values) { p.ProfileRequirements.Multirail = v }, actual: "true", other: "false"},
+		{description: "selected Spectrum-X setting", set: func(p *Profile, v Values) { p.ProfileRequirements.SpectrumX = v }, actual: "false", other: "true"},
+		{description: "selected AI setting", set: func(p *Profile, v Values) { p.ProfileRequirements.Ai = v }, actual: "true", other: "false"},
+		{description: "selected GPUDirect mode", set: func(p *Profile, v Values) { p.ProfileRequirements.GPUDirect = v }, actual: "rdma", other: "storage"},
+		{description: "cluster sriov capability", set: func(p *Profile, v Values) { p.NodeCapabilities.Sriov = v }, actual: "true", other: "false"},
+		{description: "cluster rdma capability", set: func(p *Profile, v Values) { p.NodeCapabilities.Rdma = v }, actual: "true", other: "false"},
+		{description: "cluster ib capability", set: func(p *Profile, v Values) { p.NodeCapabilities.Ib = v }, actual: "false", other: "true"},
+		{description: "cluster gpu capability", set: func(p *Profile, v Values) { p.NodeCapabilities.Gpu = v }, actual: "true", other: "false"},
+	}
+
+	if len(tests) != len(requirementMatchers) {
+		t.Fatalf("%d requirements tested, %d matchers: add the new matchers to the test", len(tests), len(requirementMatchers))
+	}
+	for i, tt := range tests {
+		matcher := requirementMatchers[i]
+		if matcher.description != tt.description {
+			t.Fatalf("matcher %d is %q, want %q", i, matcher.description, tt.description)
+		}
+
+		cases := []struct {
+			name   string
+			values Values
+			want   bool
+		}{
+			{name: "no requirement", values: nil, want: true},
+			{name: "required", values: Values{tt.actual}, want: true},
+			{name: "other value required", values: Values{tt.other}, want: false},
+			{name: "listed", values: Values{tt.other, tt.actual}, want: true},
+			{name: "forbidden", values: Values{"!" + tt.actual}, want: false},
+			{name: "other value forbidden", values: Values{"!" + tt.other}, want: true},
+		}
+		for _, c := range cases {
+			t.Run(tt.description+"/"+c.name, func(t *testing.T) {
+				profile := &Profile{}
+				tt.set(profile, c.values)
+
+				got, reason := profile.Validate(requirements, &config.ClusterCapabilities{Nodes: nodes})
+				if got != c.want {
+					t.Fatalf("Validate() = %v (%s), want %v", got, reason, c.want)
+				}
+				if !got && !strings.Contains(reason, tt.description) {
+					t.Errorf("Validate() reason = %q, want it to name the %s", reason, tt.description)
+				}
+
+				// Without node capabilities, only the requirements on the selected settings can be checked
+				got, reason = profile.Validate(requirements, &config.ClusterCapabilities{})
+				want := c.want
+				if matcher.nodeCapability {
+					want = len(c.values) == 0
+				}
+				if got != want {
+					t.Errorf("Validate() without node capabilities = %v (%s), want %v", got, reason, want)
+				}
+				if !got && matcher.nodeCapability && !strings.Contains(reason, "no node capabilities") {
+					t.Errorf("Validate() reason without node capabilities = %q, want it to tell they are unknown", reason)
+				}
+			})
+		}
+	}
+}
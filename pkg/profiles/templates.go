@@ -0,0 +1,116 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package profiles
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"text/template"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+)
+
+// templateFuncs provides helper functions for Go templates
+var templateFuncs = template.FuncMap{
+	"add": func(a, b int) int { return a + b },
+	"sub": func(a, b int) int { return a - b },
+	"gt":  func(a, b int) bool { return a > b },
+
+	"dict":         dict,
+	"deviceIDs":    deviceIDs,
+	"pciAddresses": pciAddresses,
+}
+
+// sharedTemplatesPattern matches the files of the profiles directory defining the named templates shared by the profiles,
+// e.g. profiles/nv-ipam-pools.tmpl
+const sharedTemplatesPattern = "*.tmpl"
+
+// dict returns a map of the given key and value pairs, to pass several arguments to a named template
+func dict(pairs ...any) (map[string]any, error) {
+	if len(pairs)%2 != 0 {
+		return nil, fmt.Errorf("dict expects key and value pairs, got %d arguments", len(pairs))
+	}
+	m := map[string]any{}
+	for i := 0; i < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("dict keys must be strings, got %v", pairs[i])
+		}
+		m[key] = pairs[i+1]
+	}
+	return m, nil
+}
+
+// deviceIDs returns the distinct device IDs of the PFs with the given traffic type, in PF order
+func deviceIDs(pfs []config.PFConfig, traffic string) []string {
+	ids := []string{}
+	for _, pf := range pfs {
+		if pf.Traffic == traffic && pf.DeviceID != "" && !slices.Contains(ids, pf.DeviceID) {
+			ids = append(ids, pf.DeviceID)
+		}
+	}
+	return ids
+}
+
+// pciAddresses returns the PCI addresses of the PFs with the given traffic type and device ID
+func pciAddresses(pfs []config.PFConfig, traffic, deviceID string) []string {
+	addresses := []string{}
+	for _, pf := range pfs {
+		if pf.Traffic == traffic && pf.DeviceID == deviceID {
+			addresses = append(addresses, pf.PciAddress)
+		}
+	}
+	return addresses
+}
+
+// ProcessTemplate processes a Go template file with the given config. The template can include the named templates
+// of the shared template files in the profiles directory, the parent of the profile directory of the template.
+func ProcessTemplate(templatePath string, config *config.LaunchKubernetesConfig) (string, error) {
+	// Read the template file
+	templateContent, err := os.ReadFile(templatePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read template file %s: %w", templatePath, err)
+	}
+
+	// Parse the shared templates, then the template with helper functions
+	tmpl := template.New(filepath.Base(templatePath)).Funcs(templateFuncs)
+	shared, err := filepath.Glob(filepath.Join(filepath.Dir(filepath.Dir(templatePath)), sharedTemplatesPattern))
+	if err != nil {
+		return "", err
+	}
+	if len(shared) > 0 {
+		if tmpl, err = tmpl.ParseFiles(shared...); err != nil {
+			return "", fmt.Errorf("failed to parse shared templates: %w", err)
+		}
+	}
+	tmpl, err = tmpl.Parse(string(templateContent))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template %s: %w", templatePath, err)
+	}
+
+	// Execute the template
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, config)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute template %s: %w", templatePath, err)
+	}
+
+	return buf.String(), nil
+}